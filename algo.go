@@ -0,0 +1,315 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// defaultCompared names the schedulers compared when none are given.
+var defaultCompared = []string{"fcfs", "sjf", "priority", "priority-np", "rr"}
+
+// DefaultAlgorithms are the schedulers compared when none are given, with the default quantum.
+var DefaultAlgorithms []Algorithm
+
+// builtinServer is the aperiodic server the server scheduler runs requests in.
+var builtinServer = AperiodicServer{Kind: DeferrableServer, Budget: 1, Period: 4}
+
+// init registers the built-in schedulers under the names -algo runs them by, with the parameters main
+// would run them with.
+func init() {
+	register("fcfs", registration{
+		title: "First-come, first-serve",
+		run:   func(p []Process, _ runParams) Result { return fcfs(p) },
+	})
+	register("sjf", registration{
+		title: "Shortest-job-first",
+		run: func(p []Process, _ runParams) Result {
+			return eventDriven(p, func(_ Process, remaining int64) int64 { return remaining })
+		},
+	})
+	register("sjf-priority", registration{
+		title: "Priority",
+		run: func(p []Process, _ runParams) Result {
+			return eventDriven(p, func(p Process, _ int64) int64 { return p.Priority })
+		},
+	})
+	register("ljf", registration{
+		title: "Longest-job-first",
+		run:   func(p []Process, _ runParams) Result { return simulate(p, policy{pick: longestRemaining}) },
+	})
+	register("lrtf", registration{
+		title: "Longest-remaining-time-first",
+		run: func(p []Process, _ runParams) Result {
+			return simulate(p, policy{pick: longestRemaining, preemptive: true})
+		},
+	})
+	register("priority", registration{
+		title: "Preemptive priority",
+		run: func(p []Process, _ runParams) Result {
+			return simulate(p, policy{pick: highestPriority, preemptive: true})
+		},
+	})
+	register("priority-np", registration{
+		title: "Non-preemptive priority",
+		run:   func(p []Process, _ runParams) Result { return simulate(p, policy{pick: highestPriority}) },
+	})
+	register("priority-rr", registration{
+		title: "Priority round-robin",
+		run: func(p []Process, params runParams) Result {
+			return simulate(p, policy{pick: highestPriority, preemptive: true, quantum: params.quantum})
+		},
+	})
+	register("rr", registration{
+		title: "Round-robin",
+		run:   func(p []Process, params runParams) Result { return roundRobin(p, params.quantum) },
+	})
+	register("mlq", registration{
+		title: "Multilevel queue",
+		run: func(p []Process, _ runParams) Result {
+			return multilevelQueue(p, DefaultQueueLevels, StrictPriority)
+		},
+		schedule: func(w io.Writer, title string, p []Process, _ runParams) {
+			MultilevelQueueSchedule(w, title, p, DefaultQueueLevels, StrictPriority)
+		},
+		noBursts: true,
+	})
+	register("cfs", registration{
+		title: "Completely fair",
+		run: func(p []Process, _ runParams) Result {
+			r, _ := cfs(p, DefaultCFSParams)
+			return r
+		},
+		schedule: func(w io.Writer, title string, p []Process, _ runParams) {
+			CFSSchedule(w, title, p, DefaultCFSParams)
+		},
+		noBursts: true,
+	})
+	register("edf", registration{
+		title: "Earliest deadline first",
+		run: func(p []Process, _ runParams) Result {
+			return simulate(p, policy{pick: earliestDeadline, preemptive: true})
+		},
+	})
+	register("rm", registration{
+		title: "Rate monotonic",
+		run:   func(p []Process, _ runParams) Result { return rateMonotonic(p, 1) },
+		schedule: func(w io.Writer, title string, p []Process, _ runParams) {
+			RMSchedule(w, title, p, 1)
+		},
+	})
+	register("server", registration{
+		title: "Deferrable server",
+		run: func(p []Process, _ runParams) Result {
+			r, _ := serve(expandPeriodic(p, 1), builtinServer)
+			return r
+		},
+		schedule: func(w io.Writer, title string, p []Process, _ runParams) {
+			AperiodicServerSchedule(w, title, p, builtinServer, 1)
+		},
+		noBursts: true,
+	})
+	register("feedback", registration{
+		title: "Feedback",
+		run: func(p []Process, _ runParams) Result {
+			r, _, _ := feedback(p, 4, func(l int) int64 { return int64(1) << l })
+			return r
+		},
+		schedule: func(w io.Writer, title string, p []Process, _ runParams) {
+			FeedbackSchedule(w, title, p, 4)
+		},
+		noBursts: true,
+	})
+	register("fair-share", registration{
+		title: "Fair-share",
+		run:   func(p []Process, params runParams) Result { return fairShare(p, params.quantum) },
+		schedule: func(w io.Writer, title string, p []Process, params runParams) {
+			FairShareSchedule(w, title, p, params.quantum)
+		},
+		noBursts: true,
+	})
+	register("o1", registration{
+		title: "O(1)",
+		run: func(p []Process, _ runParams) Result {
+			r, _ := o1(p, DefaultO1Params)
+			return r
+		},
+		schedule: func(w io.Writer, title string, p []Process, _ runParams) {
+			O1Schedule(w, title, p, DefaultO1Params)
+		},
+		noBursts: true,
+	})
+	register("eevdf", registration{
+		title:    "EEVDF",
+		run:      func(p []Process, _ runParams) Result { return eevdf(p, DefaultEEVDFParams) },
+		noBursts: true,
+	})
+	register("bvt", registration{
+		title:    "Borrowed virtual time",
+		run:      func(p []Process, _ runParams) Result { return bvt(p, DefaultBVTParams) },
+		noBursts: true,
+	})
+	register("cbs", registration{
+		title:    "Constant bandwidth server",
+		run:      func(p []Process, _ runParams) Result { return cbs(p) },
+		noBursts: true,
+	})
+	register("random", registration{
+		title: "Random",
+		run:   func(p []Process, params runParams) Result { return randomDispatch(p, params.quantum, 1) },
+		schedule: func(w io.Writer, title string, p []Process, params runParams) {
+			RandomSchedule(w, title, p, params.quantum, 1)
+		},
+	})
+	register("lottery", registration{
+		title: "Lottery",
+		run:   func(p []Process, params runParams) Result { return lottery(p, params.quantum, 1) },
+		schedule: func(w io.Writer, title string, p []Process, params runParams) {
+			LotterySchedule(w, title, p, params.quantum, 1)
+		},
+	})
+	register("stride", registration{
+		title: "Stride",
+		run:   func(p []Process, params runParams) Result { return stride(p, params.quantum) },
+	})
+	register("gang", registration{
+		title: "Gang",
+		run: func(p []Process, params runParams) Result {
+			r, _ := gang(p, params.cpus, params.quantum)
+			return r
+		},
+		schedule: func(w io.Writer, title string, p []Process, params runParams) {
+			GangSchedule(w, title, p, params.cpus, params.quantum)
+		},
+		noBursts: true,
+	})
+	register("smp", registration{
+		title: "Symmetric multiprocessing",
+		run: func(p []Process, params runParams) Result {
+			r, _ := smp(p, SMPParams{CPUs: params.cpus, Quantum: params.quantum}, true)
+			return r
+		},
+		schedule: func(w io.Writer, title string, p []Process, params runParams) {
+			SMPSchedule(w, title, p, SMPParams{CPUs: params.cpus, Quantum: params.quantum})
+		},
+		noBursts: true,
+	})
+	register("realtime", registration{
+		title: "Real-time plus best-effort",
+		run: func(p []Process, params runParams) Result {
+			return realTime(p, RealTimeDeadline, params.quantum)
+		},
+		schedule: func(w io.Writer, title string, p []Process, params runParams) {
+			RealTimeSchedule(w, title, p, RealTimeDeadline, params.quantum)
+		},
+	})
+	register("locks", registration{
+		title: "Priority inheritance",
+		run:   func(p []Process, _ runParams) Result { return lockPriority(p, true) },
+		schedule: func(w io.Writer, title string, p []Process, _ runParams) {
+			LockSchedule(w, title, p, true)
+		},
+	})
+	register("memory", registration{
+		title: "Admission control",
+		run:   func(p []Process, params runParams) Result { return admitted(p, 1024, params.quantum) },
+		schedule: func(w io.Writer, title string, p []Process, params runParams) {
+			MemorySchedule(w, title, p, 1024, params.quantum)
+		},
+	})
+	register("suspend", registration{
+		title: "Suspend and resume",
+		run: func(p []Process, params runParams) Result {
+			return simulate(p, policy{pick: firstReady, quantum: params.quantum})
+		},
+	})
+	register("limits", registration{
+		title: "Execution limits",
+		run: func(p []Process, params runParams) Result {
+			return simulate(p, policy{pick: firstReady, quantum: params.quantum, excludeKilled: true})
+		},
+	})
+	register("hybrid", registration{
+		title: "SJF with aging",
+		run:   func(p []Process, _ runParams) Result { return agedSJF(p, 0.5) },
+		schedule: func(w io.Writer, title string, p []Process, _ runParams) {
+			AgedSJFSchedule(w, title, p, 0.5)
+		},
+	})
+	register("delay", registration{
+		title: "Non-work-conserving SJF",
+		run:   func(p []Process, _ runParams) Result { return delayedSJF(p, 2) },
+		schedule: func(w io.Writer, title string, p []Process, _ runParams) {
+			DelayedSJFSchedule(w, title, p, 2)
+		},
+	})
+	register("overhead", registration{
+		title: "SJF with decision overhead",
+		run:   func(p []Process, _ runParams) Result { return costedSJF(p, DecisionCost{Decision: 1, Scan: 1}) },
+		schedule: func(w io.Writer, title string, p []Process, _ runParams) {
+			OverheadSchedule(w, title, p, DecisionCost{Decision: 1, Scan: 1})
+		},
+	})
+
+	for _, reg := range defaultSchedulers() {
+		DefaultAlgorithms = append(DefaultAlgorithms, reg.algorithm(runParams{}))
+	}
+}
+
+// defaultSchedulers returns the schedulers compared when none are given.
+func defaultSchedulers() []registration {
+	regs := make([]registration, len(defaultCompared))
+	for i, name := range defaultCompared {
+		regs[i] = schedulers[name]
+	}
+	return regs
+}
+
+// parseAlgorithms returns the schedulers of a comma-separated list of names, built in or registered, in
+// the order given.
+func parseAlgorithms(list string) ([]registration, error) {
+	var selected []registration
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		reg, err := lookupScheduler(name)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, reg)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no algorithm named in %q", list)
+	}
+	return selected, nil
+}
+
+// normalize fills in the default quantum and number of CPUs where params give none.
+func (params runParams) normalize() runParams {
+	if params.quantum < 1 {
+		params.quantum = defaultQuantum
+	}
+	if params.cpus < 1 {
+		params.cpus = DefaultSMPParams.CPUs
+	}
+	return params
+}
+
+// algorithm is the scheduler as an algorithm run with params, so its runs can be compared or batched.
+func (reg registration) algorithm(params runParams) Algorithm {
+	params = params.normalize()
+	return Algorithm{Name: reg.title, Run: func(p []Process) Result { return reg.run(p, params) }}
+}
+
+// output outputs the schedule of processes by the scheduler, run with params, under title, leaving out what
+// else the scheduler reports when w has schedules output by a template or as quiet lines.
+func (reg registration) output(w io.Writer, title string, processes []Process, params runParams) {
+	params = params.normalize()
+	if reg.schedule != nil && !custom(w) {
+		reg.schedule(w, title, processes, params)
+		return
+	}
+	outputResult(w, title, reg.run(processes, params))
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_parseAlgorithms(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		list       string
+		wantTitles []string
+		wantErr    error
+	}{
+		{name: "one", list: "fcfs", wantTitles: []string{"First-come, first-serve"}},
+		{name: "in order given", list: "rr, sjf,sjf-priority", wantTitles: []string{"Round-robin", "Shortest-job-first", "Priority"}},
+		{name: "registered policy", list: "hrrn,fcfs", wantTitles: []string{"hrrn", "First-come, first-serve"}},
+		{name: "unknown", list: "fcfs,nope", wantErr: ErrUnknownPolicy},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseAlgorithms(tt.list)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseAlgorithms() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.wantTitles) {
+				t.Fatalf("parseAlgorithms() = %d algorithms, want %d", len(got), len(tt.wantTitles))
+			}
+			for i, a := range got {
+				if a.title != tt.wantTitles[i] {
+					t.Errorf("parseAlgorithms()[%d] = %q, want %q", i, a.title, tt.wantTitles[i])
+				}
+			}
+		})
+	}
+	if _, err := parseAlgorithms(" , "); err == nil {
+		t.Error("parseAlgorithms() of no names succeeded")
+	}
+}
+
+func Test_registration_output(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 3},
+	}
+	algorithms, err := parseAlgorithms("fcfs,sjf,rr,cfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, want bytes.Buffer
+	for _, reg := range algorithms {
+		reg.output(&got, reg.title, processes, runParams{quantum: 2})
+	}
+	FCFSSchedule(&want, "First-come, first-serve", processes)
+	SJFSchedule(&want, "Shortest-job-first", processes)
+	RRSchedule(&want, "Round-robin", processes, 2)
+	CFSSchedule(&want, "Completely fair", processes, DefaultCFSParams)
+	if got.String() != want.String() {
+		t.Errorf("output() = %v, want %v", got.String(), want.String())
+	}
+}
+
+func Test_registration_run(t *testing.T) {
+	t.Parallel()
+	// The last process arrives after the CPU has gone idle, which a schedule and a run of it must agree on.
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 3},
+		{ProcessID: 4, ArrivalTime: 12, BurstDuration: 2, Priority: 1},
+	}
+	params := runParams{quantum: 2, cpus: 2}
+	for _, name := range SchedulerNames() {
+		reg := schedulers[name]
+		a := reg.algorithm(params)
+		r := a.Run(processes)
+		if a.Name == "" || len(r.Processes) != len(processes) {
+			t.Errorf("%s: algorithm() = %q scheduling %d processes, want %d", name, a.Name, len(r.Processes), len(processes))
+		}
+		var got, want bytes.Buffer
+		reg.output(&got, a.Name, processes, params)
+		outputResult(&want, a.Name, r)
+		if !strings.HasPrefix(got.String(), want.String()) {
+			t.Errorf("%s: output() = %v, want it to start with the run's %v", name, got.String(), want.String())
+		}
+	}
+}
+
+func Test_registration_accepts(t *testing.T) {
+	t.Parallel()
+	single := []Process{{ProcessID: 1, BurstDuration: 4}}
+	bursty := []Process{{ProcessID: 1, BurstDuration: 4, Bursts: []int64{2, 3, 2}}}
+	tests := []struct {
+		name      string
+		scheduler string
+		processes []Process
+		wantErr   error
+	}{
+		{name: "simulator with bursts", scheduler: "priority", processes: bursty},
+		{name: "round-robin with bursts", scheduler: "rr", processes: bursty},
+		{name: "shortest-job-first with bursts", scheduler: "sjf", processes: bursty},
+		{name: "own engine without bursts", scheduler: "cfs", processes: single},
+		{name: "completely fair with bursts", scheduler: "cfs", processes: bursty, wantErr: ErrInvalidArgs},
+		{name: "multilevel queue with bursts", scheduler: "mlq", processes: bursty, wantErr: ErrInvalidArgs},
+		{name: "multiprocessor with bursts", scheduler: "smp", processes: bursty, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := schedulers[tt.scheduler].accepts(tt.processes); !errors.Is(err, tt.wantErr) {
+				t.Errorf("accepts() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
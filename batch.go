@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// batchFiles returns the scheduling files args name, and whether they are a batch to be scheduled one
+// after another rather than a single file. Each argument may be a file, a directory standing for the
+// files in it of a known format, or a glob pattern such as workloads/*.csv.
+func batchFiles(args []string) ([]string, bool, error) {
+	var (
+		files []string
+		batch = len(args) > 1
+	)
+	for _, arg := range args {
+		if strings.ContainsAny(arg, "*?[") {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				return nil, false, fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+			}
+			found := false
+			for _, m := range matches {
+				if info, err := os.Stat(m); err == nil && !info.IsDir() {
+					files, found = append(files, m), true
+				}
+			}
+			if !found {
+				return nil, false, fmt.Errorf("%w: no scheduling files match %q", ErrInvalidArgs, arg)
+			}
+			batch = true
+			continue
+		}
+		if info, err := os.Stat(arg); err != nil || !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, false, fmt.Errorf("%v: error reading scheduling directory", err)
+		}
+		for _, e := range entries {
+			ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(e.Name()), "."))
+			if !e.IsDir() && scenarioLoaders[ext] != nil {
+				files = append(files, filepath.Join(arg, e.Name()))
+			}
+		}
+		batch = true
+	}
+	if !batch {
+		return nil, false, nil
+	}
+	if len(files) == 0 {
+		return nil, false, fmt.Errorf("%w: no scheduling files in %v", ErrInvalidArgs, args)
+	}
+	sort.Strings(files)
+	return files, true, nil
+}
+
+// BatchSchedule outputs the schedule of each of several scheduling files in a GANTT chart and a table of
+// timing, titled with the file's name, followed by a table summarising them all given:
+// • an output writer
+// • the scheduling files
+// • a function loading the scenario in a file
+// • a function returning the algorithm to run on a scenario
+//
+// A file that fails to load is reported in the summary and the rest are still scheduled. Quiet output has
+// no summary, and reports the file in place of its line of metrics.
+func BatchSchedule(w io.Writer, files []string, load func(file string) (Scenario, error), algorithm func(s Scenario) Algorithm) {
+	var (
+		rows                      = make([][]string, 0, len(files))
+		unit                      = unitOf(w)
+		wait, turnaround, through float64
+		processes, ran            int
+	)
+	for _, file := range files {
+		s, err := load(file)
+		if err != nil {
+			if options(w).quiet != quietOff {
+				outputMetricsError(w, file, err)
+			}
+			rows = append(rows, []string{file, "-", "-", "-", "-", err.Error()})
+			continue
+		}
+		a := algorithm(s)
+		r := a.Run(s.Processes)
+		outputResult(w, file+": "+a.Name, r)
+
+		sum := summarize(r)
+		wait += sum.wait
+		turnaround += sum.turnaround
+		through += sum.throughput
+		processes += len(s.Processes)
+		ran++
+		rows = append(rows, []string{
+			file,
+			fmt.Sprint(len(s.Processes)),
+			unit.formatAverage(sum.wait),
+			unit.formatAverage(sum.turnaround),
+			unit.formatRate(sum.throughput),
+			"",
+		})
+	}
+
+	if options(w).quiet != quietOff {
+		// Each file's line of metrics stands in for its row.
+		return
+	}
+
+	footer := []string{fmt.Sprintf("%d of %d files", ran, len(files)), fmt.Sprint(processes), "", "", "", ""}
+	if ran > 0 {
+		n := float64(ran)
+		footer[2] = msg(w, "Average") + "\n" + unit.formatAverage(wait/n)
+		footer[3] = msg(w, "Average") + "\n" + unit.formatAverage(turnaround/n)
+		footer[4] = msg(w, "Average") + "\n" + unit.formatRate(through/n)
+	}
+
+	header := msgs(w, "File", "Processes", "Wait", "Turnaround", "Throughput", "Error")
+	if ran == len(files) {
+		// Every file loaded, so there are no errors to show.
+		header, footer = header[:5], footer[:5]
+		for i := range rows {
+			rows[i] = rows[i][:5]
+		}
+	}
+
+	outputTitle(w, msg(w, "Batch summary"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.AppendBulk(rows)
+	table.SetFooter(footer)
+	table.Render()
+}
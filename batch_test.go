@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_batchFiles(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for _, name := range []string{"b.csv", "a.json", "notes.txt", "c.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "old.csv"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	in := func(names ...string) []string {
+		for i, n := range names {
+			names[i] = filepath.Join(dir, n)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		want      []string
+		wantBatch bool
+		wantErr   error
+	}{
+		{name: "single file", args: in("b.csv")},
+		{name: "standard input"},
+		{name: "directory", args: []string{dir}, want: in("a.json", "b.csv", "c.yaml"), wantBatch: true},
+		{name: "glob", args: []string{filepath.Join(dir, "*.csv")}, want: in("b.csv"), wantBatch: true},
+		{name: "several files", args: in("c.yaml", "a.json"), want: in("a.json", "c.yaml"), wantBatch: true},
+		{name: "glob without matches", args: []string{filepath.Join(dir, "*.xlsx")}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, batch, err := batchFiles(tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("batchFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if batch != tt.wantBatch || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("batchFiles() = %v, %v, want %v, %v", got, batch, tt.want, tt.wantBatch)
+			}
+		})
+	}
+}
+
+func TestBatchSchedule(t *testing.T) {
+	t.Parallel()
+	load := func(file string) (Scenario, error) {
+		switch file {
+		case "a.csv":
+			return Scenario{Quantum: 2, Processes: []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 2, ArrivalTime: 1}}}, nil
+		case "b.csv":
+			return Scenario{Processes: []Process{{ProcessID: 1, BurstDuration: 2}}}, nil
+		}
+		return Scenario{}, ErrInvalidColumn
+	}
+	roundRobinQuantum := func(s Scenario) Algorithm {
+		return Algorithm{Name: "Round-robin", Run: func(p []Process) Result { return roundRobin(p, s.quantum()) }}
+	}
+
+	var w bytes.Buffer
+	BatchSchedule(&w, []string{"a.csv", "b.csv", "broken.csv"}, load, roundRobinQuantum)
+	if got, want := w.String(), loadFixture(t, "batch_test.txt"); got != want {
+		t.Errorf("BatchSchedule() = \n%v, want \n%v", got, want)
+	}
+}
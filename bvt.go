@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// BVTParams tunes the borrowed virtual time scheduler.
+type BVTParams struct {
+	// SwitchAllowance is how many ticks of real time another process's effective virtual time must lead by
+	// before it preempts the running process, limiting context switches between near equals.
+	SwitchAllowance int64
+}
+
+// DefaultBVTParams are the BVT tunables used when none are given.
+var DefaultBVTParams = BVTParams{SwitchAllowance: 2}
+
+// BVTSchedule outputs a borrowed virtual time schedule of processes in a GANTT chart and a table of timing
+// with each process's warp and final virtual time given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the BVT tunables
+//
+// Each process's actual virtual time advances inversely to its weight (see the nice column) while it runs.
+// Latency-sensitive processes borrow against their future CPU time by subtracting their warp, so the process
+// with the lowest effective virtual time (actual minus warp) is dispatched first. Arriving processes start at
+// the lowest actual virtual time of the runnable processes, so they cannot claim CPU time missed before arrival.
+func BVTSchedule(w io.Writer, title string, processes []Process, params BVTParams) {
+	outputResult(w, title, bvt(processes, params))
+}
+
+func bvt(processes []Process, params BVTParams) Result {
+	if params.SwitchAllowance < 0 {
+		params.SwitchAllowance = 0
+	}
+
+	var (
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		weight     = make([]float64, n)
+		actual     = make([]float64, n)
+		gantt      = make([]TimeSlice, 0)
+		runnable   = make([]int, 0, n)
+		current    = -1
+		svt        float64 // scheduler virtual time, the minimum actual virtual time of runnable processes
+		next       int
+		done       int
+		timer      int64
+	)
+
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+		weight[i] = niceWeight(processes[i].Nice)
+	}
+
+	effective := func(i int) float64 {
+		return actual[i] - float64(processes[i].Warp)
+	}
+
+	for done < n {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			i := order[next]
+			if actual[i] < svt {
+				actual[i] = svt
+			}
+			runnable = append(runnable, i)
+			next++
+		}
+
+		if len(runnable) == 0 {
+			// CPU is idle until the next arrival.
+			timer = processes[order[next]].ArrivalTime
+			continue
+		}
+
+		best := runnable[0]
+		for _, i := range runnable {
+			if effective(i) < effective(best) {
+				best = i
+			}
+		}
+		if current < 0 {
+			current = best
+		} else if allowance := float64(params.SwitchAllowance) * nice0Weight / weight[current]; effective(best) < effective(current)-allowance {
+			current = best
+		}
+
+		i := current
+		if remaining[i] > 0 {
+			gantt = appendGantt(gantt, processes[i], timer)
+			actual[i] += nice0Weight / weight[i]
+			remaining[i]--
+			timer++
+			gantt = setStop(gantt, timer)
+		}
+
+		if remaining[i] <= 0 {
+			for k := range runnable {
+				if runnable[k] == i {
+					runnable = append(runnable[:k], runnable[k+1:]...)
+					break
+				}
+			}
+			completion[i] = timer
+			done++
+			current = -1
+		}
+
+		if len(runnable) > 0 {
+			svt = actual[runnable[0]]
+			for _, j := range runnable {
+				if actual[j] < svt {
+					svt = actual[j]
+				}
+			}
+		}
+	}
+
+	r := newResult(processes, completion, gantt)
+	warp, virtual := make([]string, n), make([]string, n)
+	for i := range processes {
+		warp[i] = fmt.Sprint(processes[i].Warp)
+		virtual[i] = fmt.Sprintf("%.2f", actual[i])
+	}
+	r.Columns = append(r.Columns,
+		Column{Header: "Warp", Values: warp},
+		Column{Header: "Virtual time", Values: virtual})
+	return r
+}
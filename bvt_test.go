@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBVTSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		params    BVTParams
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "warped processes run on arrival",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 8,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   2,
+						BurstDuration: 2,
+						Warp:          6,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   3,
+						BurstDuration: 3,
+					},
+					{
+						ProcessID:     4,
+						ArrivalTime:   6,
+						BurstDuration: 2,
+						Warp:          6,
+					},
+				},
+				title:  "Borrowed virtual time",
+				params: DefaultBVTParams,
+			},
+			wantOut: loadFixture(t, "bvt_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			BVTSchedule(&w, tt.args.title, tt.args.processes, tt.args.params)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("BVTSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// cbsServer is the deadline server state of one process.
+type cbsServer struct {
+	relative  int64 // relative deadline
+	budget    int64 // runtime left in the current period
+	deadline  int64 // current absolute scheduling deadline
+	throttled int64 // time the budget is next replenished, if throttled
+	overruns  int64
+	waited    int64 // ticks spent throttled
+	misses    int64
+}
+
+// CBSSchedule outputs a constant bandwidth server (SCHED_DEADLINE style) schedule of processes in a GANTT chart
+// and a table of timing with budget overruns, throttled time and deadline misses, given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+//
+// Processes with a runtime and period are deadline servers: each may use runtime ticks of CPU per period and
+// is scheduled earliest deadline first. Its relative deadline is taken from the deadline of its first
+// instance, or its period when none is given. A server that exhausts its budget before its work is done
+// has overrun: it is throttled until its next period, when its budget is replenished and its deadline
+// postponed by a period. Other processes run first-come, first-serve whenever no server is eligible.
+func CBSSchedule(w io.Writer, title string, processes []Process) {
+	outputResult(w, title, cbs(processes))
+}
+
+func cbs(processes []Process) Result {
+	var (
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		servers    = make([]cbsServer, n)
+		gantt      = make([]TimeSlice, 0)
+		ready      = make([]int, 0, n)
+		next       int
+		done       int
+		timer      int64
+	)
+
+	isServer := func(i int) bool {
+		return processes[i].Runtime > 0 && processes[i].Period > 0
+	}
+
+	for i, p := range processes {
+		remaining[i] = p.BurstDuration
+		if !isServer(i) {
+			continue
+		}
+		servers[i].relative = p.Period
+		if p.Deadline > p.ArrivalTime {
+			servers[i].relative = p.Deadline - p.ArrivalTime
+		}
+	}
+
+	for done < n {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			i := order[next]
+			if isServer(i) {
+				servers[i].budget = processes[i].Runtime
+				servers[i].deadline = timer + servers[i].relative
+			}
+			ready = append(ready, i)
+			next++
+		}
+
+		wake := int64(math.MaxInt64)
+		if next < n {
+			wake = processes[order[next]].ArrivalTime
+		}
+		current := -1
+		for _, i := range ready {
+			s := &servers[i]
+			if isServer(i) && s.throttled > 0 {
+				if s.throttled > timer {
+					if s.throttled < wake {
+						wake = s.throttled
+					}
+					continue
+				}
+				s.budget = processes[i].Runtime
+				s.deadline += processes[i].Period
+				s.throttled = 0
+			}
+			switch {
+			case current < 0:
+				current = i
+			case isServer(i) && (!isServer(current) || s.deadline < servers[current].deadline):
+				current = i
+			}
+		}
+
+		if current < 0 {
+			// Every process is throttled or yet to arrive.
+			for _, i := range ready {
+				servers[i].waited += wake - timer
+			}
+			timer = wake
+			continue
+		}
+
+		i := current
+		if remaining[i] > 0 {
+			gantt = appendGantt(gantt, processes[i], timer)
+			remaining[i]--
+			timer++
+			gantt = setStop(gantt, timer)
+		}
+		for _, j := range ready {
+			if servers[j].throttled > 0 {
+				servers[j].waited++
+			}
+		}
+
+		if remaining[i] <= 0 {
+			if isServer(i) && timer > servers[i].deadline {
+				servers[i].misses++
+			}
+			for k := range ready {
+				if ready[k] == i {
+					ready = append(ready[:k], ready[k+1:]...)
+					break
+				}
+			}
+			completion[i] = timer
+			done++
+			continue
+		}
+
+		if s := &servers[i]; isServer(i) {
+			if s.budget--; s.budget <= 0 {
+				if timer > s.deadline {
+					s.misses++
+				}
+				s.overruns++
+				s.throttled = s.deadline - s.relative + processes[i].Period
+				if s.throttled <= timer {
+					// Already past the next period: replenish immediately.
+					s.throttled = timer
+				}
+			}
+		}
+	}
+
+	r := newResult(processes, completion, gantt)
+	overruns, throttled, misses := make([]string, n), make([]string, n), make([]string, n)
+	for i, s := range servers {
+		if !isServer(i) {
+			overruns[i], throttled[i], misses[i] = "-", "-", "-"
+			continue
+		}
+		overruns[i] = fmt.Sprint(s.overruns)
+		throttled[i] = fmt.Sprint(s.waited)
+		misses[i] = fmt.Sprint(s.misses)
+	}
+	r.Columns = append(r.Columns,
+		Column{Header: "Overruns", Values: overruns},
+		Column{Header: "Throttled", Values: throttled},
+		Column{Header: "Misses", Values: misses})
+	return r
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCBSSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "servers throttle around a best-effort process",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 5,
+						Runtime:       2,
+						Period:        4,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   0,
+						BurstDuration: 3,
+						Runtime:       1,
+						Period:        3,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   1,
+						BurstDuration: 4,
+					},
+				},
+				title: "Constant bandwidth server",
+			},
+			wantOut: loadFixture(t, "cbs_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			CBSSchedule(&w, tt.args.title, tt.args.processes)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("CBSSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type (
+	// CFSParams tunes the completely fair scheduler.
+	CFSParams struct {
+		// TargetLatency is the period in which every runnable process should run once.
+		TargetLatency int64
+		// MinGranularity is the shortest slice a process is given, stretching the period when many are runnable.
+		MinGranularity int64
+	}
+	// VruntimeSample is a process's virtual runtime at the end of one of its slices.
+	VruntimeSample struct {
+		TimeSlice
+		Vruntime float64
+	}
+)
+
+// DefaultCFSParams are the CFS tunables used when none are given.
+var DefaultCFSParams = CFSParams{TargetLatency: 6, MinGranularity: 1}
+
+// nice0Weight is the load weight of a process with a nice value of zero.
+const nice0Weight = 1024
+
+// CFSSchedule outputs a completely fair schedule of processes in a GANTT chart, a table of timing and
+// the virtual runtime of each process after every slice given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the CFS tunables
+func CFSSchedule(w io.Writer, title string, processes []Process, params CFSParams) {
+	r, samples := cfs(processes, params)
+	outputResult(w, title, r)
+	if len(samples) > 0 {
+		outputVruntimes(w, samples)
+	}
+}
+
+// cfs always runs the ready process with the lowest virtual runtime for a slice of the scheduling
+// period proportional to its weight. Virtual runtime grows more slowly for heavier (lower nice) processes,
+// and arriving processes start at the current minimum so they cannot monopolise the CPU.
+func cfs(processes []Process, params CFSParams) (Result, []VruntimeSample) {
+	if params.TargetLatency < 1 {
+		params.TargetLatency = DefaultCFSParams.TargetLatency
+	}
+	if params.MinGranularity < 1 {
+		params.MinGranularity = DefaultCFSParams.MinGranularity
+	}
+
+	var (
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		vruntime   = make([]float64, n)
+		gantt      = make([]TimeSlice, 0)
+		samples    = make([]VruntimeSample, 0)
+		ready      = make([]int, 0, n)
+		minVR      float64
+		next       int
+		done       int
+		timer      int64
+	)
+
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+	}
+
+	for done < n {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			i := order[next]
+			vruntime[i] = math.Max(vruntime[i], minVR)
+			ready = append(ready, i)
+			next++
+		}
+
+		if len(ready) == 0 {
+			// CPU is idle until the next arrival.
+			timer = processes[order[next]].ArrivalTime
+			continue
+		}
+
+		k := 0
+		for j := range ready {
+			if vruntime[ready[j]] < vruntime[ready[k]] {
+				k = j
+			}
+		}
+		i := ready[k]
+		ready = append(ready[:k], ready[k+1:]...)
+
+		slice := cfsSlice(processes, i, ready, params)
+		start := timer
+		for ran := int64(0); ran < slice && remaining[i] > 0; ran++ {
+			gantt = appendGantt(gantt, processes[i], timer)
+			vruntime[i] += nice0Weight / niceWeight(processes[i].Nice)
+			remaining[i]--
+			timer++
+			gantt = setStop(gantt, timer)
+		}
+
+		// min_vruntime only moves forward, tracking the smallest vruntime still runnable.
+		lowest := vruntime[i]
+		for _, j := range ready {
+			lowest = math.Min(lowest, vruntime[j])
+		}
+		minVR = math.Max(minVR, lowest)
+
+		if timer > start {
+			samples = append(samples, VruntimeSample{
+				TimeSlice: TimeSlice{PID: processes[i].ProcessID, Start: start, Stop: timer},
+				Vruntime:  vruntime[i],
+			})
+		}
+
+		if remaining[i] > 0 {
+			// Arrivals during the slice queue ahead of the preempted process.
+			for next < n && processes[order[next]].ArrivalTime <= timer {
+				j := order[next]
+				vruntime[j] = math.Max(vruntime[j], minVR)
+				ready = append(ready, j)
+				next++
+			}
+			ready = append(ready, i)
+			continue
+		}
+		completion[i] = timer
+		done++
+	}
+
+	return newResult(processes, completion, gantt), samples
+}
+
+// cfsSlice is process i's share of the scheduling period among itself and the ready processes.
+func cfsSlice(processes []Process, i int, ready []int, params CFSParams) int64 {
+	var (
+		weight = niceWeight(processes[i].Nice)
+		total  = weight
+		period = params.TargetLatency
+	)
+	for _, j := range ready {
+		total += niceWeight(processes[j].Nice)
+	}
+	if running := int64(len(ready) + 1); running*params.MinGranularity > period {
+		period = running * params.MinGranularity
+	}
+
+	slice := int64(float64(period) * weight / total)
+	if slice < params.MinGranularity {
+		slice = params.MinGranularity
+	}
+	return slice
+}
+
+// niceWeights is the Linux kernel's sched_prio_to_weight table of load weights for nice values -20 to 19;
+// neighbouring nice values differ in weight by about 1.25 times, which is worth about 10% of CPU time.
+var niceWeights = [...]int64{
+	/* -20 */ 88761, 71755, 56483, 46273, 36291,
+	/* -15 */ 29154, 23254, 18705, 14949, 11916,
+	/* -10 */ 9548, 7620, 6100, 4904, 3906,
+	/*  -5 */ 3121, 2501, 1991, 1586, 1277,
+	/*   0 */ 1024, 820, 655, 526, 423,
+	/*   5 */ 335, 272, 215, 172, 137,
+	/*  10 */ 110, 87, 70, 56, 45,
+	/*  15 */ 36, 29, 23, 18, 15,
+}
+
+const (
+	minNice = -20
+	maxNice = 19
+)
+
+// niceWeight is the load weight of a nice value, which is clamped to the range -20 to 19.
+func niceWeight(nice int64) float64 {
+	return float64(niceWeights[clamp(nice, minNice, maxNice)-minNice])
+}
+
+func outputVruntimes(w io.Writer, samples []VruntimeSample) {
+	_, _ = fmt.Fprintln(w, msg(w, "Vruntime progression"))
+	unit := unitOf(w)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "ID", "Start", "Stop", "Vruntime"))
+	for _, s := range samples {
+		table.Append([]string{
+			fmt.Sprint(s.PID),
+			unit.format(s.Start),
+			unit.format(s.Stop),
+			unit.formatFloat(s.Vruntime, 2),
+		})
+	}
+	table.Render()
+}
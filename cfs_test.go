@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCFSSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		params    CFSParams
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 6,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   0,
+						BurstDuration: 6,
+						Nice:          5,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   2,
+						BurstDuration: 3,
+					},
+				},
+				title:  "Completely fair",
+				params: DefaultCFSParams,
+			},
+			wantOut: loadFixture(t, "cfs_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			CFSSchedule(&w, tt.args.title, tt.args.processes, tt.args.params)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("CFSSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_niceWeight(t *testing.T) {
+	t.Parallel()
+	for nice, want := range map[int64]float64{-25: 88761, -20: 88761, -1: 1277, 0: 1024, 1: 820, 19: 15, 30: 15} {
+		if got := niceWeight(nice); got != want {
+			t.Errorf("niceWeight(%d) = %v, want %v", nice, got, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// chromeEvent is the part of a Trace Event Format event that a workload is built from. Times are in
+// microseconds.
+type chromeEvent struct {
+	Ph  string   `json:"ph"`
+	Ts  float64  `json:"ts"`
+	Dur *float64 `json:"dur"`
+	Pid int64    `json:"pid"`
+	Tid int64    `json:"tid"`
+}
+
+// chromeThread is one thread of a trace, identified by its process and thread.
+type chromeThread struct{ pid, tid int64 }
+
+// busy is an interval of a thread's trace, in microseconds, during which it was inside a duration event.
+type busy struct{ start, end float64 }
+
+// chromeTrace returns a loader of Chrome tracing JSON, as saved by chrome://tracing, Perfetto or any app
+// writing the Trace Event Format, either as an array of events or as an object holding them in
+// traceEvents. Each thread becomes a process numbered by its thread ID: arriving when its first duration
+// event began, with a burst of the time it spent inside duration events, both counted in ticks of
+// tick from the first of them. Nested and overlapping events are counted once, a B event never ended
+// lasts until the end of the trace, and events other than B, E and X are skipped. A thread ID shared by
+// threads of different processes is a duplicate process ID.
+func chromeTrace(tick time.Duration) func(r io.Reader) ([]Process, error) {
+	return func(r io.Reader) ([]Process, error) {
+		return loadChromeTrace(r, tick)
+	}
+}
+
+func loadChromeTrace(r io.Reader, tick time.Duration) ([]Process, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%w: reading Chrome trace", err)
+	}
+	var events []chromeEvent
+	if t := bytes.TrimSpace(raw); len(t) > 0 && t[0] == '{' {
+		var doc struct {
+			TraceEvents []chromeEvent `json:"traceEvents"`
+		}
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return nil, fmt.Errorf("%w: reading Chrome trace", err)
+		}
+		events = doc.TraceEvents
+	} else if err := json.Unmarshal(raw, &events); err != nil {
+		return nil, fmt.Errorf("%w: reading Chrome trace", err)
+	}
+
+	var (
+		intervals = make(map[chromeThread][]busy)
+		open      = make(map[chromeThread][]float64) // the starts of each thread's B events not yet ended
+		start     = math.Inf(1)
+		end       = math.Inf(-1)
+	)
+	for i, e := range events {
+		th := chromeThread{pid: e.Pid, tid: e.Tid}
+		switch e.Ph {
+		case "X":
+			if e.Dur == nil || *e.Dur < 0 {
+				return nil, fmt.Errorf("%w: event %d is complete but has no duration", ErrInvalidColumn, i+1)
+			}
+			intervals[th] = append(intervals[th], busy{e.Ts, e.Ts + *e.Dur})
+			end = math.Max(end, e.Ts+*e.Dur)
+		case "B":
+			open[th] = append(open[th], e.Ts)
+			end = math.Max(end, e.Ts)
+		case "E":
+			stack := open[th]
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: event %d ends a duration event thread %d never began", ErrInvalidColumn, i+1, e.Tid)
+			}
+			intervals[th] = append(intervals[th], busy{stack[len(stack)-1], e.Ts})
+			open[th] = stack[:len(stack)-1]
+			end = math.Max(end, e.Ts)
+		default:
+			continue
+		}
+		start = math.Min(start, e.Ts)
+	}
+	for th, stack := range open {
+		for _, s := range stack {
+			intervals[th] = append(intervals[th], busy{s, end})
+		}
+	}
+	if len(intervals) == 0 {
+		return nil, fmt.Errorf("%w: no duration events in Chrome trace", ErrInvalidArgs)
+	}
+
+	ticks := func(us float64) int64 {
+		return int64(math.Round(us * float64(time.Microsecond) / float64(tick)))
+	}
+	processes := make([]Process, 0, len(intervals))
+	for th, in := range intervals {
+		sort.Slice(in, func(i, j int) bool { return in[i].start < in[j].start })
+		var (
+			total float64
+			cur   = in[0]
+		)
+		for _, b := range in[1:] {
+			if b.start > cur.end {
+				total += cur.end - cur.start
+				cur = b
+			} else if b.end > cur.end {
+				cur.end = b.end
+			}
+		}
+		total += cur.end - cur.start
+		if total <= 0 {
+			continue
+		}
+
+		p := Process{ProcessID: th.tid, ArrivalTime: ticks(in[0].start - start), BurstDuration: ticks(total)}
+		if p.BurstDuration < 1 {
+			p.BurstDuration = 1
+		}
+		processes = append(processes, p)
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		if processes[i].ArrivalTime != processes[j].ArrivalTime {
+			return processes[i].ArrivalTime < processes[j].ArrivalTime
+		}
+		return processes[i].ProcessID < processes[j].ProcessID
+	})
+	return processes, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_loadChromeTrace(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		r       io.Reader
+		want    []Process
+		wantErr error
+	}{
+		{
+			name: "trace object",
+			r: strings.NewReader(`{"displayTimeUnit": "ms", "traceEvents": [
+				{"name": "thread_name", "ph": "M", "pid": 1, "tid": 10, "args": {"name": "main"}},
+				{"name": "load", "ph": "X", "ts": 1000, "dur": 3000, "pid": 1, "tid": 10},
+				{"name": "parse", "ph": "X", "ts": 2000, "dur": 500, "pid": 1, "tid": 10},
+				{"name": "task", "ph": "B", "ts": 2500, "pid": 1, "tid": 11},
+				{"name": "step", "ph": "B", "ts": 3000, "pid": 1, "tid": 11},
+				{"name": "step", "ph": "E", "ts": 4000, "pid": 1, "tid": 11},
+				{"name": "mark", "ph": "i", "ts": 4500, "pid": 1, "tid": 11},
+				{"name": "task", "ph": "E", "ts": 5600, "pid": 1, "tid": 11},
+				{"name": "paint", "ph": "X", "ts": 6000, "dur": 1000, "pid": 1, "tid": 10},
+				{"name": "gc", "ph": "B", "ts": 8000, "pid": 2, "tid": 12},
+				{"name": "paint", "ph": "X", "ts": 9000, "dur": 1000, "pid": 1, "tid": 10}
+			]}`),
+			want: []Process{
+				{ProcessID: 10, ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: 11, ArrivalTime: 2, BurstDuration: 3},
+				{ProcessID: 12, ArrivalTime: 7, BurstDuration: 2},
+			},
+		},
+		{
+			name: "event array",
+			r:    strings.NewReader(`[{"ph": "X", "ts": 0, "dur": 2000, "pid": 1, "tid": 3}, {"ph": "X", "ts": 400, "dur": 100, "pid": 1, "tid": 4}]`),
+			want: []Process{
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 4, ArrivalTime: 0, BurstDuration: 1},
+			},
+		},
+		{
+			name:    "end without begin",
+			r:       strings.NewReader(`[{"ph": "E", "ts": 10, "pid": 1, "tid": 3}]`),
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name:    "no duration events",
+			r:       strings.NewReader(`{"traceEvents": [{"ph": "i", "ts": 10, "pid": 1, "tid": 3}]}`),
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadChromeTrace(tt.r, time.Millisecond)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadChromeTrace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadChromeTrace() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ansiReset ends a colour begun by ansiColor.
+const ansiReset = "\x1b[0m"
+
+// ansiPalette are the xterm 256-colour backgrounds chart cells take by PID, light enough for the black text
+// on them to read, in an order that keeps neighbouring PIDs apart.
+var ansiPalette = []int{114, 141, 221, 81, 210, 150, 183, 229, 117, 216, 158, 219}
+
+// ansiIdle is the background of cells of a CPU that was idle.
+const ansiIdle = 250
+
+// ansiColor begins the colour of chart cells of the slices of pid: the same for a PID every time, and grey
+// for an idle CPU.
+func ansiColor(pid int64) string {
+	bg := ansiIdle
+	if pid >= 0 {
+		bg = ansiPalette[pid%int64(len(ansiPalette))]
+	}
+	return fmt.Sprintf("\x1b[30;48;5;%dm", bg)
+}
+
+// WithColor returns a writer to w that has the GANTT charts of schedules written to it coloured by PID,
+// for a terminal.
+func WithColor(w io.Writer) io.Writer {
+	ow := options(w)
+	ow.color = true
+	return ow
+}
+
+// useColor reports whether charts written to f are coloured under the -color mode given: always, never,
+// or auto to colour them only if f is a terminal and NO_COLOR is not set.
+func useColor(mode string, f *os.File) (bool, error) {
+	switch strings.ToLower(mode) {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto", "":
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		info, err := f.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	}
+	return false, fmt.Errorf("%w: -color must be auto, always or never, got %q", ErrInvalidArgs, mode)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWithColor(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputGantt(WithColor(&w), []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: idlePID, Start: 2, Stop: 3},
+		{PID: 13, Start: 3, Stop: 4},
+	})
+	want := "Gantt schedule\n" +
+		"|\x1b[30;48;5;141m   1   \x1b[0m|\x1b[30;48;5;250m   -   \x1b[0m|\x1b[30;48;5;141m   13   \x1b[0m|\n" +
+		"0\t2\t3\t4\n\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputGantt() = %q, want %q", got, want)
+	}
+}
+
+func Test_useColor(t *testing.T) {
+	t.Parallel()
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	tests := []struct {
+		mode    string
+		want    bool
+		wantErr error
+	}{
+		{mode: "always", want: true},
+		{mode: "NEVER", want: false},
+		{mode: "auto", want: false},
+		{mode: "sometimes", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		got, err := useColor(tt.mode, f)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("useColor(%q) error = %v, wantErr %v", tt.mode, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("useColor(%q) = %v, want %v", tt.mode, got, tt.want)
+		}
+	}
+}
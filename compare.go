@@ -0,0 +1,352 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Algorithm is a named scheduler that produces a Result without any output, so runs can be compared.
+type Algorithm struct {
+	Name string
+	Run  func(processes []Process) Result
+}
+
+// CompareSchedules outputs a table comparing the average wait, average turnaround and throughput of several
+// algorithms on the same processes given:
+// • an output writer
+// • a title for the table
+// • a slice of processes
+// • the algorithms to compare
+//
+// The optimal non-preemptive and preemptive schedules are included as reference rows, and each algorithm's
+// average wait is shown as a difference from both.
+func CompareSchedules(w io.Writer, title string, processes []Process, algorithms []Algorithm) {
+	if len(processes) == 0 {
+		return
+	}
+
+	nonPreemptive, exact := optimalNonPreemptive(processes)
+	preemptive := optimalPreemptive(processes)
+	npWait, pWait := summarize(nonPreemptive).wait, summarize(preemptive).wait
+
+	npName := msg(w, "Optimal (non-preemptive)")
+	if !exact {
+		npName = msg(w, "Best found (non-preemptive)")
+	}
+
+	rows := make([][]string, 0, len(algorithms)+2)
+	row := func(name string, r Result) []string {
+		s := summarize(r)
+		return []string{
+			name,
+			fmt.Sprintf("%.2f", s.wait),
+			fmt.Sprintf("%.2f", s.turnaround),
+			fmt.Sprintf("%.2f/t", s.throughput),
+			fmt.Sprintf("%+.2f", s.wait-npWait),
+			fmt.Sprintf("%+.2f", s.wait-pWait),
+		}
+	}
+	for _, a := range algorithms {
+		rows = append(rows, row(a.Name, a.Run(processes)))
+	}
+	rows = append(rows, row(npName, nonPreemptive), row(msg(w, "Optimal (preemptive)"), preemptive))
+
+	outputTitle(w, title)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "Algorithm", "Wait", "Turnaround", "Throughput", "vs NP optimal", "vs P optimal"))
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT})
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// CompareSideBySide outputs a table of the average wait and turnaround, per process and weighted by burst,
+// the average slowdown, fairness of
+// waits, throughput, makespan, context switches, CPU utilization and idle time of several algorithms on the
+// same processes, a column per algorithm, their average wait against the optimal non-preemptive and
+// preemptive schedules', how well they kept to deadlines if the processes have any, and
+// the average wait at each priority if the processes were given several, given:
+// • an output writer
+// • a title for the table
+// • a slice of processes
+// • the algorithms to compare
+// • whether to follow it with each process's wait under every algorithm, after the first as a
+// difference from it
+func CompareSideBySide(w io.Writer, title string, processes []Process, algorithms []Algorithm, deltas bool) {
+	if len(processes) == 0 || len(algorithms) == 0 {
+		return
+	}
+
+	var (
+		unit    = unitOf(w)
+		results = make([]Result, len(algorithms))
+		header  = msgs(w, "Metric")
+		rows    = labelRows(w, "Average wait", "Burst-weighted wait", "Average turnaround", "Burst-weighted turnaround",
+			"Average slowdown", "Fairness of waits", "Gini of waits", "Throughput", "Makespan", "Context switches",
+			"CPU utilization", "Idle time")
+	)
+	for i, a := range algorithms {
+		results[i] = a.Run(processes)
+		s := summarize(results[i])
+		header = append(header, a.Name)
+		rows[0] = append(rows[0], unit.formatAverage(s.wait))
+		rows[1] = append(rows[1], unit.formatAverage(s.weightedWait))
+		rows[2] = append(rows[2], unit.formatAverage(s.turnaround))
+		rows[3] = append(rows[3], unit.formatAverage(s.weightedTurnaround))
+		rows[4] = append(rows[4], fmt.Sprintf("%.2f", s.slowdown))
+		rows[5] = append(rows[5], fmt.Sprintf("%.2f", s.fairness))
+		rows[6] = append(rows[6], fmt.Sprintf("%.2f", s.gini))
+		rows[7] = append(rows[7], unit.formatRate(s.throughput))
+		rows[8] = append(rows[8], unit.format(s.makespan))
+		rows[9] = append(rows[9], fmt.Sprint(contextSwitches(results[i].Gantt)))
+		_, cpu := cpuTimes(results[i].Gantt)
+		rows[10] = append(rows[10], fmt.Sprintf("%.1f%%", 100*cpu.utilization()))
+		rows[11] = append(rows[11], unit.format(cpu.idle))
+	}
+	rows = append(rows, optimalRows(w, processes, results)...)
+	rows = append(rows, deadlineRows(w, results)...)
+	rows = append(rows, priorityWaits(w, results)...)
+
+	outputTitle(w, title)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetColumnAlignment(sideBySideAlignment(len(header)))
+	table.AppendBulk(rows)
+	table.Render()
+	if !deltas {
+		return
+	}
+
+	waits := make([]map[int64]int64, len(results))
+	for i, r := range results {
+		waits[i] = make(map[int64]int64, len(r.Processes))
+		for _, p := range r.Processes {
+			waits[i][p.ProcessID] = p.Wait
+		}
+	}
+	ids := make([]int64, 0, len(waits[0]))
+	for id := range waits[0] {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	rows = rows[:0]
+	for _, id := range ids {
+		base := waits[0][id]
+		row := []string{fmt.Sprint(id), unit.format(base)}
+		for _, wait := range waits[1:] {
+			d := unit.format(wait[id] - base)
+			if wait[id] >= base {
+				d = "+" + d
+			}
+			row = append(row, d)
+		}
+		rows = append(rows, row)
+	}
+	header[0] = msg(w, "ID")
+	_, _ = fmt.Fprintf(w, "%s %s\n", msg(w, "Wait by process, against"), algorithms[0].Name)
+	table = tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetColumnAlignment(sideBySideAlignment(len(header)))
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// labelRows returns a row for each of labels, translated for w, to append a value under each algorithm to.
+func labelRows(w io.Writer, labels ...string) [][]string {
+	rows := make([][]string, len(labels))
+	for i, label := range msgs(w, labels...) {
+		rows[i] = []string{label}
+	}
+	return rows
+}
+
+// sideBySideAlignment aligns a table of n columns with names on the left and values to the right.
+func sideBySideAlignment(n int) []int {
+	alignment := make([]int, n)
+	alignment[0] = tablewriter.ALIGN_LEFT
+	for i := 1; i < n; i++ {
+		alignment[i] = tablewriter.ALIGN_RIGHT
+	}
+	return alignment
+}
+
+// optimalRows returns rows of the average wait under each of results as a difference from that of the
+// optimal non-preemptive schedule of processes, or the best found when it is too costly to search them
+// all, and from that of the optimal preemptive one, for output to w.
+func optimalRows(w io.Writer, processes []Process, results []Result) [][]string {
+	var (
+		unit                 = unitOf(w)
+		nonPreemptive, exact = optimalNonPreemptive(processes)
+		npWait, pWait        = summarize(nonPreemptive).wait, summarize(optimalPreemptive(processes)).wait
+		rows                 = labelRows(w, "Wait vs NP optimal", "Wait vs P optimal")
+	)
+	if !exact {
+		rows[0][0] = msg(w, "Wait vs NP best found")
+	}
+	delta := func(d float64) string {
+		if d < 0 {
+			return unit.formatAverage(d)
+		}
+		return "+" + unit.formatAverage(d)
+	}
+	for _, r := range results {
+		wait := summarize(r).wait
+		rows[0] = append(rows[0], delta(wait-npWait))
+		rows[1] = append(rows[1], delta(wait-pWait))
+	}
+	return rows
+}
+
+// deadlineRows returns rows of the share of processes that met their deadline, the total tardiness and
+// the maximum lateness under each of results, for output to w, or none if no process has a deadline.
+func deadlineRows(w io.Writer, results []Result) [][]string {
+	unit := unitOf(w)
+	rows := labelRows(w, "Deadlines met", "Total tardiness", "Max lateness")
+	for _, r := range results {
+		d, ok := summarizeDeadlines(r)
+		if !ok {
+			return nil
+		}
+		rows[0] = append(rows[0], fmt.Sprintf("%.1f%%", 100*d.hitRatio()))
+		rows[1] = append(rows[1], unit.format(d.tardiness))
+		rows[2] = append(rows[2], unit.format(d.maxLateness))
+	}
+	return rows
+}
+
+// priorityWaits returns a row per priority of the average wait of the processes given it under each of
+// results, for output to w, or none if the processes share one priority.
+func priorityWaits(w io.Writer, results []Result) [][]string {
+	var (
+		unit   = unitOf(w)
+		levels []int64
+		seen   = make(map[int64]bool)
+		waits  = make([]map[int64]float64, len(results))
+	)
+	for i, r := range results {
+		waits[i] = make(map[int64]float64)
+		for _, c := range priorityClasses(r) {
+			if !seen[c.priority] {
+				seen[c.priority] = true
+				levels = append(levels, c.priority)
+			}
+			waits[i][c.priority] = c.wait
+		}
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+
+	rows := make([][]string, len(levels))
+	for l, priority := range levels {
+		rows[l] = []string{fmt.Sprintf("%s %d", msg(w, "Average wait, priority"), priority)}
+		for i := range results {
+			wait, ok := waits[i][priority]
+			if !ok {
+				rows[l] = append(rows[l], "-")
+				continue
+			}
+			rows[l] = append(rows[l], unit.formatAverage(wait))
+		}
+	}
+	return rows
+}
+
+// contextSwitches counts the times a CPU of a GANTT chart was handed from one process to another, idle
+// time between them not counting as a process.
+func contextSwitches(gantt []TimeSlice) int {
+	var (
+		switches int
+		last     = make(map[int]int64)
+	)
+	slices := append([]TimeSlice(nil), gantt...)
+	sort.SliceStable(slices, func(i, j int) bool { return slices[i].Start < slices[j].Start })
+	for _, s := range slices {
+		if s.PID < 0 {
+			continue
+		}
+		if pid, ok := last[s.CPU]; ok && pid != s.PID {
+			switches++
+		}
+		last[s.CPU] = s.PID
+	}
+	return switches
+}
+
+// summary holds the averages reported for a schedule.
+type summary struct {
+	wait       float64
+	turnaround float64
+	throughput float64
+	// slowdown is the average slowdown of the processes that have a burst.
+	slowdown float64
+	// makespan is how long the schedule took, from the first arrival to the last completion.
+	makespan int64
+	// weightedWait and weightedTurnaround are the averages weighted by burst, which count a process as
+	// often as it took ticks to run rather than once.
+	weightedWait, weightedTurnaround float64
+	// fairness and gini are Jain's fairness index and the Gini coefficient of the waiting times.
+	fairness, gini float64
+	// worstWait and worstTurnaround are the processes that waited longest and took longest to turn around,
+	// the first of them on a tie.
+	worstWait, worstTurnaround ProcessResult
+}
+
+// summarize averages the processes of r, leaving out killed ones when r does. Throughput is the processes
+// completed per tick of the makespan, or 0 when the makespan is.
+func summarize(r Result) summary {
+	var (
+		n, slowed, bursts            float64
+		s                            summary
+		firstArrival, lastCompletion int64
+		waits                        []float64
+	)
+	for _, p := range r.Processes {
+		if p.Killed && r.ExcludeKilled {
+			continue
+		}
+		if n == 0 || p.ArrivalTime < firstArrival {
+			firstArrival = p.ArrivalTime
+		}
+		if n == 0 || p.Wait > s.worstWait.Wait {
+			s.worstWait = p
+		}
+		if n == 0 || p.Turnaround > s.worstTurnaround.Turnaround {
+			s.worstTurnaround = p
+		}
+		n++
+		s.wait += float64(p.Wait)
+		waits = append(waits, float64(p.Wait))
+		s.turnaround += float64(p.Turnaround)
+		bursts += float64(p.BurstDuration)
+		s.weightedWait += float64(p.BurstDuration * p.Wait)
+		s.weightedTurnaround += float64(p.BurstDuration * p.Turnaround)
+		if sd, ok := slowdown(p); ok {
+			s.slowdown += sd
+			slowed++
+		}
+		if p.Completion > lastCompletion {
+			lastCompletion = p.Completion
+		}
+	}
+	if n == 0 {
+		return s
+	}
+	s.wait /= n
+	s.turnaround /= n
+	if slowed > 0 {
+		s.slowdown /= slowed
+	}
+	if bursts > 0 {
+		s.weightedWait /= bursts
+		s.weightedTurnaround /= bursts
+	}
+	s.makespan = lastCompletion - firstArrival
+	if s.makespan > 0 {
+		s.throughput = n / float64(s.makespan)
+	}
+	s.fairness, s.gini = jainIndex(waits), giniCoefficient(waits)
+	return s
+}
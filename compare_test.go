@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompareSchedules(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes  []Process
+		title      string
+		algorithms []Algorithm
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 10,
+						Priority:      3,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   1,
+						BurstDuration: 1,
+						Priority:      1,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   2,
+						BurstDuration: 3,
+						Priority:      2,
+					},
+				},
+				title:      "Comparison",
+				algorithms: DefaultAlgorithms,
+			},
+			wantOut: loadFixture(t, "compare_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			CompareSchedules(&w, tt.args.title, tt.args.processes, tt.args.algorithms)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("CompareSchedules() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestCompareSideBySide(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+	}
+	algorithms := []Algorithm{DefaultAlgorithms[0], {Name: "Round-robin", Run: func(p []Process) Result {
+		return roundRobin(p, 2)
+	}}}
+	fixture := loadFixture(t, "compare_sidebyside_test.txt")
+	tests := []struct {
+		name    string
+		deltas  bool
+		wantOut string
+	}{
+		{name: "metrics", wantOut: fixture[:strings.Index(fixture, "Wait by process")]},
+		{name: "deltas", deltas: true, wantOut: fixture},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			CompareSideBySide(&w, "Comparison", processes, algorithms, tt.deltas)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("CompareSideBySide() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_contextSwitches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  int
+	}{
+		{name: "empty"},
+		{
+			name:  "same process across idle time",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: idlePID, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 5}},
+		},
+		{
+			name: "per CPU",
+			gantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2, CPU: 0},
+				{PID: 2, Start: 0, Stop: 3, CPU: 1},
+				{PID: 3, Start: 2, Stop: 4, CPU: 0},
+				{PID: 1, Start: 3, Stop: 5, CPU: 1},
+				{PID: 3, Start: 4, Stop: 6, CPU: 0},
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := contextSwitches(tt.gantt); got != tt.want {
+				t.Errorf("contextSwitches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_summarize_throughput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		processes      []Process
+		wantMakespan   int64
+		wantThroughput float64
+	}{
+		{name: "empty"},
+		{
+			name:           "from the first arrival",
+			processes:      []Process{{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3}, {ProcessID: 2, ArrivalTime: 3, BurstDuration: 1}},
+			wantMakespan:   4,
+			wantThroughput: 0.5,
+		},
+		{
+			name:      "zero bursts complete as they arrive",
+			processes: []Process{{ProcessID: 1, ArrivalTime: 2}, {ProcessID: 2, ArrivalTime: 2}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := summarize(fcfs(tt.processes))
+			if s.makespan != tt.wantMakespan || s.throughput != tt.wantThroughput {
+				t.Errorf("summarize() makespan, throughput = %v, %v, want %v, %v", s.makespan, s.throughput, tt.wantMakespan, tt.wantThroughput)
+			}
+		})
+	}
+}
+
+func Test_deadlineRows(t *testing.T) {
+	t.Parallel()
+	early := Result{Processes: []ProcessResult{
+		{Process: Process{ProcessID: 1, Deadline: 4}, Completion: 3},
+		{Process: Process{ProcessID: 2, Deadline: 5}, Completion: 7},
+		{Process: Process{ProcessID: 3}, Completion: 9},
+	}}
+	late := Result{Processes: []ProcessResult{
+		{Process: Process{ProcessID: 1, Deadline: 4}, Completion: 6},
+		{Process: Process{ProcessID: 2, Deadline: 5}, Completion: 8},
+		{Process: Process{ProcessID: 3}, Completion: 9},
+	}}
+	tests := []struct {
+		name    string
+		results []Result
+		want    [][]string
+	}{
+		{
+			name:    "deadlines",
+			results: []Result{early, late},
+			want: [][]string{
+				{"Deadlines met", "50.0%", "0.0%"},
+				{"Total tardiness", "2", "5"},
+				{"Max lateness", "2", "3"},
+			},
+		},
+		{
+			name:    "no deadlines",
+			results: []Result{{Processes: early.Processes[2:]}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := deadlineRows(io.Discard, tt.results); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("deadlineRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
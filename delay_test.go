@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDelayedSJFSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{
+			ProcessID:     1,
+			ArrivalTime:   0,
+			BurstDuration: 6,
+		},
+		{
+			ProcessID:     2,
+			ArrivalTime:   1,
+			BurstDuration: 1,
+		},
+		{
+			ProcessID:     3,
+			ArrivalTime:   2,
+			BurstDuration: 2,
+		},
+	}
+	type args struct {
+		processes []Process
+		title     string
+		delay     int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "idles for a shorter arrival",
+			args: args{
+				processes: processes,
+				title:     "Non-work-conserving SJF",
+				delay:     2,
+			},
+			wantOut: loadFixture(t, "delay_test.txt"),
+		},
+		{
+			name: "no delay is work-conserving",
+			args: args{
+				processes: processes,
+				title:     "Non-work-conserving SJF",
+				delay:     0,
+			},
+			wantOut: loadFixture(t, "delay_none_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			DelayedSJFSchedule(&w, tt.args.title, tt.args.processes, tt.args.delay)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("DelayedSJFSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
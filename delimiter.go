@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// delimited returns a loader of CSV whose columns are separated by comma.
+func delimited(comma rune) func(r io.Reader) ([]Process, error) {
+	return func(r io.Reader) ([]Process, error) {
+		return loadDelimited(r, comma)
+	}
+}
+
+// parseDelimiter returns the column separator named by s: a single character, or tab given as "tab" or
+// "\t". Quotes, line breaks and the Unicode replacement character cannot separate columns.
+func parseDelimiter(s string) (rune, error) {
+	switch s {
+	case "tab", `\t`:
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+		return 0, fmt.Errorf("%w: %q cannot separate columns", ErrInvalidArgs, s)
+	}
+	return r, nil
+}
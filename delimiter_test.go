@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseDelimiter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s       string
+		want    rune
+		wantErr error
+	}{
+		{s: ";", want: ';'},
+		{s: "tab", want: '\t'},
+		{s: `\t`, want: '\t'},
+		{s: "|", want: '|'},
+		{s: "", wantErr: ErrInvalidArgs},
+		{s: ";;", wantErr: ErrInvalidArgs},
+		{s: `"`, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.s, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseDelimiter(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseDelimiter(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseDelimiter(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadDelimited(t *testing.T) {
+	t.Parallel()
+	want := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2, Bursts: []int64{3, 4, 2}},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1, Class: "batch"},
+	}
+	tests := []struct {
+		name  string
+		input string
+		comma rune
+	}{
+		{name: "semicolons", input: "1;5;0;2;\"bursts=3;4;2\"\n2;9;3;1;class=batch", comma: ';'},
+		{name: "tabs", input: "1\t5\t0\t2\tbursts=3;4;2\n2\t9\t3\t1\tclass=batch", comma: '\t'},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := delimited(tt.comma)(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("loadDelimited() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
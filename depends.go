@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkDependencies reports a dependency on a process that does not exist, or a cycle of dependencies
+// that could never be released.
+func checkDependencies(processes []Process) error {
+	byID := make(map[int64]Process, len(processes))
+	for _, p := range processes {
+		byID[p.ProcessID] = p
+	}
+	for _, p := range processes {
+		for _, d := range p.DependsOn {
+			if _, ok := byID[d]; !ok {
+				return fmt.Errorf("%w: process %d depends on process %d", ErrUnknownDependency, p.ProcessID, d)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		state = make(map[int64]int, len(processes))
+		path  []int64
+		visit func(id int64) error
+	)
+	visit = func(id int64) error {
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			for i := range path {
+				if path[i] == id {
+					return fmt.Errorf("%w: %s", ErrDependencyCycle, joinIDs(append(path[i:], id), " -> "))
+				}
+			}
+		}
+		state[id] = visiting
+		path = append(path, id)
+		for _, d := range byID[id].DependsOn {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = visited
+		return nil
+	}
+	for _, p := range processes {
+		if err := visit(p.ProcessID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependenciesMet reports whether every process p depends on has finished.
+func dependenciesMet(p Process, finished map[int64]bool) bool {
+	for _, d := range p.DependsOn {
+		if !finished[d] {
+			return false
+		}
+	}
+	return true
+}
+
+// dependent reports whether any process depends on another.
+func dependent(processes []Process) bool {
+	for _, p := range processes {
+		if len(p.DependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// joinIDs formats process IDs separated by sep.
+func joinIDs(ids []int64, sep string) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = fmt.Sprint(id)
+	}
+	return strings.Join(s, sep)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// diffMetrics are the metrics of schedules the diff subcommand compares, and which way each gets worse:
+// 1 if by growing, -1 if by shrinking.
+var diffMetrics = []struct {
+	name  string
+	value func(m quietMetrics) float64
+	worse float64
+}{
+	{"Average wait", func(m quietMetrics) float64 { return m.Wait }, 1},
+	{"Average turnaround", func(m quietMetrics) float64 { return m.Turnaround }, 1},
+	{"Throughput", func(m quietMetrics) float64 { return m.Throughput }, -1},
+	{"Makespan", func(m quietMetrics) float64 { return m.Makespan }, 1},
+	{"Context switches", func(m quietMetrics) float64 { return float64(m.ContextSwitches) }, 1},
+	{"CPU utilization (%)", func(m quietMetrics) float64 { return 100 * m.Utilization }, -1},
+	{"Idle time", func(m quietMetrics) float64 { return m.Idle }, 1},
+}
+
+// diffResults runs the diff subcommand with args, comparing the schedules of two files of quiet JSON
+// output by title and writing to w the change in each metric and in the wait and turnaround of each
+// process, marking those that got worse by more than the threshold.
+func diffResults(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	threshold := fs.Float64("threshold", 10, "mark changes for the worse by more than this percentage of the old value as regressions")
+	catalogFile := fs.String("messages", "", "translate the headers and labels of the output with the YAML catalog of English messages in this file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *catalogFile != "" {
+		f, err := os.Open(*catalogFile)
+		if err != nil {
+			return fmt.Errorf("%v: error opening message catalog", err)
+		}
+		c, err := LoadCatalog(f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+		w = WithCatalog(w, c)
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("%w: diff takes an old and a new results file written by -quiet -json, got %v", ErrInvalidArgs, fs.Args())
+	}
+	old, err := loadResults(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	newer, err := loadResults(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	newByTitle := make(map[string]resultLine, len(newer))
+	for _, m := range newer {
+		newByTitle[m.Schedule] = m
+	}
+	var (
+		regressions int
+		compared    = make(map[string]bool, len(old))
+	)
+	for _, o := range old {
+		n, ok := newByTitle[o.Schedule]
+		if !ok {
+			_, _ = fmt.Fprintf(w, "%s: only in %s\n\n", o.Schedule, fs.Arg(0))
+			continue
+		}
+		compared[o.Schedule] = true
+		switch {
+		case o.Error != "" && n.Error != "":
+			_, _ = fmt.Fprintf(w, "%s: fails in both, in %s with %s\n\n", o.Schedule, fs.Arg(1), n.Error)
+			continue
+		case n.Error != "":
+			// A schedule that ran before but no longer does is the worst regression there is.
+			regressions++
+			_, _ = fmt.Fprintf(w, "%s: fails in %s with %s !\n\n", o.Schedule, fs.Arg(1), n.Error)
+			continue
+		case o.Error != "":
+			_, _ = fmt.Fprintf(w, "%s: failed in %s with %s\n\n", o.Schedule, fs.Arg(0), o.Error)
+			continue
+		}
+		if o.Unit != n.Unit {
+			return fmt.Errorf("%w: %s is in %q in %s but %q in %s", ErrInvalidArgs, o.Schedule, o.Unit, fs.Arg(0), n.Unit, fs.Arg(1))
+		}
+		regressions += outputDiff(w, o.quietMetrics, n.quietMetrics, *threshold)
+	}
+	for _, n := range newer {
+		if !compared[n.Schedule] {
+			_, _ = fmt.Fprintf(w, "%s: only in %s\n\n", n.Schedule, fs.Arg(1))
+		}
+	}
+	_, _ = fmt.Fprintf(w, "%d regressions of more than %g%%\n", regressions, *threshold)
+	return nil
+}
+
+// resultLine is a line of quiet JSON output: the metrics of a schedule, or the error that kept it from running.
+type resultLine struct {
+	quietMetrics
+	Error string `json:"error"`
+}
+
+// loadResults reads the lines of quiet JSON output in the file called name.
+func loadResults(name string) ([]resultLine, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error opening results file", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var (
+		results []resultLine
+		dec     = json.NewDecoder(f)
+	)
+	for {
+		var line resultLine
+		err := dec.Decode(&line)
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading results file %s", err, name)
+		}
+		results = append(results, line)
+	}
+}
+
+// outputDiff outputs tables of the change in each metric of a schedule from old to newer, and in the wait
+// and turnaround of each process, marking regressions with a !, and returns how many there were.
+func outputDiff(w io.Writer, old, newer quietMetrics, threshold float64) int {
+	var (
+		regressions int
+		rows        [][]string
+	)
+	delta := func(o, n, worse float64) string {
+		d := diffValue(n - o)
+		if n > o {
+			d = "+" + d
+		}
+		if regressed(o, n, worse, threshold) {
+			regressions++
+			d += " !"
+		}
+		return d
+	}
+	for _, m := range diffMetrics {
+		o, n := m.value(old), m.value(newer)
+		rows = append(rows, []string{msg(w, m.name), diffValue(o), diffValue(n), delta(o, n, m.worse)})
+	}
+	title := old.Schedule
+	if old.Unit != "" {
+		title += ", times in " + old.Unit
+	}
+	_, _ = fmt.Fprintln(w, title)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "Metric", "Old", "New", "Delta"))
+	table.SetColumnAlignment(sideBySideAlignment(4))
+	table.AppendBulk(rows)
+	table.Render()
+
+	newJobs := make(map[int64]quietJob, len(newer.Jobs))
+	for _, j := range newer.Jobs {
+		newJobs[j.ID] = j
+	}
+	rows = nil
+	for _, o := range old.Jobs {
+		n, ok := newJobs[o.ID]
+		if !ok {
+			rows = append(rows, []string{fmt.Sprint(o.ID), diffValue(o.Wait) + " -> -", "", diffValue(o.Turnaround) + " -> -", ""})
+			continue
+		}
+		delete(newJobs, o.ID)
+		rows = append(rows, []string{
+			fmt.Sprint(o.ID),
+			diffValue(o.Wait) + " -> " + diffValue(n.Wait), delta(o.Wait, n.Wait, 1),
+			diffValue(o.Turnaround) + " -> " + diffValue(n.Turnaround), delta(o.Turnaround, n.Turnaround, 1),
+		})
+	}
+	for _, n := range newer.Jobs {
+		if _, ok := newJobs[n.ID]; ok {
+			rows = append(rows, []string{fmt.Sprint(n.ID), "- -> " + diffValue(n.Wait), "", "- -> " + diffValue(n.Turnaround), ""})
+		}
+	}
+	if len(rows) > 0 {
+		table := tablewriter.NewWriter(w)
+		table.SetHeader(msgs(w, "ID", "Wait", "Delta", "Turnaround", "Delta"))
+		table.SetColumnAlignment(sideBySideAlignment(5))
+		table.AppendBulk(rows)
+		table.Render()
+	}
+	_, _ = fmt.Fprintln(w)
+	return regressions
+}
+
+// regressed reports whether a metric going from old to newer got worse, in the direction worse, by more
+// than threshold percent of old. Any worsening of a metric that was 0 is a regression.
+func regressed(old, newer, worse, threshold float64) bool {
+	change := (newer - old) * worse
+	if change <= 0 {
+		return false
+	}
+	return old == 0 || change > math.Abs(old)*threshold/100
+}
+
+// diffValue shows a value of a diff to at most two decimal places.
+func diffValue(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_diffResults(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	old := write("old.json", `{"schedule":"RR","processes":2,"wait":1.5,"turnaround":4,"throughput":0.4,"makespan":5,"context_switches":2,"utilization":1,"idle":0,`+
+		`"jobs":[{"id":1,"wait":2,"turnaround":5,"completion":5},{"id":2,"wait":1,"turnaround":3,"completion":4}]}
+{"schedule":"gone","processes":0,"wait":0,"turnaround":0,"throughput":0,"makespan":0,"context_switches":0,"utilization":0,"idle":0}
+{"schedule":"bad.csv","error":"no processes"}
+{"schedule":"broken","processes":1,"wait":0,"turnaround":1,"throughput":1,"makespan":1,"context_switches":0,"utilization":1,"idle":0}
+{"schedule":"fixed","error":"no processes"}
+`)
+	newer := write("new.json", `{"schedule":"RR","processes":2,"wait":1.05,"turnaround":3.5,"throughput":0.35,"makespan":5.5,"context_switches":1,"utilization":0.9,"idle":0.5,`+
+		`"jobs":[{"id":2,"wait":2.1,"turnaround":4.1,"completion":5.1},{"id":3,"wait":0,"turnaround":1,"completion":1}]}
+{"schedule":"broken","error":"no processes"}
+{"schedule":"fixed","processes":1,"wait":0,"turnaround":1,"throughput":1,"makespan":1,"context_switches":0,"utilization":1,"idle":0}
+`)
+
+	var w bytes.Buffer
+	if err := diffResults(&w, []string{"-threshold", "20", old, newer}); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"RR",
+		"+---------------------+-----+------+--------+",
+		"|       METRIC        | OLD | NEW  | DELTA  |",
+		"+---------------------+-----+------+--------+",
+		"| Average wait        | 1.5 | 1.05 |  -0.45 |",
+		"| Average turnaround  |   4 |  3.5 |   -0.5 |",
+		"| Throughput          | 0.4 | 0.35 |  -0.05 |",
+		"| Makespan            |   5 |  5.5 |   +0.5 |",
+		"| Context switches    |   2 |    1 |     -1 |",
+		"| CPU utilization (%) | 100 |   90 |    -10 |",
+		"| Idle time           |   0 |  0.5 | +0.5 ! |",
+		"+---------------------+-----+------+--------+",
+		"+----+----------+--------+------------+--------+",
+		"| ID |   WAIT   | DELTA  | TURNAROUND | DELTA  |",
+		"+----+----------+--------+------------+--------+",
+		"| 1  |   2 -> - |        |     5 -> - |        |",
+		"| 2  | 1 -> 2.1 | +1.1 ! |   3 -> 4.1 | +1.1 ! |",
+		"| 3  |   - -> 0 |        |     - -> 1 |        |",
+		"+----+----------+--------+------------+--------+",
+		"",
+		"gone: only in " + old,
+		"",
+		"bad.csv: only in " + old,
+		"",
+		"broken: fails in " + newer + " with no processes !",
+		"",
+		"fixed: failed in " + old + " with no processes",
+		"",
+		"4 regressions of more than 20%",
+		"",
+	}, "\n")
+	if got := w.String(); got != want {
+		t.Errorf("diffResults() = %v, want %v", got, want)
+	}
+
+	if err := diffResults(&w, []string{old}); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("diffResults() with one file error = %v, want %v", err, ErrInvalidArgs)
+	}
+	ms := write("ms.json", `{"schedule":"RR","unit":"ms"}`)
+	if err := diffResults(&w, []string{old, ms}); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("diffResults() across units error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_regressed(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		old, new      float64
+		worse         float64
+		wantRegressed bool
+	}{
+		{name: "grew past threshold", old: 10, new: 12, worse: 1, wantRegressed: true},
+		{name: "grew within threshold", old: 10, new: 11, worse: 1},
+		{name: "improved", old: 10, new: 5, worse: 1},
+		{name: "shrank past threshold", old: 10, new: 8, worse: -1, wantRegressed: true},
+		{name: "grew from nothing", old: 0, new: 0.1, worse: 1, wantRegressed: true},
+		{name: "unchanged", old: 0, new: 0, worse: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := regressed(tt.old, tt.new, tt.worse, 10); got != tt.wantRegressed {
+				t.Errorf("regressed() = %v, want %v", got, tt.wantRegressed)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// EDFSchedule outputs an earliest deadline first schedule of processes in a GANTT chart, a table of timing
+// and a table of deadline misses given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+//
+// The ready process with the nearest deadline always runs, preempting the running one if necessary.
+// Processes without a deadline only run when no process with one is ready.
+func EDFSchedule(w io.Writer, title string, processes []Process) {
+	outputResult(w, title, simulate(processes, policy{pick: earliestDeadline, preemptive: true}))
+}
+
+// earliestDeadline picks the ready job with the nearest deadline, keeping the earliest on ties.
+func earliestDeadline(ready []*job, _ int64) int {
+	best := 0
+	for i := range ready {
+		if lessNonZero(ready[i].Deadline, ready[best].Deadline) {
+			best = i
+		}
+	}
+	return best
+}
+
+// lessNonZero reports whether a is strictly less than b, treating zero as unset and greater than any value.
+func lessNonZero(a, b int64) bool {
+	switch {
+	case a == 0:
+		return false
+	case b == 0:
+		return true
+	default:
+		return a < b
+	}
+}
+
+// Lateness is how long after its deadline the process completed; negative when it finished early.
+func (p ProcessResult) Lateness() int64 {
+	return p.Completion - p.Deadline
+}
+
+// Missed reports whether the process has a deadline and completed after it.
+func (p ProcessResult) Missed() bool {
+	return p.Deadline != 0 && p.Lateness() > 0
+}
+
+// deadlineStats sums up how well a schedule kept to the deadlines of its processes.
+type deadlineStats struct {
+	jobs, met int
+	// tardiness is the total of how late the processes that missed their deadlines completed.
+	tardiness   int64
+	maxLateness int64
+}
+
+// hitRatio is the share of the processes with a deadline that met it.
+func (d deadlineStats) hitRatio() float64 {
+	return float64(d.met) / float64(d.jobs)
+}
+
+// summarizeDeadlines returns how well a schedule kept to the deadlines of its processes, or false if
+// none of them has one.
+func summarizeDeadlines(r Result) (deadlineStats, bool) {
+	var d deadlineStats
+	for _, p := range r.Processes {
+		if p.Deadline == 0 {
+			continue
+		}
+		if d.jobs == 0 || p.Lateness() > d.maxLateness {
+			d.maxLateness = p.Lateness()
+		}
+		d.jobs++
+		if p.Missed() {
+			d.tardiness += p.Lateness()
+		} else {
+			d.met++
+		}
+	}
+	return d, d.jobs > 0
+}
+
+// outputDeadlines outputs the lateness of every process with a deadline, the deadline-miss ratio, and
+// how many met their deadlines, the total tardiness and the maximum lateness. It outputs nothing when no
+// process has a deadline, so every scheduler can report misses.
+func outputDeadlines(w io.Writer, r Result) {
+	var (
+		rows   [][]string
+		missed int
+		unit   = unitOf(w)
+	)
+	for _, p := range r.Processes {
+		if p.Deadline == 0 {
+			continue
+		}
+		miss := "no"
+		if p.Missed() {
+			miss = "yes"
+			missed++
+		}
+		rows = append(rows, []string{
+			fmt.Sprint(p.ProcessID),
+			unit.format(p.Deadline),
+			unit.format(p.Completion),
+			unit.format(p.Lateness()),
+			msg(w, miss),
+		})
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, msg(w, "Deadlines"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "ID", "Deadline", "Exit", "Lateness", "Missed"))
+	table.AppendBulk(rows)
+	table.SetFooter([]string{"", "", "", "", fmt.Sprintf("%d/%d (%.0f%%)", missed, len(rows), 100*float64(missed)/float64(len(rows)))})
+	table.Render()
+	d, _ := summarizeDeadlines(r)
+	_, _ = fmt.Fprintf(w, "%s: %d/%d (%.0f%%); %s: %s; %s: %s\n", msg(w, "Deadlines met"), d.met, d.jobs, 100*d.hitRatio(),
+		msg(w, "Total tardiness"), unit.format(d.tardiness), msg(w, "Max lateness"), unit.format(d.maxLateness))
+}
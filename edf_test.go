@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEDFSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 4,
+						Deadline:      10,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   1,
+						BurstDuration: 2,
+						Deadline:      4,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   2,
+						BurstDuration: 3,
+						Deadline:      7,
+					},
+					{
+						ProcessID:     4,
+						ArrivalTime:   3,
+						BurstDuration: 2,
+						Deadline:      9,
+					},
+					{
+						ProcessID:     5,
+						ArrivalTime:   3,
+						BurstDuration: 1,
+					},
+				},
+				title: "Earliest deadline first",
+			},
+			wantOut: loadFixture(t, "edf_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			EDFSchedule(&w, tt.args.title, tt.args.processes)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("EDFSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestDeadlineMisses(t *testing.T) {
+	t.Parallel()
+	// The same workload as TestEDFSchedule, for contrast.
+	processes := []Process{
+		{
+			ProcessID:     1,
+			ArrivalTime:   0,
+			BurstDuration: 4,
+			Deadline:      10,
+		},
+		{
+			ProcessID:     2,
+			ArrivalTime:   1,
+			BurstDuration: 2,
+			Deadline:      4,
+		},
+		{
+			ProcessID:     3,
+			ArrivalTime:   2,
+			BurstDuration: 3,
+			Deadline:      7,
+		},
+		{
+			ProcessID:     4,
+			ArrivalTime:   3,
+			BurstDuration: 2,
+			Deadline:      9,
+		},
+		{
+			ProcessID:     5,
+			ArrivalTime:   3,
+			BurstDuration: 1,
+		},
+	}
+	tests := []struct {
+		name     string
+		schedule func(w *bytes.Buffer)
+		wantOut  string
+	}{
+		{
+			name: "first-come, first-serve",
+			schedule: func(w *bytes.Buffer) {
+				FCFSSchedule(w, "First-come, first-serve", processes)
+			},
+			wantOut: loadFixture(t, "fcfs_deadlines_test.txt"),
+		},
+		{
+			name: "round-robin",
+			schedule: func(w *bytes.Buffer) {
+				RRSchedule(w, "Round-robin", processes, 2)
+			},
+			wantOut: loadFixture(t, "rr_deadlines_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			tt.schedule(&w)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// EEVDFParams tunes the earliest eligible virtual deadline first scheduler.
+type EEVDFParams struct {
+	// Slice is the length in ticks of each request a process makes for the CPU.
+	Slice int64
+}
+
+// DefaultEEVDFParams are the EEVDF tunables used when none are given.
+var DefaultEEVDFParams = EEVDFParams{Slice: 3}
+
+// eevdfEpsilon absorbs floating point error when comparing virtual times.
+const eevdfEpsilon = 1e-9
+
+// EEVDFSchedule outputs an earliest eligible virtual deadline first schedule of processes in a GANTT chart and
+// a table of timing with each process's lag when it exited and the largest lag it accrued, given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the EEVDF tunables
+//
+// Every process accrues virtual runtime inversely to its weight (see the nice column). A process is eligible
+// when its virtual runtime is no more than the weighted average V, i.e. it is owed CPU time (positive lag).
+// Each request of Slice ticks gets a virtual deadline Slice virtual ticks past the virtual runtime it started
+// at, and of the eligible processes the one with the earliest virtual deadline runs its request to the end.
+// Arriving processes start at V with no lag.
+func EEVDFSchedule(w io.Writer, title string, processes []Process, params EEVDFParams) {
+	outputResult(w, title, eevdf(processes, params))
+}
+
+func eevdf(processes []Process, params EEVDFParams) Result {
+	if params.Slice < 1 {
+		params.Slice = DefaultEEVDFParams.Slice
+	}
+
+	var (
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		weight     = make([]float64, n)
+		vruntime   = make([]float64, n)
+		deadline   = make([]float64, n)
+		exitLag    = make([]float64, n)
+		maxLag     = make([]float64, n)
+		gantt      = make([]TimeSlice, 0)
+		runnable   = make([]int, 0, n)
+		current    = -1
+		used       int64 // ticks of the current request already run
+		avg        float64
+		next       int
+		done       int
+		timer      int64
+	)
+
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+		weight[i] = niceWeight(processes[i].Nice)
+	}
+
+	// lag is the CPU time, in ticks, process i is owed relative to an ideal fair share.
+	lag := func(i int) float64 {
+		return weight[i] / nice0Weight * (avg - vruntime[i])
+	}
+	average := func() {
+		var sum, total float64
+		for _, i := range runnable {
+			sum += weight[i] * vruntime[i]
+			total += weight[i]
+		}
+		if total > 0 {
+			avg = sum / total
+		}
+	}
+
+	for done < n {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			i := order[next]
+			vruntime[i] = avg
+			deadline[i] = avg + float64(params.Slice)*nice0Weight/weight[i]
+			runnable = append(runnable, i)
+			next++
+		}
+		average()
+
+		if len(runnable) == 0 {
+			// CPU is idle until the next arrival.
+			timer = processes[order[next]].ArrivalTime
+			continue
+		}
+
+		if current < 0 {
+			for _, i := range runnable {
+				if vruntime[i] > avg+eevdfEpsilon {
+					continue
+				}
+				if current < 0 || deadline[i] < deadline[current]-eevdfEpsilon {
+					current = i
+				}
+			}
+			used = 0
+		}
+
+		i := current
+		if remaining[i] > 0 {
+			gantt = appendGantt(gantt, processes[i], timer)
+			vruntime[i] += nice0Weight / weight[i]
+			remaining[i]--
+			used++
+			timer++
+			gantt = setStop(gantt, timer)
+		}
+		average()
+
+		for _, j := range runnable {
+			if l := lag(j); math.Abs(l) > math.Abs(maxLag[j]) {
+				maxLag[j] = l
+			}
+		}
+
+		switch {
+		case remaining[i] <= 0:
+			exitLag[i] = lag(i)
+			for k := range runnable {
+				if runnable[k] == i {
+					runnable = append(runnable[:k], runnable[k+1:]...)
+					break
+				}
+			}
+			completion[i] = timer
+			done++
+			current = -1
+		case used >= params.Slice:
+			deadline[i] = vruntime[i] + float64(params.Slice)*nice0Weight/weight[i]
+			current = -1
+		}
+	}
+
+	r := newResult(processes, completion, gantt)
+	exit, largest := make([]string, n), make([]string, n)
+	for i := range processes {
+		exit[i] = fmt.Sprintf("%.2f", exitLag[i])
+		largest[i] = fmt.Sprintf("%.2f", maxLag[i])
+	}
+	r.Columns = append(r.Columns,
+		Column{Header: "Lag", Values: exit},
+		Column{Header: "Max lag", Values: largest})
+	return r
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEEVDFSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		params    EEVDFParams
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			// The same workload as TestCFSSchedule, for comparison.
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 6,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   0,
+						BurstDuration: 6,
+						Nice:          5,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   2,
+						BurstDuration: 3,
+					},
+				},
+				title:  "EEVDF",
+				params: DefaultEEVDFParams,
+			},
+			wantOut: loadFixture(t, "eevdf_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			EEVDFSchedule(&w, tt.args.title, tt.args.processes, tt.args.params)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("EEVDFSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+type (
+	// FrequencyLevel is a DVFS operating point: how much of a burst a CPU completes per tick at that
+	// frequency and the power it draws while running there.
+	FrequencyLevel struct {
+		Speed float64
+		Power float64
+	}
+	// Governor chooses the frequency level of each CPU every tick.
+	Governor int
+)
+
+const (
+	// PerformanceGovernor keeps every CPU at its fastest level.
+	PerformanceGovernor Governor = iota
+	// PowersaveGovernor keeps every CPU at its slowest level.
+	PowersaveGovernor
+	// OnDemandGovernor runs a CPU at its fastest level while processes wait for it and at its slowest otherwise.
+	OnDemandGovernor
+)
+
+// scaled reports whether the CPUs change frequency under a governor.
+func (p SMPParams) scaled() bool {
+	return len(p.Levels) > 0
+}
+
+// level returns the frequency level the governor picks for CPU c, given whether processes are waiting
+// for it. CPUs beyond the end of Levels have the levels of the last one listed; a CPU with no levels runs at
+// its speed drawing no power.
+func (p SMPParams) level(c int, waiting bool) FrequencyLevel {
+	levels := p.Levels[len(p.Levels)-1]
+	if c < len(p.Levels) {
+		levels = p.Levels[c]
+	}
+	if len(levels) == 0 {
+		return FrequencyLevel{Speed: p.Speeds[c]}
+	}
+	slowest, fastest := levels[0], levels[0]
+	for _, l := range levels {
+		if l.Speed < slowest.Speed {
+			slowest = l
+		}
+		if l.Speed > fastest.Speed {
+			fastest = l
+		}
+	}
+	switch {
+	case p.Governor == PowersaveGovernor, p.Governor == OnDemandGovernor && !waiting:
+		return slowest
+	default:
+		return fastest
+	}
+}
+
+// outputEnergy outputs the energy the CPUs used running processes and idling, and the energy per process.
+func outputEnergy(w io.Writer, u smpUsage, processes int) {
+	total := u.energy + u.idleEnergy
+	_, _ = fmt.Fprintf(w, "%s: %.2f; %s: %.2f; %s: %.2f; %s: %.2f\n", msg(w, "Energy"), total, msg(w, "Running"), u.energy,
+		msg(w, "Idle"), u.idleEnergy, msg(w, "Per process"), total/float64(processes))
+}
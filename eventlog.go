@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// Kinds of events in an event log, in the order events at the same time are logged.
+const (
+	eventCompletion = "completion"
+	eventPreemption = "preemption"
+	eventArrival    = "arrival"
+	eventIdle       = "idle"
+	eventOverhead   = "overhead"
+	eventDispatch   = "dispatch"
+)
+
+// eventOrder ranks the kinds of events logged at the same time.
+var eventOrder = map[string]int{eventCompletion: 0, eventPreemption: 1, eventArrival: 2, eventIdle: 3, eventOverhead: 4, eventDispatch: 5}
+
+// logEvent is a line of an event log. Times are in the writer's time unit.
+type logEvent struct {
+	Schedule string  `json:"schedule"`
+	Time     float64 `json:"time"`
+	Event    string  `json:"event"`
+	PID      *int64  `json:"pid,omitempty"`
+	CPU      *int    `json:"cpu,omitempty"`
+	Queue    string  `json:"queue,omitempty"`
+	Until    float64 `json:"until,omitempty"`
+	Killed   bool    `json:"killed,omitempty"`
+
+	tick int64
+}
+
+// WithEventLog returns a writer to w that has schedules written to it also write what happened in them to
+// dst as newline-delimited JSON, an event per line in order of time: each process's arrival, every
+// dispatch of one onto a CPU, every preemption of one leaving it before completing, each completion, each
+// time a CPU went idle, and each time one was busy deciding what to run, until when.
+func WithEventLog(w io.Writer, dst io.Writer) io.Writer {
+	ow := options(w)
+	ow.events = &eventLog{enc: json.NewEncoder(dst)}
+	return ow
+}
+
+// eventLog writes the events of schedules as JSON lines.
+type eventLog struct {
+	enc *json.Encoder
+}
+
+// write writes the events of a schedule titled title, with times shown in unit u.
+func (l *eventLog) write(title string, r Result, u TimeUnit) error {
+	var (
+		events []logEvent
+		scaled = func(t int64) float64 { return float64(t) * u.scale() }
+	)
+	add := func(kind string, tick int64, pid *int64, cpu *int) *logEvent {
+		events = append(events, logEvent{Schedule: title, Time: scaled(tick), Event: kind, PID: pid, CPU: cpu, tick: tick})
+		return &events[len(events)-1]
+	}
+
+	completion := make(map[int64]int64, len(r.Processes))
+	for i := range r.Processes {
+		p := &r.Processes[i]
+		completion[p.ProcessID] = p.Completion
+		add(eventArrival, p.ArrivalTime, &p.ProcessID, nil)
+		add(eventCompletion, p.Completion, &p.ProcessID, nil).Killed = p.Killed
+	}
+
+	lanes := make(map[int][]TimeSlice)
+	for _, s := range r.Gantt {
+		lanes[s.CPU] = append(lanes[s.CPU], s)
+	}
+	for cpu, lane := range lanes {
+		cpu, lane := cpu, lane
+		sort.SliceStable(lane, func(i, j int) bool { return lane[i].Start < lane[j].Start })
+		if lane[0].Start > 0 {
+			add(eventIdle, 0, nil, &cpu).Until = scaled(lane[0].Start)
+		}
+		for i := range lane {
+			s := &lane[i]
+			if s.PID == overheadPID {
+				add(eventOverhead, s.Start, nil, &cpu).Until = scaled(s.Stop)
+				continue
+			}
+			if s.PID < 0 {
+				add(eventIdle, s.Start, nil, &cpu).Until = scaled(s.Stop)
+				continue
+			}
+			add(eventDispatch, s.Start, &s.PID, &cpu).Queue = s.Queue
+			if s.Stop < completion[s.PID] {
+				add(eventPreemption, s.Stop, &s.PID, &cpu)
+			}
+			if i+1 < len(lane) && s.Stop < lane[i+1].Start {
+				add(eventIdle, s.Stop, nil, &cpu).Until = scaled(lane[i+1].Start)
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.tick != b.tick {
+			return a.tick < b.tick
+		}
+		if eventOrder[a.Event] != eventOrder[b.Event] {
+			return eventOrder[a.Event] < eventOrder[b.Event]
+		}
+		if a.cpu() != b.cpu() {
+			return a.cpu() < b.cpu()
+		}
+		return a.pid() < b.pid()
+	})
+	for _, e := range events {
+		if err := l.enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cpu is the CPU of an event, or -1 if it has none.
+func (e logEvent) cpu() int {
+	if e.CPU == nil {
+		return -1
+	}
+	return *e.CPU
+}
+
+// pid is the PID of an event, or -1 if it has none.
+func (e logEvent) pid() int64 {
+	if e.PID == nil {
+		return -1
+	}
+	return *e.PID
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestWithEventLog(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		unit      TimeUnit
+		processes []Process
+		want      string
+	}{
+		{
+			name:      "preemption",
+			processes: []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 1}},
+			want: `{"schedule":"RR","time":0,"event":"arrival","pid":1}
+{"schedule":"RR","time":0,"event":"dispatch","pid":1,"cpu":0}
+{"schedule":"RR","time":1,"event":"arrival","pid":2}
+{"schedule":"RR","time":2,"event":"preemption","pid":1,"cpu":0}
+{"schedule":"RR","time":2,"event":"dispatch","pid":2,"cpu":0}
+{"schedule":"RR","time":3,"event":"completion","pid":2}
+{"schedule":"RR","time":3,"event":"dispatch","pid":1,"cpu":0}
+{"schedule":"RR","time":4,"event":"completion","pid":1}
+`,
+		},
+		{
+			name:      "idle",
+			unit:      TimeUnit{Name: "ms", Scale: 0.5},
+			processes: []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, ArrivalTime: 4, BurstDuration: 1}},
+			want: `{"schedule":"RR","time":0,"event":"arrival","pid":1}
+{"schedule":"RR","time":0,"event":"dispatch","pid":1,"cpu":0}
+{"schedule":"RR","time":1,"event":"completion","pid":1}
+{"schedule":"RR","time":1,"event":"idle","cpu":0,"until":2}
+{"schedule":"RR","time":2,"event":"arrival","pid":2}
+{"schedule":"RR","time":2,"event":"dispatch","pid":2,"cpu":0}
+{"schedule":"RR","time":2.5,"event":"completion","pid":2}
+`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got bytes.Buffer
+			RRSchedule(WithEventLog(WithTimeUnit(io.Discard, tt.unit), &got), "RR", tt.processes, 2)
+			if got.String() != tt.want {
+				t.Errorf("event log = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+// rank orders ready processes for eventDriven; the lowest rank runs.
+type rank func(p Process, remaining int64) int64
+
+// eventDriven runs a preemptive schedule of processes, jumping from one arrival or completion to the next
+// rather than stepping one tick at a time, so its cost depends on the number of processes and not on
+// their burst lengths.
+//
+// At each event the ready process of lowest rank runs, and the running process keeps the CPU unless
+// another ranks strictly lower; other ties go to the process given first. A process's rank may only
+// change with its remaining burst and must not grow as it runs, so no preemption can fall between events.
+//
+// Processes with I/O bursts are run on the simulator instead, which blocks them for their I/O and ranks
+// them by the remaining time of their current CPU burst.
+func eventDriven(processes []Process, rank rank) Result {
+	if blocking(processes) {
+		return simulate(processes, policy{pick: lowestRank(rank), preemptive: true})
+	}
+	var (
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		arrived    = make([]bool, n)
+		gantt      = make([]TimeSlice, 0)
+		running    = -1
+		next       int
+		done       int
+		timer      int64
+	)
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+	}
+
+	for done < n {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			i := order[next]
+			arrived[i] = true
+			next++
+			// A process with nothing to run completes as it arrives.
+			if remaining[i] <= 0 {
+				completion[i] = timer
+				done++
+			}
+		}
+
+		best := running
+		for i := range processes {
+			if !arrived[i] || remaining[i] <= 0 || i == running {
+				continue
+			}
+			if best < 0 || rank(processes[i], remaining[i]) < rank(processes[best], remaining[best]) {
+				best = i
+			}
+		}
+		running = best
+
+		if running < 0 {
+			if next >= n {
+				break
+			}
+			// CPU is idle until the next arrival.
+			timer = processes[order[next]].ArrivalTime
+			continue
+		}
+
+		// Run until the process completes or the next arrival, whichever comes first.
+		stop := timer + remaining[running]
+		if next < n && processes[order[next]].ArrivalTime < stop {
+			stop = processes[order[next]].ArrivalTime
+		}
+		if stop > timer {
+			gantt = appendGantt(gantt, processes[running], timer)
+			gantt = setStop(gantt, stop)
+		}
+		remaining[running] -= stop - timer
+		timer = stop
+
+		if remaining[running] <= 0 {
+			completion[running] = timer
+			done++
+			running = -1
+		}
+	}
+
+	return newResult(processes, completion, gantt)
+}
+
+// lowestRank picks the ready job of lowest rank, keeping the earliest on ties, as eventDriven does.
+func lowestRank(rank rank) picker {
+	return func(ready []*job, _ int64) int {
+		best := 0
+		for i := range ready {
+			if rank(ready[i].Process, ready[i].remaining) < rank(ready[best].Process, ready[best].remaining) {
+				best = i
+			}
+		}
+		return best
+	}
+}
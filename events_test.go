@@ -0,0 +1,97 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_eventDriven(t *testing.T) {
+	t.Parallel()
+	remaining := func(_ Process, remaining int64) int64 { return remaining }
+	priority := func(p Process, _ int64) int64 { return p.Priority }
+	tests := []struct {
+		name           string
+		processes      []Process
+		rank           rank
+		wantCompletion []int64
+		wantGantt      []TimeSlice
+	}{
+		{
+			name: "shortest remaining time",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 2, BurstDuration: 6},
+				{ProcessID: 2, ArrivalTime: 5, BurstDuration: 2},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 8},
+				{ProcessID: 4, ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 5, ArrivalTime: 4, BurstDuration: 4},
+			},
+			rank:           remaining,
+			wantCompletion: []int64{15, 7, 23, 3, 10},
+			wantGantt: []TimeSlice{
+				{PID: 4, Start: 0, Stop: 3},
+				{PID: 1, Start: 3, Stop: 4},
+				{PID: 5, Start: 4, Stop: 5},
+				{PID: 2, Start: 5, Stop: 7},
+				{PID: 5, Start: 7, Stop: 10},
+				{PID: 1, Start: 10, Stop: 15},
+				{PID: 3, Start: 15, Stop: 23},
+			},
+		},
+		{
+			name: "idle gap",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 5, BurstDuration: 3, Priority: 1},
+			},
+			rank:           priority,
+			wantCompletion: []int64{2, 8},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 5, Stop: 8},
+			},
+		},
+		{
+			name: "zero and negative bursts",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 0},
+				{ProcessID: 3, ArrivalTime: 5, BurstDuration: 0},
+				{ProcessID: 4, ArrivalTime: 2, BurstDuration: -1},
+			},
+			rank:           remaining,
+			wantCompletion: []int64{3, 1, 5, 2},
+			wantGantt:      []TimeSlice{{PID: 1, Start: 0, Stop: 3}},
+		},
+		{
+			name: "long bursts",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4_000_000_000, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 1_000_000_000, BurstDuration: 2_000_000_000, Priority: 1},
+			},
+			rank:           priority,
+			wantCompletion: []int64{6_000_000_000, 3_000_000_000},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1_000_000_000},
+				{PID: 2, Start: 1_000_000_000, Stop: 3_000_000_000},
+				{PID: 1, Start: 3_000_000_000, Stop: 6_000_000_000},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := eventDriven(tt.processes, tt.rank)
+			completion := make([]int64, len(r.Processes))
+			for i, p := range r.Processes {
+				completion[i] = p.Completion
+			}
+			if !reflect.DeepEqual(completion, tt.wantCompletion) {
+				t.Errorf("eventDriven() completion = %v, want %v", completion, tt.wantCompletion)
+			}
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("eventDriven() gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+		})
+	}
+}
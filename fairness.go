@@ -0,0 +1,35 @@
+package main
+
+import "sort"
+
+// jainIndex is Jain's fairness index of values: (Σx)² / (n·Σx²), 1 when they are all equal and falling
+// towards 1/n as one comes to dominate the rest. Values that are all 0 are perfectly fair.
+func jainIndex(values []float64) float64 {
+	var sum, squares float64
+	for _, v := range values {
+		sum += v
+		squares += v * v
+	}
+	if squares == 0 {
+		return 1
+	}
+	return sum * sum / (float64(len(values)) * squares)
+}
+
+// giniCoefficient is the Gini coefficient of values, which must not be negative: the mean absolute
+// difference between any two as a share of twice their mean, 0 when they are all equal and nearing 1 as
+// one comes to hold the whole. Values that are all 0 are perfectly equal.
+func giniCoefficient(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	var sum, ranked float64
+	for i, v := range sorted {
+		sum += v
+		ranked += float64(i+1) * v
+	}
+	if sum == 0 {
+		return 0
+	}
+	n := float64(len(sorted))
+	return 2*ranked/(n*sum) - (n+1)/n
+}
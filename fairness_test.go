@@ -0,0 +1,33 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_fairness(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		values   []float64
+		wantJain float64
+		wantGini float64
+	}{
+		{name: "equal", values: []float64{3, 3, 3}, wantJain: 1, wantGini: 0},
+		{name: "none waited", values: []float64{0, 0}, wantJain: 1, wantGini: 0},
+		{name: "one waited", values: []float64{0, 0, 0, 4}, wantJain: 0.25, wantGini: 0.75},
+		{name: "uneven", values: []float64{4, 1, 4}, wantJain: 81.0 / 99, wantGini: 2.0 / 9},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := jainIndex(tt.values); math.Abs(got-tt.wantJain) > 1e-9 {
+				t.Errorf("jainIndex() = %v, want %v", got, tt.wantJain)
+			}
+			if got := giniCoefficient(tt.values); math.Abs(got-tt.wantGini) > 1e-9 {
+				t.Errorf("giniCoefficient() = %v, want %v", got, tt.wantGini)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// FairShareSchedule outputs a fair-share schedule of processes in a GANTT chart, a table of timing and
+// the share of CPU time each user received given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the time quantum given to each turn
+//
+// Users with ready processes take turns at the CPU one quantum at a time, and each user's turns go
+// round-robin through their own processes, so CPU time is split evenly between users however many
+// processes they run. A process without a user is treated as the only process of its own user.
+func FairShareSchedule(w io.Writer, title string, processes []Process, quantum int64) {
+	r := fairShare(processes, quantum)
+	outputResult(w, title, r)
+	if len(processes) > 0 {
+		outputUserShares(w, r)
+	}
+}
+
+func fairShare(processes []Process, quantum int64) Result {
+	if quantum < 1 {
+		quantum = defaultQuantum
+	}
+
+	var (
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		gantt      = make([]TimeSlice, 0)
+		users      []string             // users in the order they first had a ready process
+		queues     = map[string][]int{} // ready processes of each user
+		turn       int                  // index in users of the next user to serve
+		next       int
+		done       int
+		timer      int64
+	)
+
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+	}
+
+	admit := func() {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			i := order[next]
+			u := owner(processes[i])
+			if _, ok := queues[u]; !ok {
+				users = append(users, u)
+			}
+			queues[u] = append(queues[u], i)
+			next++
+		}
+	}
+
+	for done < n {
+		admit()
+
+		u := ""
+		for k := range users {
+			if c := users[(turn+k)%len(users)]; len(queues[c]) > 0 {
+				u = c
+				turn = (turn+k)%len(users) + 1
+				break
+			}
+		}
+		if u == "" {
+			// CPU is idle until the next arrival.
+			timer = processes[order[next]].ArrivalTime
+			continue
+		}
+
+		i := queues[u][0]
+		queues[u] = queues[u][1:]
+
+		run := quantum
+		if remaining[i] < run {
+			run = remaining[i]
+		}
+		if run > 0 {
+			gantt = appendGantt(gantt, processes[i], timer)
+			timer += run
+			remaining[i] -= run
+			gantt = setStop(gantt, timer)
+		}
+
+		admit()
+		if remaining[i] > 0 {
+			queues[u] = append(queues[u], i)
+			continue
+		}
+		completion[i] = timer
+		done++
+	}
+
+	return newResult(processes, completion, gantt)
+}
+
+// owner is the fair-share group of a process.
+func owner(p Process) string {
+	if p.User != "" {
+		return "user " + p.User
+	}
+	return fmt.Sprintf("process %d", p.ProcessID)
+}
+
+// outputUserShares outputs each user's processes and CPU time, with their share of all CPU time and
+// of the ticks during which at least two users had unfinished processes.
+func outputUserShares(w io.Writer, r Result) {
+	var (
+		users     []string
+		count     = map[string]int{}
+		cpu       = map[string]int64{}
+		contended = map[string]int64{}
+		byPID     = map[int64]string{}
+		total     int64
+		contested int64
+	)
+	for _, p := range r.Processes {
+		u := owner(p.Process)
+		if _, ok := count[u]; !ok {
+			users = append(users, u)
+		}
+		count[u]++
+		cpu[u] += p.BurstDuration
+		total += p.BurstDuration
+		byPID[p.ProcessID] = u
+	}
+
+	for _, s := range r.Gantt {
+		for t := s.Start; t < s.Stop; t++ {
+			active := map[string]bool{}
+			for _, p := range r.Processes {
+				if p.ArrivalTime <= t && t < p.Completion {
+					active[owner(p.Process)] = true
+				}
+			}
+			if len(active) > 1 {
+				contended[byPID[s.PID]]++
+				contested++
+			}
+		}
+	}
+
+	percent := func(part, whole int64) string {
+		if whole == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%%", 100*float64(part)/float64(whole))
+	}
+
+	_, _ = fmt.Fprintln(w, msg(w, "CPU share"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "User", "Processes", "CPU", "Share", "Contended share"))
+	for _, u := range users {
+		table.Append([]string{
+			u,
+			fmt.Sprint(count[u]),
+			fmt.Sprint(cpu[u]),
+			percent(cpu[u], total),
+			percent(contended[u], contested),
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFairShareSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		quantum   int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						BurstDuration: 4,
+						User:          "alice",
+					},
+					{
+						ProcessID:     2,
+						BurstDuration: 4,
+						User:          "alice",
+					},
+					{
+						ProcessID:     3,
+						BurstDuration: 4,
+						User:          "alice",
+					},
+					{
+						ProcessID:     4,
+						BurstDuration: 4,
+						User:          "bob",
+					},
+					{
+						ProcessID:     5,
+						ArrivalTime:   3,
+						BurstDuration: 2,
+					},
+				},
+				title:   "Fair-share",
+				quantum: 2,
+			},
+			wantOut: loadFixture(t, "fairshare_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			FairShareSchedule(&w, tt.args.title, tt.args.processes, tt.args.quantum)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("FairShareSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
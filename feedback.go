@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// defaultFeedbackLevels is the number of feedback queues used when none is given.
+const defaultFeedbackLevels = 4
+
+// FeedbackSchedule outputs a multilevel feedback schedule of processes in a GANTT chart annotated with the
+// queue each slice ran from, and a table of timing including the deepest queue each process sank to, given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the number of queues
+//
+// New processes enter queue 0. A process dispatched from queue i runs for up to 2^i ticks and, if it is not
+// finished, is demoted to queue i+1 (the last queue keeps its processes). Lower queues only run when all
+// higher ones are empty, and a running process is never preempted before its quantum expires.
+func FeedbackSchedule(w io.Writer, title string, processes []Process, levels int) {
+	r, depth, _ := feedback(processes, levels, func(l int) int64 { return int64(1) << l })
+	outputResult(w, title, r)
+	if len(processes) > 0 {
+		outputDepth(w, depth)
+	}
+}
+
+// FeedbackQuantaSchedule outputs a multilevel feedback schedule like FeedbackSchedule, but with the quantum
+// of each queue configured and a trace of every dispatch and the quantum it was given, given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the number of queues
+// • the quantum of each queue, numbered from 0
+func FeedbackQuantaSchedule(w io.Writer, title string, processes []Process, levels int, quanta Quanta) {
+	r, depth, decisions := feedback(processes, levels, func(l int) int64 { return quanta.of(int64(l)) })
+	outputResult(w, title, r)
+	if len(processes) > 0 {
+		outputDepth(w, depth)
+		outputDecisions(w, decisions)
+	}
+}
+
+// feedback returns the schedule, the deepest queue each process reached and every dispatch, where quantum
+// gives the quantum of each queue.
+func feedback(processes []Process, levels int, quantum func(level int) int64) (Result, []int, []QuantumDecision) {
+	if levels < 1 {
+		levels = defaultFeedbackLevels
+	}
+
+	var (
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		level      = make([]int, n)
+		gantt      = make([]TimeSlice, 0)
+		queues     = make([][]int, levels)
+		decisions  []QuantumDecision
+		next       int
+		done       int
+		timer      int64
+	)
+
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+	}
+
+	admit := func() {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			queues[0] = append(queues[0], order[next])
+			next++
+		}
+	}
+
+	for done < n {
+		admit()
+
+		l := 0
+		for l < levels && len(queues[l]) == 0 {
+			l++
+		}
+		if l == levels {
+			// CPU is idle until the next arrival.
+			timer = processes[order[next]].ArrivalTime
+			continue
+		}
+
+		i := queues[l][0]
+		queues[l] = queues[l][1:]
+
+		q := quantum(l)
+		run := q
+		if remaining[i] < run {
+			run = remaining[i]
+		}
+		if run > 0 {
+			decisions = append(decisions, QuantumDecision{
+				TimeSlice: TimeSlice{PID: processes[i].ProcessID, Start: timer, Stop: timer + run, Queue: strconv.Itoa(l)},
+				Quantum:   q,
+			})
+			gantt = appendSlice(gantt, TimeSlice{
+				PID:   processes[i].ProcessID,
+				Start: timer,
+				Stop:  timer + run,
+				Queue: strconv.Itoa(l),
+			})
+			timer += run
+			remaining[i] -= run
+		}
+
+		admit()
+		if remaining[i] > 0 {
+			if level[i] < levels-1 {
+				level[i]++
+			}
+			queues[level[i]] = append(queues[level[i]], i)
+			continue
+		}
+		completion[i] = timer
+		done++
+	}
+
+	r := newResult(processes, completion, gantt)
+	depth := make([]string, n)
+	for i := range level {
+		depth[i] = strconv.Itoa(level[i])
+	}
+	r.Columns = append(r.Columns, Column{Header: "Depth", Values: depth})
+	return r, level, decisions
+}
+
+// outputDepth outputs the average depth processes sank to and how many finished in each queue.
+func outputDepth(w io.Writer, depth []int) {
+	var (
+		total  int
+		counts []int
+	)
+	for _, d := range depth {
+		total += d
+		for len(counts) <= d {
+			counts = append(counts, 0)
+		}
+		counts[d]++
+	}
+
+	_, _ = fmt.Fprintf(w, "%s: %.2f; %s", msg(w, "Average depth"), float64(total)/float64(len(depth)), msg(w, "Finished in"))
+	for l, c := range counts {
+		sep := ","
+		if l == 0 {
+			sep = ""
+		}
+		_, _ = fmt.Fprintf(w, "%s %s %d: %d", sep, msg(w, "queue"), l, c)
+	}
+	_, _ = fmt.Fprintln(w)
+}
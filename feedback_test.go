@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFeedbackSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		levels    int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 3,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   2,
+						BurstDuration: 6,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   4,
+						BurstDuration: 4,
+					},
+					{
+						ProcessID:     4,
+						ArrivalTime:   6,
+						BurstDuration: 5,
+					},
+					{
+						ProcessID:     5,
+						ArrivalTime:   8,
+						BurstDuration: 2,
+					},
+				},
+				title:  "Feedback",
+				levels: 4,
+			},
+			wantOut: loadFixture(t, "feedback_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			FeedbackSchedule(&w, tt.args.title, tt.args.processes, tt.args.levels)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("FeedbackSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
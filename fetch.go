@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// fetchTimeout is how long fetching a scheduling file from a URL may take.
+	fetchTimeout = 10 * time.Second
+	// maxFetchSize is the largest scheduling file fetched from a URL, in bytes.
+	maxFetchSize = 16 << 20
+)
+
+// workloadURL returns the URL a scheduling file argument names, if it names an http or https URL rather
+// than a local file.
+func workloadURL(arg string) (*url.URL, bool) {
+	u, err := url.Parse(arg)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, false
+	}
+	return u, true
+}
+
+// fetchWorkload returns the scheduling file at u, failing if fetching it takes longer than timeout or it
+// is larger than limit bytes.
+func fetchWorkload(u *url.URL, timeout time.Duration, limit int64) ([]byte, error) {
+	client := http.Client{Timeout: timeout}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: error fetching scheduling file", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: fetching scheduling file %s: %s", ErrInvalidArgs, u, resp.Status)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: error fetching scheduling file", err)
+	}
+	if int64(len(b)) > limit {
+		return nil, fmt.Errorf("%w: scheduling file %s is larger than %d bytes", ErrInvalidArgs, u, limit)
+	}
+	return b, nil
+}
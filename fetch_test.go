@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_workloadURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		arg  string
+		want bool
+	}{
+		{arg: "https://example.com/workloads/rr.csv", want: true},
+		{arg: "http://localhost:8080/rr.json?raw=1", want: true},
+		{arg: "example_processes_rr.csv"},
+		{arg: "C:/workloads/rr.csv"},
+		{arg: "file:///tmp/rr.csv"},
+		{arg: "-"},
+	}
+	for _, tt := range tests {
+		if _, got := workloadURL(tt.arg); got != tt.want {
+			t.Errorf("workloadURL(%q) = %v, want %v", tt.arg, got, tt.want)
+		}
+	}
+}
+
+func Test_fetchWorkload(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rr.csv":
+			_, _ = w.Write([]byte("1,5,0,3\n2,4,1,1\n"))
+		case "/large.csv":
+			_, _ = w.Write([]byte(strings.Repeat("1,5,0,3\n", 100)))
+		case "/slow.csv":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name        string
+		path        string
+		want        string
+		wantErr     error
+		wantTimeout bool
+	}{
+		{name: "success", path: "/rr.csv", want: "1,5,0,3\n2,4,1,1\n"},
+		{name: "not found", path: "/missing.csv", wantErr: ErrInvalidArgs},
+		{name: "too large", path: "/large.csv", wantErr: ErrInvalidArgs},
+		{name: "too slow", path: "/slow.csv", wantTimeout: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			u, err := url.Parse(srv.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := fetchWorkload(u, 50*time.Millisecond, 64)
+			if tt.wantTimeout {
+				var te interface{ Timeout() bool }
+				if !errors.As(err, &te) || !te.Timeout() {
+					t.Fatalf("fetchWorkload() error = %v, want a timeout", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("fetchWorkload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("fetchWorkload() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// gangUsage counts how CPU time was spent in a multi-CPU schedule.
+type gangUsage struct {
+	busy          int64 // CPU ticks running a thread
+	idle          int64 // CPU ticks with nothing ready to run
+	fragmentation int64 // CPU ticks left idle while a job waited for enough free CPUs
+}
+
+// GangSchedule outputs a gang schedule of multi-threaded jobs on several CPUs in a GANTT chart per CPU,
+// a table of timing and how much CPU time was lost to gang constraints, given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes, each running Threads threads of BurstDuration
+// • the number of CPUs
+// • the length of each time slot
+//
+// All threads of a job run at the same time or not at all. Each slot, the ready jobs are considered in
+// round-robin order and packed onto the free CPUs their Affinity allows first-fit; jobs that do not fit
+// wait for a later slot while the CPUs they could not use sit idle. A job with more threads than the CPUs
+// it may use runs on all of them.
+func GangSchedule(w io.Writer, title string, processes []Process, cpus int, quantum int64) {
+	r, usage := gang(processes, cpus, quantum)
+	outputResult(w, title, r)
+	if len(processes) > 0 {
+		outputGangUsage(w, usage)
+	}
+}
+
+func gang(processes []Process, cpus int, quantum int64) (Result, gangUsage) {
+	if cpus < 1 {
+		cpus = 1
+	}
+	if quantum < 1 {
+		quantum = defaultQuantum
+	}
+
+	var (
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		finished   = make([]bool, n)
+		lanes      = make([][]TimeSlice, cpus)
+		ready      = make([]int, 0, n)
+		usage      gangUsage
+		next       int
+		done       int
+		timer      int64
+	)
+
+	threads := func(i int) int {
+		t := int(processes[i].Threads)
+		if t < 1 {
+			t = 1
+		}
+		// A job cannot have more threads running than the CPUs it may use.
+		usable := 0
+		for c := 0; c < cpus; c++ {
+			if allowed(processes[i], c, cpus) {
+				usable++
+			}
+		}
+		if t > usable {
+			t = usable
+		}
+		return t
+	}
+
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+	}
+
+	for done < n {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			if i := order[next]; remaining[i] > 0 {
+				ready = append(ready, i)
+			} else {
+				finished[i] = true
+				completion[i] = timer
+				done++
+			}
+			next++
+		}
+
+		if len(ready) == 0 {
+			// CPUs are idle until the next arrival.
+			usage.idle += int64(cpus) * (processes[order[next]].ArrivalTime - timer)
+			timer = processes[order[next]].ArrivalTime
+			continue
+		}
+
+		var (
+			taken   = make([]bool, cpus)
+			row     []int // jobs running this slot
+			placed  = map[int][]int{}
+			waiting []int // jobs that did not fit
+		)
+		for _, i := range ready {
+			var fit []int
+			for c := 0; c < cpus && len(fit) < threads(i); c++ {
+				if !taken[c] && allowed(processes[i], c, cpus) {
+					fit = append(fit, c)
+				}
+			}
+			if len(fit) < threads(i) {
+				waiting = append(waiting, i)
+				continue
+			}
+			for _, c := range fit {
+				taken[c] = true
+			}
+			placed[i] = fit
+			row = append(row, i)
+		}
+
+		for tick := int64(0); tick < quantum; tick++ {
+			busy := 0
+			for _, i := range row {
+				if remaining[i] <= 0 {
+					continue
+				}
+				for _, c := range placed[i] {
+					lanes[c] = appendSlice(lanes[c], TimeSlice{
+						PID:   processes[i].ProcessID,
+						Start: timer,
+						Stop:  timer + 1,
+						CPU:   c,
+					})
+				}
+				remaining[i]--
+				busy += threads(i)
+			}
+			if busy == 0 {
+				break
+			}
+
+			usage.busy += int64(busy)
+			if len(waiting) > 0 {
+				usage.fragmentation += int64(cpus - busy)
+			} else {
+				usage.idle += int64(cpus - busy)
+			}
+			timer++
+
+			for _, i := range row {
+				if remaining[i] == 0 && !finished[i] {
+					finished[i] = true
+					completion[i] = timer
+					done++
+				}
+			}
+		}
+
+		// Jobs that ran go to the back so the others get the first pick of CPUs next slot.
+		ready = ready[:0]
+		for _, i := range waiting {
+			ready = append(ready, i)
+		}
+		for _, i := range row {
+			if remaining[i] > 0 {
+				ready = append(ready, i)
+			}
+		}
+	}
+
+	var gantt []TimeSlice
+	for _, lane := range lanes {
+		gantt = append(gantt, lane...)
+	}
+	sort.SliceStable(gantt, func(i, j int) bool {
+		return gantt[i].CPU < gantt[j].CPU
+	})
+
+	return newResult(processes, completion, gantt), usage
+}
+
+// outputGangUsage outputs how much of the idle CPU time was caused by jobs waiting for enough CPUs.
+func outputGangUsage(w io.Writer, u gangUsage) {
+	if u.busy+u.idle+u.fragmentation == 0 {
+		return
+	}
+	unit := unitOf(w)
+	_, _ = fmt.Fprintf(w, "%s: %s; %s: %s\n", msg(w, "Gang fragmentation"), unit.format(u.fragmentation),
+		msg(w, "Idle time"), unit.format(u.idle+u.fragmentation))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGangSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		cpus      int
+		quantum   int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "fragmentation while a wide job waits",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 4,
+						Threads:       2,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   0,
+						BurstDuration: 3,
+						Threads:       3,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   1,
+						BurstDuration: 2,
+						Threads:       1,
+					},
+					{
+						ProcessID:     4,
+						ArrivalTime:   2,
+						BurstDuration: 2,
+						Threads:       2,
+					},
+				},
+				title:   "Gang",
+				cpus:    4,
+				quantum: 2,
+			},
+			wantOut: loadFixture(t, "gang_test.txt"),
+		},
+		{
+			name: "affinity",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 2,
+						Affinity:      []int{1},
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   0,
+						BurstDuration: 2,
+						Affinity:      []int{1},
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   0,
+						BurstDuration: 2,
+						Threads:       2,
+					},
+				},
+				title:   "Gang",
+				cpus:    2,
+				quantum: 2,
+			},
+			wantOut: loadFixture(t, "gang_affinity_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			GangSchedule(&w, tt.args.title, tt.args.processes, tt.args.cpus, tt.args.quantum)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("GangSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
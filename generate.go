@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Workload describes a synthetic workload of Count processes, numbered from 1 and generated from Seed.
+	Workload struct {
+		Count int
+		// ArrivalRate is the mean number of arrivals per tick of a Poisson arrival process; all processes
+		// arrive at time zero when it is not positive.
+		ArrivalRate float64
+		// Burst and BurstMean are the distribution of burst durations and its mean.
+		Burst     Spread
+		BurstMean float64
+		// Priority is the distribution of priorities, from 1 to MaxPriority with the mean of that range
+		// before skewed distributions are clamped into it.
+		Priority    Spread
+		MaxPriority int64
+		// ParetoShape is the tail index of Pareto distributions, which must be over 1 to have a mean.
+		ParetoShape float64
+		Seed        int64
+	}
+	// Spread is the distribution of the values generated for a field.
+	Spread int
+)
+
+const (
+	// UniformSpread is equally likely to give any whole value from 1 to just under twice the mean.
+	UniformSpread Spread = iota
+	// ExponentialSpread mostly gives values under the mean, with a few several times over it.
+	ExponentialSpread
+	// ParetoSpread gives a heavy tail: most values near its minimum and rare ones far above the mean.
+	ParetoSpread
+)
+
+// DefaultWorkload is a small workload of exponential bursts arriving about every other tick, with
+// priorities uniform over the range the scheduling file format allows.
+var DefaultWorkload = Workload{
+	Count:       10,
+	ArrivalRate: 0.5,
+	Burst:       ExponentialSpread,
+	BurstMean:   5,
+	Priority:    UniformSpread,
+	MaxPriority: 50,
+	ParetoShape: 2,
+	Seed:        1,
+}
+
+// ErrUnknownSpread is returned when a distribution of generated values is not recognised.
+var ErrUnknownSpread = errors.New("unknown spread")
+
+var spreadNames = []string{"uniform", "exponential", "pareto"}
+
+// ParseSpread returns the distribution of generated values with the given name.
+func ParseSpread(name string) (Spread, error) {
+	for s, n := range spreadNames {
+		if strings.EqualFold(name, n) {
+			return Spread(s), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q (known: %v)", ErrUnknownSpread, name, spreadNames)
+}
+
+func (s Spread) String() string {
+	if s < 0 || int(s) >= len(spreadNames) {
+		return fmt.Sprintf("Spread(%d)", int(s))
+	}
+	return spreadNames[s]
+}
+
+// Generate returns the processes of the workload in arrival order. The same workload always generates the
+// same processes.
+func (wl Workload) Generate() []Process {
+	var (
+		rng       = rand.New(rand.NewSource(wl.Seed))
+		processes = make([]Process, wl.Count)
+		arrival   float64
+	)
+	sample := func(s Spread, mean float64) int64 {
+		var v float64
+		switch s {
+		case ExponentialSpread:
+			v = rng.ExpFloat64() * mean
+		case ParetoSpread:
+			shape := wl.ParetoShape
+			if shape <= 1 {
+				shape = DefaultWorkload.ParetoShape
+			}
+			// A Pareto distribution of this shape with the given mean starts at mean·(shape-1)/shape.
+			v = mean * (shape - 1) / shape / math.Pow(1-rng.Float64(), 1/shape)
+		default:
+			v = 1 + rng.Float64()*(2*mean-2)
+		}
+		if v = math.Round(v); v < 1 {
+			return 1
+		}
+		return int64(v)
+	}
+
+	for i := range processes {
+		if i > 0 && wl.ArrivalRate > 0 {
+			arrival += rng.ExpFloat64() / wl.ArrivalRate
+		}
+		processes[i] = Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   int64(math.Round(arrival)),
+			BurstDuration: sample(wl.Burst, wl.BurstMean),
+		}
+		if wl.MaxPriority > 0 {
+			if p := sample(wl.Priority, float64(wl.MaxPriority+1)/2); p < wl.MaxPriority {
+				processes[i].Priority = p
+			} else {
+				processes[i].Priority = wl.MaxPriority
+			}
+		}
+	}
+	return processes
+}
+
+// Args returns the arguments to the generate subcommand that generate the workload again.
+func (wl Workload) Args() []string {
+	return []string{
+		"-n", strconv.Itoa(wl.Count),
+		"-rate", strconv.FormatFloat(wl.ArrivalRate, 'g', -1, 64),
+		"-burst", wl.Burst.String(),
+		"-burst-mean", strconv.FormatFloat(wl.BurstMean, 'g', -1, 64),
+		"-priority", wl.Priority.String(),
+		"-max-priority", strconv.FormatInt(wl.MaxPriority, 10),
+		"-pareto-shape", strconv.FormatFloat(wl.ParetoShape, 'g', -1, 64),
+		"-seed", strconv.FormatInt(wl.Seed, 10),
+	}
+}
+
+// generate runs the generate subcommand with args, writing the workload they describe to w as CSV or JSON.
+func generate(w io.Writer, args []string) error {
+	var (
+		fs          = flag.NewFlagSet("generate", flag.ContinueOnError)
+		wl          = DefaultWorkload
+		burst       = fs.String("burst", wl.Burst.String(), "distribution of burst durations: uniform, exponential or pareto")
+		priority    = fs.String("priority", wl.Priority.String(), "distribution of priorities: uniform, exponential or pareto")
+		format      = fs.String("format", "csv", "write the processes as csv or json")
+		err         error
+		writeOutput func(io.Writer, Workload) error
+	)
+	fs.IntVar(&wl.Count, "n", wl.Count, "number of processes to generate")
+	fs.Float64Var(&wl.ArrivalRate, "rate", wl.ArrivalRate, "mean Poisson arrivals per tick, or 0 for all at time zero")
+	fs.Float64Var(&wl.BurstMean, "burst-mean", wl.BurstMean, "mean burst duration")
+	fs.Int64Var(&wl.MaxPriority, "max-priority", wl.MaxPriority, "lowest priority to generate, or 0 for none")
+	fs.Float64Var(&wl.ParetoShape, "pareto-shape", wl.ParetoShape, "tail index of Pareto distributions, over 1")
+	fs.Int64Var(&wl.Seed, "seed", wl.Seed, "seed for the generated workload")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: generate takes no arguments, got %v", ErrInvalidArgs, fs.Args())
+	}
+	if wl.Count < 0 || wl.BurstMean < 1 {
+		return fmt.Errorf("%w: need a count of at least 0 and a mean burst of at least 1", ErrInvalidArgs)
+	}
+	if wl.Burst, err = ParseSpread(*burst); err != nil {
+		return err
+	}
+	if wl.Priority, err = ParseSpread(*priority); err != nil {
+		return err
+	}
+	switch strings.ToLower(*format) {
+	case "csv":
+		writeOutput = writeProcessesCSV
+	case "json":
+		writeOutput = writeProcessesJSON
+	default:
+		return fmt.Errorf("%w: unknown output format %q", ErrInvalidArgs, *format)
+	}
+	return writeOutput(w, wl)
+}
+
+// writeProcessesCSV writes the positional columns of the workload's processes as rows loadProcesses reads
+// back, after a comment giving the arguments that generate them again.
+func writeProcessesCSV(w io.Writer, wl Workload) error {
+	if _, err := fmt.Fprintf(w, "# generate %s\n", strings.Join(wl.Args(), " ")); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	for _, p := range wl.Generate() {
+		row := []string{
+			strconv.FormatInt(p.ProcessID, 10),
+			strconv.FormatInt(p.BurstDuration, 10),
+			strconv.FormatInt(p.ArrivalTime, 10),
+			strconv.FormatInt(p.Priority, 10),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeProcessesJSON writes the positional fields of the workload's processes as a JSON document
+// loadProcessesJSON reads back, alongside the arguments that generate them again.
+func writeProcessesJSON(w io.Writer, wl Workload) error {
+	doc := generatedJSON{Generated: "generate " + strings.Join(append(wl.Args(), "-format", "json"), " ")}
+	for _, p := range wl.Generate() {
+		doc.Processes = append(doc.Processes, map[string]interface{}{
+			"id":       p.ProcessID,
+			"burst":    p.BurstDuration,
+			"arrival":  p.ArrivalTime,
+			"priority": p.Priority,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
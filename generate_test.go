@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWorkload_Generate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		wl   Workload
+	}{
+		{name: "default", wl: DefaultWorkload},
+		{name: "uniform bursts", wl: Workload{Count: 50, ArrivalRate: 2, Burst: UniformSpread, BurstMean: 3, Priority: ExponentialSpread, MaxPriority: 5, Seed: 2}},
+		{name: "pareto bursts", wl: Workload{Count: 50, ArrivalRate: 0.1, Burst: ParetoSpread, BurstMean: 10, Priority: ParetoSpread, MaxPriority: 50, ParetoShape: 1.5, Seed: 3}},
+		{name: "all at once", wl: Workload{Count: 5, Burst: ExponentialSpread, BurstMean: 1, Seed: 4}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.wl.Generate()
+			if len(got) != tt.wl.Count {
+				t.Fatalf("Generate() made %d processes, want %d", len(got), tt.wl.Count)
+			}
+			for i, p := range got {
+				if p.ProcessID != int64(i+1) || p.BurstDuration < 1 {
+					t.Errorf("Generate()[%d] = %+v, want ID %d and a burst of at least 1", i, p, i+1)
+				}
+				if i > 0 && p.ArrivalTime < got[i-1].ArrivalTime {
+					t.Errorf("Generate()[%d] arrives at %d, before %d", i, p.ArrivalTime, got[i-1].ArrivalTime)
+				}
+				if tt.wl.ArrivalRate <= 0 && p.ArrivalTime != 0 {
+					t.Errorf("Generate()[%d] arrives at %d, want 0", i, p.ArrivalTime)
+				}
+				if tt.wl.MaxPriority > 0 && (p.Priority < 1 || p.Priority > tt.wl.MaxPriority) {
+					t.Errorf("Generate()[%d] priority = %d, want 1 to %d", i, p.Priority, tt.wl.MaxPriority)
+				}
+				if tt.wl.MaxPriority <= 0 && p.Priority != 0 {
+					t.Errorf("Generate()[%d] priority = %d, want none", i, p.Priority)
+				}
+			}
+			if again := tt.wl.Generate(); !reflect.DeepEqual(again, got) {
+				t.Errorf("Generate() = %v, then %v", got, again)
+			}
+		})
+	}
+}
+
+func Test_generate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		load    func(b *bytes.Buffer) ([]Process, error)
+		wantErr error
+	}{
+		{
+			name: "csv",
+			args: []string{"-n", "20", "-burst", "pareto", "-seed", "9"},
+			load: func(b *bytes.Buffer) ([]Process, error) { return loadProcesses(b) },
+		},
+		{
+			name: "json",
+			args: []string{"-n", "20", "-format", "json", "-priority", "exponential", "-rate", "3"},
+			load: func(b *bytes.Buffer) ([]Process, error) { return loadProcessesJSON(b) },
+		},
+		{
+			name:    "unknown spread",
+			args:    []string{"-burst", "normal"},
+			wantErr: ErrUnknownSpread,
+		},
+		{
+			name:    "unknown format",
+			args:    []string{"-format", "yaml"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "arguments",
+			args:    []string{"processes.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var b bytes.Buffer
+			err := generate(&b, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("generate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := tt.load(&b)
+			if err != nil {
+				t.Fatalf("loading generated processes: %v", err)
+			}
+			if len(got) != 20 {
+				t.Errorf("generate() wrote %d processes, want 20", len(got))
+			}
+		})
+	}
+}
+
+func Test_generate_reproduces(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		generated func(t *testing.T, out []byte) string
+	}{
+		{
+			name: "csv",
+			args: []string{"-n", "15", "-burst", "uniform", "-burst-mean", "4", "-rate", "0.25", "-seed", "11"},
+			generated: func(t *testing.T, out []byte) string {
+				line, _, _ := strings.Cut(string(out), "\n")
+				return strings.TrimPrefix(line, "# ")
+			},
+		},
+		{
+			name: "json",
+			args: []string{"-n", "15", "-format", "json", "-burst", "pareto", "-pareto-shape", "1.5", "-seed", "12"},
+			generated: func(t *testing.T, out []byte) string {
+				var doc generatedJSON
+				if err := json.Unmarshal(out, &doc); err != nil {
+					t.Fatal(err)
+				}
+				return doc.Generated
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var first, again bytes.Buffer
+			if err := generate(&first, tt.args); err != nil {
+				t.Fatal(err)
+			}
+			args := strings.Fields(tt.generated(t, first.Bytes()))
+			if len(args) == 0 || args[0] != "generate" {
+				t.Fatalf("generate() recorded %q, want the generate subcommand", args)
+			}
+			args = args[1:]
+			if err := generate(&again, args); err != nil {
+				t.Fatal(err)
+			}
+			if first.String() != again.String() {
+				t.Errorf("generate(%q) wrote\n%s\nthen generate(%q) wrote\n%s", tt.args, first.String(), args, again.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// headerAliases are other names a CSV header row may give the positional columns.
+var headerAliases = map[string]string{
+	"pid":           "id",
+	"processid":     "id",
+	"burstduration": "burst",
+	"arrivaltime":   "arrival",
+}
+
+// headed reports whether a CSV row is a header naming the columns rather than a process, which always
+// starts with its numeric ID.
+func headed(row []string) bool {
+	_, err := strconv.ParseInt(strings.TrimSpace(row[0]), 10, 64)
+	return err != nil
+}
+
+// headerColumns returns the field each column of a CSV header row names, in any order, as one of the
+// positional columns (pid or id, burst, arrival, priority) or a key=value column key.
+func headerColumns(row []string) ([]string, *ParseError) {
+	header := make([]string, len(row))
+	seen := make(map[string]bool)
+	for c, column := range row {
+		name := strings.ToLower(strings.TrimSpace(column))
+		if alias, ok := headerAliases[name]; ok {
+			name = alias
+		}
+		if namedPositional[name] == nil && processFields[name] == nil {
+			return nil, &ParseError{Column: c + 1, Value: column, Err: fmt.Errorf("%w: unknown column in header", ErrInvalidColumn)}
+		}
+		if seen[name] {
+			return nil, &ParseError{Column: c + 1, Value: column, Err: fmt.Errorf("%w: column given twice in header", ErrInvalidColumn)}
+		}
+		seen[name] = true
+		header[c] = name
+	}
+	return header, nil
+}
+
+// headedProcess builds the n-th process, on CSV line line, from a row under header, leaving empty cells
+// unset, along with every value in the row that could not be loaded.
+func headedProcess(header, row []string, line, n int) (Process, ParseErrors) {
+	if len(row) > len(header) {
+		err := fmt.Errorf("%w: more columns than the header", ErrInvalidColumn)
+		return Process{}, ParseErrors{{Line: line, Column: len(header) + 1, Err: err}}
+	}
+	o := make(map[string]interface{}, len(row))
+	for c, cell := range row {
+		if cell = strings.TrimSpace(cell); cell != "" {
+			o[header[c]] = cell
+		}
+	}
+
+	p, errs := processFromFields(o, n)
+	for _, e := range errs {
+		e.Line, e.Process = line, 0
+		for c, name := range header {
+			if name == e.Field {
+				e.Column, e.Field = c+1, ""
+			}
+		}
+	}
+	return p, errs
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// AgedSJFSchedule outputs a non-preemptive shortest-job-first schedule with aging of processes in a GANTT chart
+// and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the aging coefficient, how many ticks of burst each tick of waiting is worth
+//
+// Whenever the CPU is free the ready process with the lowest score, its burst less coefficient times
+// its wait, is run to completion. A coefficient of zero is plain shortest-job-first and a large one
+// approaches first-come, first-serve; the score each process was dispatched with is shown.
+func AgedSJFSchedule(w io.Writer, title string, processes []Process, coefficient float64) {
+	r := agedSJF(processes, coefficient)
+	outputResult(w, title, r)
+	if len(processes) > 0 {
+		_, _ = fmt.Fprintf(w, "%s: %g\n", msg(w, "Aging coefficient"), coefficient)
+	}
+}
+
+func agedSJF(processes []Process, coefficient float64) Result {
+	scores := make([]string, len(processes))
+	r := simulate(processes, policy{
+		pick: func(ready []*job, _ int64) int {
+			score := func(j *job) float64 {
+				return float64(j.remaining) - coefficient*float64(j.waited)
+			}
+			best := 0
+			for i := range ready {
+				if score(ready[i]) < score(ready[best]) {
+					best = i
+				}
+			}
+			scores[ready[best].index] = fmt.Sprintf("%.2f", score(ready[best]))
+			return best
+		},
+	})
+	r.Columns = append(r.Columns, Column{Header: "Score", Values: scores})
+	return r
+}
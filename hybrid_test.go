@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAgedSJFSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{
+			ProcessID:     1,
+			ArrivalTime:   0,
+			BurstDuration: 10,
+		},
+		{
+			ProcessID:     2,
+			ArrivalTime:   1,
+			BurstDuration: 7,
+		},
+		{
+			ProcessID:     3,
+			ArrivalTime:   2,
+			BurstDuration: 2,
+		},
+		{
+			ProcessID:     4,
+			ArrivalTime:   3,
+			BurstDuration: 3,
+		},
+		{
+			ProcessID:     5,
+			ArrivalTime:   4,
+			BurstDuration: 1,
+		},
+	}
+	tests := []struct {
+		name        string
+		coefficient float64
+		wantOut     string
+	}{
+		{
+			name:        "no aging is shortest job first",
+			coefficient: 0,
+			wantOut:     loadFixture(t, "hybrid_sjf_test.txt"),
+		},
+		{
+			name:        "half a tick of burst per tick waited",
+			coefficient: 0.5,
+			wantOut:     loadFixture(t, "hybrid_test.txt"),
+		},
+		{
+			name:        "heavy aging is first come first serve",
+			coefficient: 10,
+			wantOut:     loadFixture(t, "hybrid_fcfs_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			AgedSJFSchedule(&w, "SJF with aging", processes, tt.coefficient)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("AgedSJFSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// cpuTime is how long a CPU, or all of them together, spent running processes, how long deciding what
+// to run, and how long idle, held idle on purpose included, from time 0 until the last slice of a
+// schedule ended.
+type cpuTime struct {
+	busy, overhead, idle int64
+}
+
+// utilization is the share of the CPU time spent running processes.
+func (c cpuTime) utilization() float64 {
+	if c.busy+c.overhead+c.idle == 0 {
+		return 0
+	}
+	return float64(c.busy) / float64(c.busy+c.overhead+c.idle)
+}
+
+// cpuTimes returns the busy, overhead and idle time of each CPU of a GANTT chart, and of all of them
+// together. Time that slices overlap on one CPU counts once, to the slice starting first.
+func cpuTimes(gantt []TimeSlice) (perCPU []cpuTime, total cpuTime) {
+	if len(gantt) == 0 {
+		return nil, total
+	}
+	perCPU = make([]cpuTime, cpuCount(gantt))
+	start, stop := (svgChart{gantt: gantt}).span()
+	sorted := append([]TimeSlice(nil), gantt...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+	covered := make([]int64, len(perCPU))
+	for i := range covered {
+		covered[i] = start
+	}
+	for _, s := range sorted {
+		if s.PID < 0 && s.PID != overheadPID {
+			continue
+		}
+		from := s.Start
+		if covered[s.CPU] > from {
+			from = covered[s.CPU]
+		}
+		if s.Stop <= from {
+			continue
+		}
+		covered[s.CPU] = s.Stop
+		if s.PID == overheadPID {
+			perCPU[s.CPU].overhead += s.Stop - from
+		} else {
+			perCPU[s.CPU].busy += s.Stop - from
+		}
+	}
+	for i := range perCPU {
+		perCPU[i].idle = stop - start - perCPU[i].busy - perCPU[i].overhead
+		total.busy += perCPU[i].busy
+		total.overhead += perCPU[i].overhead
+		total.idle += perCPU[i].idle
+	}
+	return perCPU, total
+}
+
+// outputCPUTime outputs the CPU utilization of a schedule and its busy and idle time, with the time spent
+// deciding what to run if any was, and those of each CPU if it ran on several.
+func outputCPUTime(w io.Writer, gantt []TimeSlice) {
+	perCPU, total := cpuTimes(gantt)
+	if len(perCPU) == 0 {
+		return
+	}
+	unit := unitOf(w)
+	line := func(name string, c cpuTime) {
+		_, _ = fmt.Fprintf(w, "%s %.1f%%: busy %s", name, 100*c.utilization(), unit.format(c.busy))
+		if total.overhead > 0 {
+			_, _ = fmt.Fprintf(w, ", overhead %s", unit.format(c.overhead))
+		}
+		_, _ = fmt.Fprintf(w, ", idle %s\n", unit.format(c.idle))
+	}
+	line(msg(w, "CPU utilization"), total)
+	if len(perCPU) > 1 {
+		for i, c := range perCPU {
+			line(fmt.Sprintf("  %s %d", msg(w, "CPU"), i), c)
+		}
+	}
+}
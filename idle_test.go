@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_cpuTimes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		gantt      []TimeSlice
+		wantPerCPU []cpuTime
+		wantTotal  cpuTime
+	}{
+		{name: "empty"},
+		{
+			name:       "arrival gap and held idle",
+			gantt:      []TimeSlice{{PID: 1, Start: 2, Stop: 4}, {PID: heldPID, Start: 4, Stop: 5}, {PID: 2, Start: 5, Stop: 8}},
+			wantPerCPU: []cpuTime{{busy: 5, idle: 3}},
+			wantTotal:  cpuTime{busy: 5, idle: 3},
+		},
+		{
+			name:       "decision overhead",
+			gantt:      []TimeSlice{{PID: overheadPID, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 5}, {PID: 2, Start: 6, Stop: 8}},
+			wantPerCPU: []cpuTime{{busy: 5, overhead: 2, idle: 1}},
+			wantTotal:  cpuTime{busy: 5, overhead: 2, idle: 1},
+		},
+		{
+			name: "per CPU",
+			gantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4, CPU: 0},
+				{PID: 2, Start: 0, Stop: 1, CPU: 1},
+				{PID: idlePID, Start: 1, Stop: 3, CPU: 1},
+				{PID: 3, Start: 3, Stop: 4, CPU: 1},
+			},
+			wantPerCPU: []cpuTime{{busy: 4}, {busy: 2, idle: 2}},
+			wantTotal:  cpuTime{busy: 6, idle: 2},
+		},
+		{
+			name:       "overlapping slices count once",
+			gantt:      []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 1, Stop: 2}, {PID: 4, Start: 6, Stop: 7}},
+			wantPerCPU: []cpuTime{{busy: 5, idle: 2}},
+			wantTotal:  cpuTime{busy: 5, idle: 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			perCPU, total := cpuTimes(tt.gantt)
+			if !reflect.DeepEqual(perCPU, tt.wantPerCPU) || total != tt.wantTotal {
+				t.Errorf("cpuTimes() = %v, %v, want %v, %v", perCPU, total, tt.wantPerCPU, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func Test_outputCPUTime(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputCPUTime(WithTimeUnit(&w, TimeUnit{Name: "ms"}), []TimeSlice{
+		{PID: 1, Start: 0, Stop: 4, CPU: 0},
+		{PID: 2, Start: 0, Stop: 1, CPU: 1},
+		{PID: 3, Start: 3, Stop: 4, CPU: 1},
+	})
+	want := "CPU utilization 75.0%: busy 6ms, idle 2ms\n" +
+		"  CPU 0 100.0%: busy 4ms, idle 0ms\n" +
+		"  CPU 1 50.0%: busy 2ms, idle 2ms\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputCPUTime() = %q, want %q", got, want)
+	}
+}
+
+func Test_outputCPUTime_overhead(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputCPUTime(&w, []TimeSlice{{PID: overheadPID, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 5}, {PID: 2, Start: 6, Stop: 8}})
+	if got, want := w.String(), "CPU utilization 62.5%: busy 5, overhead 2, idle 1\n"; got != want {
+		t.Errorf("outputCPUTime() = %q, want %q", got, want)
+	}
+}
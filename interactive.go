@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// roundRobinName is what the interactive prompt calls the round-robin scheduler run without -policy.
+const roundRobinName = "round-robin"
+
+// prompter asks questions on w and reads the answers, one per line, from sc.
+type prompter struct {
+	sc *bufio.Scanner
+	w  io.Writer
+}
+
+// ask asks question and returns the answer, or def if the answer is empty. It reports false once there
+// are no more answers.
+func (p prompter) ask(question, def string) (string, bool) {
+	if def != "" {
+		question += " [" + def + "]"
+	}
+	_, _ = fmt.Fprintf(p.w, "%s: ", question)
+	if !p.sc.Scan() {
+		_, _ = fmt.Fprintln(p.w)
+		return "", false
+	}
+	if answer := strings.TrimSpace(p.sc.Text()); answer != "" {
+		return answer, true
+	}
+	return def, true
+}
+
+// askInt asks question until the answer is a whole number of at least min, or def if it is empty.
+func (p prompter) askInt(question string, def, min int64) (int64, error) {
+	for {
+		answer, ok := p.ask(question, strconv.FormatInt(def, 10))
+		if !ok {
+			return 0, fmt.Errorf("%w: no answer to %q", io.ErrUnexpectedEOF, question)
+		}
+		v, err := strconv.ParseInt(answer, 10, 64)
+		switch {
+		case err != nil:
+			_, _ = fmt.Fprintf(p.w, "  %q is not a whole number\n", answer)
+		case v < min:
+			_, _ = fmt.Fprintf(p.w, "  must be at least %d\n", min)
+		default:
+			return v, nil
+		}
+	}
+}
+
+// promptWorkload asks for processes one at a time on w, reading the answers from r, and then, if
+// askPolicy, for the scheduler to run them under: round-robin or a registered policy. Each answer
+// defaults to the value in brackets: the next free PID, the previous process's burst, arrival and
+// priority. Answering done, or ending the input, at a PID prompt finishes the processes.
+func promptWorkload(r io.Reader, w io.Writer, askPolicy bool) ([]Process, string, error) {
+	var (
+		p         = prompter{sc: bufio.NewScanner(r), w: w}
+		processes []Process
+		used      = make(map[int64]bool)
+		prev      = Process{BurstDuration: 1, Priority: 1}
+		next      = int64(1)
+	)
+	_, _ = fmt.Fprintln(w, "Enter each process, pressing Enter to accept the value in brackets, and done for the PID once all are entered.")
+	for {
+		_, _ = fmt.Fprintf(w, "Process %d\n", len(processes)+1)
+		for used[next] {
+			next++
+		}
+		answer, ok := p.ask("  PID", strconv.FormatInt(next, 10))
+		if !ok || strings.EqualFold(answer, "done") {
+			break
+		}
+		pid, err := strconv.ParseInt(answer, 10, 64)
+		if err != nil || used[pid] {
+			_, _ = fmt.Fprintf(w, "  %q is not a whole number or is already used\n", answer)
+			continue
+		}
+
+		proc := Process{ProcessID: pid}
+		if proc.BurstDuration, err = p.askInt("  Burst", prev.BurstDuration, 1); err != nil {
+			return nil, "", err
+		}
+		if proc.ArrivalTime, err = p.askInt("  Arrival", prev.ArrivalTime, 0); err != nil {
+			return nil, "", err
+		}
+		if proc.Priority, err = p.askInt("  Priority", prev.Priority, 0); err != nil {
+			return nil, "", err
+		}
+		processes = append(processes, proc)
+		used[pid], prev = true, proc
+	}
+	if len(processes) == 0 {
+		return nil, "", fmt.Errorf("%w: no processes entered", ErrInvalidArgs)
+	}
+	if !askPolicy {
+		return processes, "", nil
+	}
+
+	choices := append([]string{roundRobinName}, SchedulerNames()...)
+	for {
+		answer, ok := p.ask("Scheduler ("+strings.Join(choices, ", ")+")", roundRobinName)
+		if !ok {
+			return processes, roundRobinName, nil
+		}
+		for _, c := range choices {
+			if strings.EqualFold(answer, c) {
+				return processes, c, nil
+			}
+		}
+		_, _ = fmt.Fprintf(w, "  no scheduler is called %q\n", answer)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_promptWorkload(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		input      string
+		askPolicy  bool
+		want       []Process
+		wantPolicy string
+		wantErr    error
+	}{
+		{
+			name:      "defaults",
+			input:     "\n4\n\n3\n\n\n2\n\ndone\n",
+			askPolicy: true,
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 4, ArrivalTime: 0, Priority: 3},
+				{ProcessID: 2, BurstDuration: 4, ArrivalTime: 2, Priority: 3},
+			},
+			wantPolicy: roundRobinName,
+		},
+		{
+			name:      "invalid answers",
+			input:     "x\n7\n0\n-1\n5\n9\n\n7\n2\n1\n1\n1\ndone\nHRRN\n",
+			askPolicy: true,
+			want: []Process{
+				{ProcessID: 7, BurstDuration: 5, ArrivalTime: 9, Priority: 1},
+				{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1, Priority: 1},
+			},
+			wantPolicy: "hrrn",
+		},
+		{
+			name:    "no processes",
+			input:   "done\n",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "input ends mid-process",
+			input:   "1\n5\n",
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:  "policy given",
+			input: "1\n5\n0\n2\n",
+			want:  []Process{{ProcessID: 1, BurstDuration: 5, Priority: 2}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, policy, err := promptWorkload(strings.NewReader(tt.input), io.Discard, tt.askPolicy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("promptWorkload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("promptWorkload() = %+v, want %+v", got, tt.want)
+			}
+			if policy != tt.wantPolicy {
+				t.Errorf("promptWorkload() policy = %q, want %q", policy, tt.wantPolicy)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+type (
+	// Jitter perturbs the arrival times and burst durations of a workload with seeded random noise, so the
+	// same workload can be rerun under different noise and any run can be reproduced from its seed.
+	Jitter struct {
+		Distribution Distribution
+		// Arrival and Burst scale the noise added to arrival times and burst durations: the half-width of
+		// a uniform distribution, the standard deviation of a normal one or the mean of an exponential one.
+		Arrival float64
+		Burst   float64
+		Seed    int64
+	}
+	// Distribution is the shape of the noise Jitter adds.
+	Distribution int
+)
+
+const (
+	// UniformNoise is equally likely to be anywhere within the scale either side of zero.
+	UniformNoise Distribution = iota
+	// NormalNoise is normally distributed around zero with the scale as its standard deviation.
+	NormalNoise
+	// ExponentialNoise only ever delays arrivals and lengthens bursts, by the scale on average.
+	ExponentialNoise
+)
+
+// ErrUnknownDistribution is returned when a noise distribution name is not recognised.
+var ErrUnknownDistribution = errors.New("unknown distribution")
+
+var distributionNames = []string{"uniform", "normal", "exponential"}
+
+// ParseDistribution returns the distribution with the given name.
+func ParseDistribution(name string) (Distribution, error) {
+	for d, n := range distributionNames {
+		if strings.EqualFold(name, n) {
+			return Distribution(d), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q (known: %v)", ErrUnknownDistribution, name, distributionNames)
+}
+
+func (d Distribution) String() string {
+	if d < 0 || int(d) >= len(distributionNames) {
+		return fmt.Sprintf("Distribution(%d)", int(d))
+	}
+	return distributionNames[d]
+}
+
+// Apply returns a copy of processes with noise added to their arrival times and to each CPU burst, rounded
+// to whole ticks. Arrivals never move before zero and bursts never shrink below one tick.
+func (j Jitter) Apply(processes []Process) []Process {
+	var (
+		rng       = rand.New(rand.NewSource(j.Seed))
+		perturbed = make([]Process, len(processes))
+	)
+	noise := func(scale float64) int64 {
+		if scale <= 0 {
+			return 0
+		}
+		switch j.Distribution {
+		case NormalNoise:
+			return int64(math.Round(rng.NormFloat64() * scale))
+		case ExponentialNoise:
+			return int64(math.Round(rng.ExpFloat64() * scale))
+		default:
+			return int64(math.Round((2*rng.Float64() - 1) * scale))
+		}
+	}
+	burst := func(b int64) int64 {
+		if b += noise(j.Burst); b < 1 {
+			return 1
+		}
+		return b
+	}
+
+	for i, p := range processes {
+		if p.ArrivalTime += noise(j.Arrival); p.ArrivalTime < 0 {
+			p.ArrivalTime = 0
+		}
+		switch {
+		case len(p.Bursts) > 0:
+			p.Bursts = append([]int64(nil), p.Bursts...)
+			p.BurstDuration = 0
+			for k := 0; k < len(p.Bursts); k += 2 {
+				p.Bursts[k] = burst(p.Bursts[k])
+				p.BurstDuration += p.Bursts[k]
+			}
+		case p.BurstDuration > 0:
+			p.BurstDuration = burst(p.BurstDuration)
+		}
+		perturbed[i] = p
+	}
+	return perturbed
+}
+
+// outputJitter outputs the noise added to the workload and the seed that reproduces it.
+func outputJitter(w io.Writer, j Jitter) {
+	_, _ = fmt.Fprintf(w, "%s: %s; %s: %g; %s: %g; %s: %d\n", msg(w, "Jitter"), j.Distribution,
+		msg(w, "Arrival"), j.Arrival, msg(w, "Burst"), j.Burst, msg(w, "Seed"), j.Seed)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestJitter_Apply(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 6},
+		{ProcessID: 3, ArrivalTime: 5, BurstDuration: 5, Bursts: []int64{2, 4, 3}},
+	}
+	tests := []struct {
+		name  string
+		j     Jitter
+		check func(t *testing.T, j Jitter, got []Process)
+	}{
+		{
+			name: "no noise",
+			j:    Jitter{Seed: 3},
+			check: func(t *testing.T, _ Jitter, got []Process) {
+				if !reflect.DeepEqual(got, processes) {
+					t.Errorf("Apply() = %v, want %v", got, processes)
+				}
+			},
+		},
+		{
+			name: "same seed",
+			j:    Jitter{Distribution: NormalNoise, Arrival: 2, Burst: 2, Seed: 42},
+			check: func(t *testing.T, j Jitter, got []Process) {
+				if again := j.Apply(processes); !reflect.DeepEqual(again, got) {
+					t.Errorf("Apply() = %v, then %v", got, again)
+				}
+			},
+		},
+		{
+			name: "bounds",
+			j:    Jitter{Distribution: UniformNoise, Arrival: 10, Burst: 10, Seed: 7},
+			check: func(t *testing.T, _ Jitter, got []Process) {
+				for _, p := range got {
+					if p.ArrivalTime < 0 || p.BurstDuration < 1 {
+						t.Errorf("Apply() = %+v, want arrival >= 0 and burst >= 1", p)
+					}
+				}
+				if io := got[2].Bursts[1]; io != 4 {
+					t.Errorf("Apply() I/O burst = %d, want 4", io)
+				}
+				if cpu := got[2].Bursts[0] + got[2].Bursts[2]; cpu != got[2].BurstDuration {
+					t.Errorf("Apply() burst = %d, want the CPU total %d", got[2].BurstDuration, cpu)
+				}
+				if processes[2].Bursts[0] != 2 {
+					t.Errorf("Apply() changed the input bursts to %v", processes[2].Bursts)
+				}
+			},
+		},
+		{
+			name: "exponential only delays",
+			j:    Jitter{Distribution: ExponentialNoise, Arrival: 3, Burst: 3, Seed: 9},
+			check: func(t *testing.T, _ Jitter, got []Process) {
+				for i, p := range got {
+					if p.ArrivalTime < processes[i].ArrivalTime || p.BurstDuration < processes[i].BurstDuration {
+						t.Errorf("Apply() = %+v, want no earlier or shorter than %+v", p, processes[i])
+					}
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.check(t, tt.j, tt.j.Apply(processes))
+		})
+	}
+}
+
+func TestParseDistribution(t *testing.T) {
+	t.Parallel()
+	if d, err := ParseDistribution("Normal"); d != NormalNoise || err != nil {
+		t.Errorf("ParseDistribution() = %v, %v, want %v", d, err, NormalNoise)
+	}
+	if _, err := ParseDistribution("poisson"); !errors.Is(err, ErrUnknownDistribution) {
+		t.Errorf("error = %v, want %v", err, ErrUnknownDistribution)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scenarioLoaders read a scenario in each input format. Only YAML carries more than the processes.
+var scenarioLoaders = map[string]func(r io.Reader) (Scenario, error){
+	"csv":    processesOnly(loadProcesses),
+	"tsv":    processesOnly(delimited('\t')),
+	"json":   processesOnly(loadProcessesJSON),
+	"xlsx":   processesOnly(loadProcessesXLSX),
+	"perf":   processesOnly(perfTrace(time.Millisecond)),
+	"chrome": processesOnly(chromeTrace(time.Millisecond)),
+	"yaml":   loadScenarioYAML,
+	"yml":    loadScenarioYAML,
+}
+
+// scenarioLoader returns the loader for the named format, or when format is empty for the format of the
+// file's extension: JSON for .json, YAML for .yaml or .yml, tab-separated for .tsv, Excel for .xlsx, perf
+// script output for .perf and CSV otherwise.
+func scenarioLoader(format, file string) (func(r io.Reader) (Scenario, error), error) {
+	if format == "" {
+		format = "csv"
+		if ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(file), ".")); scenarioLoaders[ext] != nil {
+			format = ext
+		}
+	}
+	load, ok := scenarioLoaders[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown input format %q", ErrInvalidArgs, format)
+	}
+	return load, nil
+}
+
+// processesOnly makes a scenario loader of a format holding nothing but processes.
+func processesOnly(load func(r io.Reader) ([]Process, error)) func(r io.Reader) (Scenario, error) {
+	return func(r io.Reader) (Scenario, error) {
+		processes, err := load(r)
+		return Scenario{Processes: processes}, err
+	}
+}
+
+// namedPositional are the named fields of JSON, YAML and headed CSV input standing for the positional CSV
+// columns, set before any other field.
+var namedPositional = map[string]func(p *Process, v string) (err error){
+	"id": func(p *Process, v string) (err error) {
+		p.ProcessID, err = strconv.ParseInt(v, 10, 64)
+		return err
+	},
+	"burst": func(p *Process, v string) (err error) {
+		p.BurstDuration, err = strconv.ParseInt(v, 10, 64)
+		return err
+	},
+	"arrival": func(p *Process, v string) (err error) {
+		p.ArrivalTime, err = strconv.ParseInt(v, 10, 64)
+		return err
+	},
+	"priority": func(p *Process, v string) (err error) {
+		p.Priority, err = strconv.ParseInt(v, 10, 64)
+		return err
+	},
+}
+
+// generatedJSON is a JSON document of processes written by the generate subcommand, recording the
+// arguments that generate them again.
+type generatedJSON struct {
+	Generated string                   `json:"generated"`
+	Processes []map[string]interface{} `json:"processes"`
+}
+
+// loadProcessesJSON reads processes as a JSON array of objects, each with an id and burst, optionally an
+// arrival and priority, and any of the key=value column keys as named fields:
+//
+//	[{"id": 1, "burst": 5, "arrival": 0, "priority": 2, "deadline": 12, "bursts": [3, 4, 2]}]
+//
+// A field takes the same value as its column, or an array of its ;-separated parts, each of which may be
+// an array of its :-separated parts, as in "locks": [["disk", 1, 3]]. The array may instead be the
+// processes of an object written by the generate subcommand.
+func loadProcessesJSON(r io.Reader) ([]Process, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%w: reading JSON", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var objects []map[string]interface{}
+	if t := bytes.TrimSpace(raw); len(t) > 0 && t[0] == '{' {
+		var doc generatedJSON
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("%w: reading JSON", err)
+		}
+		objects = doc.Processes
+	} else if err := dec.Decode(&objects); err != nil {
+		return nil, fmt.Errorf("%w: reading JSON", err)
+	}
+
+	return processesFromFields(objects)
+}
+
+// processesFromFields builds a process from each object of named fields decoded from JSON or YAML.
+func processesFromFields(objects []map[string]interface{}) ([]Process, error) {
+	var (
+		processes = make([]Process, 0, len(objects))
+		errs      ParseErrors
+	)
+	for i, o := range objects {
+		p, pErrs := processFromFields(o, i+1)
+		if len(pErrs) > 0 {
+			errs = append(errs, pErrs...)
+			continue
+		}
+		processes = append(processes, p.instances()...)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if err := checkProcesses(processes); err != nil {
+		return nil, err
+	}
+	return processes, nil
+}
+
+// processFromFields builds the n-th process from an object of named fields, along with every field that
+// could not be loaded. It checks only the fields of that process.
+func processFromFields(o map[string]interface{}, n int) (Process, ParseErrors) {
+	var (
+		p    Process
+		errs ParseErrors
+	)
+	for _, required := range []string{"id", "burst"} {
+		if _, ok := o[required]; !ok {
+			errs = append(errs, &ParseError{Process: n, Field: required, Err: fmt.Errorf("%w: missing", ErrInvalidColumn)})
+		}
+	}
+	keys := make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+	// Positional fields go first, so a field like bursts may replace one.
+	sort.Slice(keys, func(a, b int) bool {
+		_, pa := namedPositional[strings.ToLower(keys[a])]
+		_, pb := namedPositional[strings.ToLower(keys[b])]
+		if pa != pb {
+			return pa
+		}
+		return keys[a] < keys[b]
+	})
+	for _, k := range keys {
+		v, err := fieldColumn(o[k], ";")
+		if err != nil {
+			errs = append(errs, &ParseError{Process: n, Field: k, Err: err})
+			continue
+		}
+		set, ok := namedPositional[strings.ToLower(k)]
+		if !ok {
+			set, ok = processFields[strings.ToLower(k)]
+		}
+		if !ok {
+			errs = append(errs, &ParseError{Process: n, Field: k, Err: fmt.Errorf("%w: unknown field", ErrInvalidColumn)})
+			continue
+		}
+		if err := set(&p, v); err != nil {
+			e := parseError(v, err)
+			e.Process, e.Field = n, k
+			errs = append(errs, e)
+		}
+	}
+	return p, errs
+}
+
+// fieldColumn formats a decoded field value as the CSV column it stands for, joining the parts of an array
+// with sep and of any array within it with ":".
+func fieldColumn(v interface{}, sep string) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			var err error
+			if parts[i], err = fieldColumn(e, ":"); err != nil {
+				return "", err
+			}
+		}
+		return strings.Join(parts, sep), nil
+	}
+	return "", fmt.Errorf("%w: %v is not a string, number or array", ErrInvalidColumn, v)
+}
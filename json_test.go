@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadProcessesJSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		r       io.Reader
+		want    []Process
+		wantErr error
+	}{
+		{
+			name: "positional fields",
+			r:    strings.NewReader(`[{"id": 1, "burst": 5, "arrival": 0, "priority": 2}, {"id": 2, "burst": 9, "arrival": 3}]`),
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+			},
+		},
+		{
+			name: "optional fields",
+			r: strings.NewReader(`[{"id": 1, "burst": 1, "class": "batch", "deadline": 12, "tickets": 3,
+				"bursts": [3, 4, 2], "locks": [["disk", 1, 3]], "depends": "2"}, {"id": 2, "burst": 1}]`),
+			want: []Process{
+				{
+					ProcessID:     1,
+					BurstDuration: 5,
+					Class:         "batch",
+					Deadline:      12,
+					Tickets:       3,
+					Bursts:        []int64{3, 4, 2},
+					Locks:         []Lock{{Resource: "disk", At: 1, Hold: 3}},
+					DependsOn:     []int64{2},
+				},
+				{ProcessID: 2, BurstDuration: 1},
+			},
+		},
+		{
+			name: "generated",
+			r:    strings.NewReader(`{"generated": "generate -n 1 -seed 3", "processes": [{"id": 1, "burst": 4, "arrival": 0, "priority": 7}]}`),
+			want: []Process{{ProcessID: 1, BurstDuration: 4, Priority: 7}},
+		},
+		{
+			name:    "bad JSON",
+			r:       strings.NewReader(`{"id": 1`),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "missing burst",
+			r:       strings.NewReader(`[{"id": 1}]`),
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name:    "unknown field",
+			r:       strings.NewReader(`[{"id": 1, "burst": 2, "colour": "red"}]`),
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name:    "boolean field",
+			r:       strings.NewReader(`[{"id": 1, "burst": 2, "class": true}]`),
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name:    "dependency cycle",
+			r:       strings.NewReader(`[{"id": 1, "burst": 2, "depends": [2]}, {"id": 2, "burst": 2, "depends": [1]}]`),
+			wantErr: ErrDependencyCycle,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadProcessesJSON(tt.r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadProcessesJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadProcessesJSON() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_scenarioLoader(t *testing.T) {
+	t.Parallel()
+	csv, err := scenarioLoader("", "example_processes_rr.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := csv(strings.NewReader("1,5,0,3\n2,4,1,1\n3,2,2,4\n4,1,3,2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []string{"", "JSON"} {
+		load, err := scenarioLoader(format, "example_processes_rr.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := load(strings.NewReader(`[{"id": 1, "burst": 5, "arrival": 0, "priority": 3},
+			{"id": 2, "burst": 4, "arrival": 1, "priority": 1}, {"id": 3, "burst": 2, "arrival": 2, "priority": 4},
+			{"id": 4, "burst": 1, "arrival": 3, "priority": 2}]`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("scenarioLoader(%q) loaded %+v, want %+v", format, got, want)
+		}
+	}
+	if _, err := scenarioLoader("toml", "processes.toml"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("scenarioLoader(toml) error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import "io"
+
+// LimitSchedule outputs a round-robin schedule of processes that are killed when they overrun their
+// limits in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes, where those with a MaxCPU or MaxTurnaround are killed on exceeding it
+// • the time quantum each process may run before being preempted
+// • whether killed processes are left out of the averages and throughput
+//
+// The table marks each killed process with the limit it overran, and its exit is when it was killed.
+func LimitSchedule(w io.Writer, title string, processes []Process, quantum int64, excludeKilled bool) {
+	if quantum < 1 {
+		quantum = defaultQuantum
+	}
+	outputResult(w, title, simulate(processes, policy{pick: firstReady, quantum: quantum, excludeKilled: excludeKilled}))
+}
+
+// expired reports whether j has gone unfinished for its MaxTurnaround by time t.
+func (j *job) expired(t int64) bool {
+	return j.MaxTurnaround > 0 && t-j.ArrivalTime >= j.MaxTurnaround
+}
+
+// overran reports whether j has run for its MaxCPU with some of its burst still to run.
+func (j *job) overran() bool {
+	return j.MaxCPU > 0 && j.executed >= j.MaxCPU && j.executed < j.BurstDuration
+}
+
+// limit names the limit a killed job overran.
+func (j *job) limit() string {
+	if j.MaxCPU > 0 && j.executed >= j.MaxCPU {
+		return "CPU limit"
+	}
+	return "turnaround limit"
+}
+
+// limited reports whether any process has a CPU or turnaround limit.
+func limited(processes []Process) bool {
+	for _, p := range processes {
+		if p.MaxCPU > 0 || p.MaxTurnaround > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLimitSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes     []Process
+		title         string
+		quantum       int64
+		excludeKilled bool
+	}
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, MaxCPU: 3},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, MaxTurnaround: 5},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2},
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "killed included in averages",
+			args: args{
+				processes: processes,
+				title:     "Limits",
+				quantum:   2,
+			},
+			wantOut: loadFixture(t, "limits_test.txt"),
+		},
+		{
+			name: "killed excluded from averages",
+			args: args{
+				processes:     processes,
+				title:         "Limits",
+				quantum:       2,
+				excludeKilled: true,
+			},
+			wantOut: loadFixture(t, "limits_excluded_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			LimitSchedule(&w, tt.args.title, tt.args.processes, tt.args.quantum, tt.args.excludeKilled)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("LimitSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// LockSchedule outputs a preemptive priority schedule of processes sharing locked resources in a GANTT
+// chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes, where those with Locks hold resources for parts of their burst
+// • whether a process holding a lock inherits the priority of the processes it blocks
+//
+// A process that needs a resource another holds blocks until it is handed the lock, so a high priority
+// process may wait while medium priority ones run ahead of the low priority holder. Priority inheritance
+// runs the holder at the priority of its highest priority waiter until it releases the lock. The time each
+// process spent blocked is shown in the table and, with inheritance, compared with the same schedule without.
+func LockSchedule(w io.Writer, title string, processes []Process, inherit bool) {
+	r := lockPriority(processes, inherit)
+	outputResult(w, title, r)
+	if inherit && locking(processes) {
+		outputInversion(w, r, lockPriority(processes, false))
+	}
+}
+
+// lockPriority runs processes by preemptive priority, blocking those that need a lock another holds,
+// with the holder inheriting the priority of its waiters if inherit.
+func lockPriority(processes []Process, inherit bool) Result {
+	return simulate(processes, policy{pick: highestPriority, preemptive: true, inherit: inherit})
+}
+
+// lockTable tracks which job holds each resource and which jobs are blocked waiting for it.
+type lockTable struct {
+	inherit bool
+	owners  map[string]*job
+	waiting map[string][]*job // jobs blocked on each resource, in the order they blocked
+}
+
+func newLockTable(inherit bool) *lockTable {
+	return &lockTable{inherit: inherit, owners: make(map[string]*job), waiting: make(map[string][]*job)}
+}
+
+// acquire takes the locks j must hold before running its next tick, reporting false if j instead blocks
+// at time t on a resource another job holds.
+func (l *lockTable) acquire(j *job, t int64) bool {
+	for j.locks < len(j.Locks) && j.Locks[j.locks].At <= j.executed {
+		r := j.Locks[j.locks].Resource
+		if o := l.owners[r]; o != nil {
+			j.waitsFor, j.blockedAt = r, t
+			l.waiting[r] = append(l.waiting[r], j)
+			if l.inherit {
+				l.boost(o, j.priority)
+			}
+			return false
+		}
+		l.owners[r] = j
+		j.locks++
+	}
+	return true
+}
+
+// release frees the locks whose sections j has finished, or all it holds once j completes, handing each
+// to its highest priority waiter. It returns the waiters that may run again.
+func (l *lockTable) release(j *job, t int64, completed bool) []*job {
+	var woken []*job
+	for _, lk := range j.Locks[:j.locks] {
+		if l.owners[lk.Resource] != j || (!completed && lk.At+lk.Hold > j.executed) {
+			continue
+		}
+		delete(l.owners, lk.Resource)
+		if w := l.handOff(lk.Resource, t); w != nil {
+			woken = append(woken, w)
+		}
+	}
+	if j.boosted {
+		l.restore(j)
+	}
+	return woken
+}
+
+// handOff gives resource r to the highest priority job waiting for it, keeping the earliest on ties.
+func (l *lockTable) handOff(r string, t int64) *job {
+	queue := l.waiting[r]
+	if len(queue) == 0 {
+		return nil
+	}
+	best := 0
+	for i := range queue {
+		if queue[i].priority < queue[best].priority {
+			best = i
+		}
+	}
+	w := queue[best]
+	l.waiting[r] = append(queue[:best], queue[best+1:]...)
+	w.waitsFor = ""
+	w.lockWait += t - w.blockedAt
+	l.owners[r] = w
+	w.locks++
+	if l.inherit {
+		for _, o := range l.waiting[r] {
+			l.boost(w, o.priority)
+		}
+	}
+	return w
+}
+
+// expire removes the jobs that have been waiting for locks past their MaxTurnaround at time t, in input
+// order, dropping any priority their holders inherited from them.
+func (l *lockTable) expire(t int64) []*job {
+	var expired []*job
+	for r, queue := range l.waiting {
+		kept := queue[:0]
+		for _, j := range queue {
+			if !j.expired(t) {
+				kept = append(kept, j)
+				continue
+			}
+			j.waitsFor = ""
+			j.lockWait += t - j.blockedAt
+			expired = append(expired, j)
+		}
+		l.waiting[r] = kept
+		if o := l.owners[r]; len(kept) < len(queue) && o != nil && o.boosted {
+			l.restore(o)
+		}
+	}
+	sort.Slice(expired, func(a, b int) bool { return expired[a].index < expired[b].index })
+	return expired
+}
+
+// boost raises the effective priority of o, and of the holders of any lock it is itself waiting for,
+// to priority.
+func (l *lockTable) boost(o *job, priority int64) {
+	for o != nil && priority < o.priority {
+		if !o.boosted {
+			o.own, o.boosted = o.priority, true
+		}
+		o.priority = priority
+		if o.waitsFor == "" {
+			return
+		}
+		o = l.owners[o.waitsFor]
+	}
+}
+
+// restore drops the priority j inherited to that of the highest priority job still waiting on its locks.
+func (l *lockTable) restore(j *job) {
+	priority := j.own
+	for r, o := range l.owners {
+		if o != j {
+			continue
+		}
+		for _, w := range l.waiting[r] {
+			if w.priority < priority {
+				priority = w.priority
+			}
+		}
+	}
+	j.priority, j.boosted = priority, priority != j.own
+}
+
+// locking reports whether any process holds locks.
+func locking(processes []Process) bool {
+	for _, p := range processes {
+		if len(p.Locks) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLocks reports lock sections out of order, extending past the end of their process's burst, or
+// taking a resource the process already holds.
+func checkLocks(processes []Process) error {
+	for _, p := range processes {
+		for i, lk := range p.Locks {
+			switch {
+			case i > 0 && lk.At < p.Locks[i-1].At:
+				return fmt.Errorf("%w: process %d locks %q out of order", ErrInvalidColumn, p.ProcessID, lk.Resource)
+			case lk.At+lk.Hold > p.BurstDuration:
+				return fmt.Errorf("%w: process %d holds %q past the end of its burst", ErrInvalidColumn, p.ProcessID, lk.Resource)
+			}
+			for _, prev := range p.Locks[:i] {
+				if prev.Resource == lk.Resource && prev.At+prev.Hold > lk.At {
+					return fmt.Errorf("%w: process %d locks %q while holding it", ErrInvalidColumn, p.ProcessID, lk.Resource)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// outputInversion compares the total time processes spent blocked on locks with and without inheritance.
+func outputInversion(w io.Writer, inherited, baseline Result) {
+	total := func(r Result) int64 {
+		var sum int64
+		for _, p := range r.Processes {
+			sum += p.Blocked
+		}
+		return sum
+	}
+	unit := unitOf(w)
+	_, _ = fmt.Fprintf(w, "%s: %s; %s: %s\n", msg(w, "Blocked on locks"), unit.format(total(inherited)),
+		msg(w, "Without priority inheritance"), unit.format(total(baseline)))
+}
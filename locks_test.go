@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLockSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 3, Locks: []Lock{{Resource: "R", At: 1, Hold: 3}}},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 3, Priority: 1, Locks: []Lock{{Resource: "R", At: 1, Hold: 1}}},
+		{ProcessID: 3, ArrivalTime: 3, BurstDuration: 4, Priority: 2},
+	}
+	type args struct {
+		processes []Process
+		title     string
+		inherit   bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "priority inversion",
+			args: args{
+				processes: processes,
+				title:     "Priority inversion",
+			},
+			wantOut: loadFixture(t, "locks_test.txt"),
+		},
+		{
+			name: "priority inheritance",
+			args: args{
+				processes: processes,
+				title:     "Priority inheritance",
+				inherit:   true,
+			},
+			wantOut: loadFixture(t, "locks_inherit_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			LockSchedule(&w, tt.args.title, tt.args.processes, tt.args.inherit)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("LockSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import "io"
+
+// LJFSchedule outputs a non-preemptive longest-job-first schedule of processes in a GANTT chart
+// and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+//
+// It is the mirror image of shortest-job-first and exists to contrast with it on the same workload.
+func LJFSchedule(w io.Writer, title string, processes []Process) {
+	outputResult(w, title, simulate(processes, policy{pick: longestRemaining}))
+}
+
+// LRTFSchedule outputs a preemptive longest-remaining-time-first schedule of processes in a GANTT chart
+// and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+func LRTFSchedule(w io.Writer, title string, processes []Process) {
+	outputResult(w, title, simulate(processes, policy{pick: longestRemaining, preemptive: true}))
+}
+
+// longestRemaining picks the ready job with the most remaining burst, keeping the earliest on ties.
+// Before a job first runs its remaining burst is its whole burst, so this also orders by job length.
+func longestRemaining(ready []*job, _ int64) int {
+	best := 0
+	for i := range ready {
+		if ready[i].remaining > ready[best].remaining {
+			best = i
+		}
+	}
+	return best
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestLongestSchedules(t *testing.T) {
+	t.Parallel()
+	// The same workload as TestSJFSchedule, for contrast.
+	processes := []Process{
+		{
+			ProcessID:     1,
+			ArrivalTime:   2,
+			BurstDuration: 6,
+			Priority:      2,
+		},
+		{
+			ProcessID:     2,
+			ArrivalTime:   5,
+			BurstDuration: 2,
+			Priority:      1,
+		},
+		{
+			ProcessID:     3,
+			ArrivalTime:   1,
+			BurstDuration: 8,
+			Priority:      3,
+		},
+		{
+			ProcessID:     4,
+			ArrivalTime:   0,
+			BurstDuration: 3,
+			Priority:      4,
+		},
+		{
+			ProcessID:     5,
+			ArrivalTime:   4,
+			BurstDuration: 4,
+			Priority:      5,
+		},
+	}
+	tests := []struct {
+		name     string
+		schedule func(w io.Writer, title string, processes []Process)
+		title    string
+		wantOut  string
+	}{
+		{
+			name:     "longest job first",
+			schedule: LJFSchedule,
+			title:    "Longest-job-first",
+			wantOut:  loadFixture(t, "ljf_test.txt"),
+		},
+		{
+			name:     "longest remaining time first",
+			schedule: LRTFSchedule,
+			title:    "Longest-remaining-time-first",
+			wantOut:  loadFixture(t, "lrtf_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			tt.schedule(&w, tt.title, processes)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("%s = %v, want %v", tt.title, got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// strideScale is divided by a process's tickets to give its stride; it is large enough to keep strides
+// proportionate for tickets taken from nice weights.
+const strideScale = 1 << 20
+
+// LotterySchedule outputs a lottery schedule of processes in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the time quantum each drawing wins
+// • the seed for the random number generator
+//
+// Each quantum a ticket is drawn from those held by the ready processes and its holder runs, so over
+// time each process gets the CPU in proportion to its tickets. A workload giving nice values but no
+// tickets holds the kernel's load weight for each nice value in tickets.
+func LotterySchedule(w io.Writer, title string, processes []Process, quantum, seed int64) {
+	outputResult(w, title, lottery(processes, quantum, seed))
+	if len(processes) > 0 {
+		_, _ = fmt.Fprintf(w, "%s: %d\n", msg(w, "Seed"), seed)
+	}
+}
+
+func lottery(processes []Process, quantum, seed int64) Result {
+	if quantum < 1 {
+		quantum = defaultQuantum
+	}
+	processes = niceTickets(processes)
+	rng := rand.New(rand.NewSource(seed))
+	r := simulate(processes, policy{
+		pick: func(ready []*job, _ int64) int {
+			var total int64
+			for _, j := range ready {
+				total += tickets(j.Process)
+			}
+			winner := rng.Int63n(total)
+			for i, j := range ready {
+				if winner -= tickets(j.Process); winner < 0 {
+					return i
+				}
+			}
+			return len(ready) - 1
+		},
+		quantum: quantum,
+	})
+	r.Columns = append(r.Columns, ticketColumn(processes))
+	return r
+}
+
+// StrideSchedule outputs a stride schedule of processes in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the time quantum of each dispatch
+//
+// It is the deterministic counterpart of lottery scheduling: each process advances its pass by a stride
+// inversely proportional to its tickets every time it runs, and the ready process with the lowest pass
+// runs next. A process joins at the lowest pass of those already ready so it cannot catch up on time it
+// was not ready for. As in lottery scheduling, nice values give tickets when none are set.
+func StrideSchedule(w io.Writer, title string, processes []Process, quantum int64) {
+	outputResult(w, title, stride(processes, quantum))
+}
+
+func stride(processes []Process, quantum int64) Result {
+	if quantum < 1 {
+		quantum = defaultQuantum
+	}
+	processes = niceTickets(processes)
+	var (
+		pass   = make([]int64, len(processes))
+		joined = make([]bool, len(processes))
+		global int64
+	)
+	r := simulate(processes, policy{
+		pick: func(ready []*job, _ int64) int {
+			// Newcomers join at the lowest pass among the processes already competing.
+			for _, j := range ready {
+				if joined[j.index] && pass[j.index] < global {
+					global = pass[j.index]
+				}
+			}
+			best := 0
+			for i, j := range ready {
+				if !joined[j.index] {
+					joined[j.index], pass[j.index] = true, global
+				}
+				if pass[j.index] < pass[ready[best].index] {
+					best = i
+				}
+			}
+			i := ready[best].index
+			pass[i] += strideScale / tickets(ready[best].Process)
+			global = pass[i]
+			return best
+		},
+		quantum: quantum,
+	})
+	r.Columns = append(r.Columns, ticketColumn(processes))
+	return r
+}
+
+// tickets is the number of tickets a process holds, defaulting to one.
+func tickets(p Process) int64 {
+	if p.Tickets < 1 {
+		return 1
+	}
+	return p.Tickets
+}
+
+// niceTickets returns processes with tickets from the load weights of their nice values when any process
+// has a nice value but none has tickets, so a workload written in nice values needs no tickets.
+func niceTickets(processes []Process) []Process {
+	niced := false
+	for _, p := range processes {
+		if p.Tickets > 0 {
+			return processes
+		}
+		niced = niced || p.Nice != 0
+	}
+	if !niced {
+		return processes
+	}
+	weighted := append([]Process(nil), processes...)
+	for i := range weighted {
+		weighted[i].Tickets = int64(niceWeight(weighted[i].Nice))
+	}
+	return weighted
+}
+
+func ticketColumn(processes []Process) Column {
+	values := make([]string, len(processes))
+	for i, p := range processes {
+		values[i] = fmt.Sprint(tickets(p))
+	}
+	return Column{Header: "Tickets", Values: values}
+}
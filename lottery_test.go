@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestProportionalShareSchedules(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{
+			ProcessID:     1,
+			ArrivalTime:   0,
+			BurstDuration: 6,
+			Tickets:       3,
+		},
+		{
+			ProcessID:     2,
+			ArrivalTime:   0,
+			BurstDuration: 6,
+			Tickets:       2,
+		},
+		{
+			ProcessID:     3,
+			ArrivalTime:   2,
+			BurstDuration: 4,
+		},
+	}
+	tests := []struct {
+		name     string
+		schedule func(w *bytes.Buffer)
+		wantOut  string
+	}{
+		{
+			name: "lottery",
+			schedule: func(w *bytes.Buffer) {
+				LotterySchedule(w, "Lottery", processes, 1, 42)
+			},
+			wantOut: loadFixture(t, "lottery_test.txt"),
+		},
+		{
+			name: "stride",
+			schedule: func(w *bytes.Buffer) {
+				StrideSchedule(w, "Stride", processes, 1)
+			},
+			wantOut: loadFixture(t, "stride_test.txt"),
+		},
+		{
+			name: "stride by nice value",
+			schedule: func(w *bytes.Buffer) {
+				StrideSchedule(w, "Stride", []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Nice: 5},
+				}, 1)
+			},
+			wantOut: loadFixture(t, "stride_nice_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			tt.schedule(&w)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.wantOut)
+			}
+		})
+	}
+}
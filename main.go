@@ -1,14 +1,16 @@
 package main
 
 import (
-	"container/heap"
+	"bytes"
 	"encoding/csv"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
-	"math"
+	"net/url"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -17,32 +19,376 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := generate(os.Stdout, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := diffResults(os.Stdout, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
+	policyName := flag.String("policy", "", "run the registered scheduling policy with this name instead")
+	algoNames := flag.String("algo", "", "run the schedulers in this comma-separated list instead of round-robin, each one of "+strings.Join(SchedulerNames(), ", "))
+	quantumFlag := flag.Int64("quantum", 0, "give the schedulers taking a time quantum this one instead of the scenario's")
+	hyperperiods := flag.Int64("hyperperiods", 0, "expand periodic tasks into their jobs over this many hyperperiods before scheduling")
+	jitterName := flag.String("jitter", "", "perturb arrivals and bursts with uniform, normal or exponential noise")
+	arrivalJitter := flag.Float64("arrival-jitter", 1, "scale of the noise added to arrival times")
+	burstJitter := flag.Float64("burst-jitter", 1, "scale of the noise added to burst durations")
+	seed := flag.Int64("seed", 1, "seed for the jitter noise")
+	starvation := flag.Int64("starvation", 0, "report processes that wait longer than this, or never run, after the schedule")
+	unitName := flag.String("unit", "abstract", "show times in this unit: abstract, s, ms, µs (or us) or ns")
+	scale := flag.Float64("scale", 1, "how much of the unit each tick stands for")
+	format := flag.String("format", "", "read the scheduling file as csv, tsv, json, yaml, xlsx, perf or chrome; by default, by its extension")
+	delimiter := flag.String("delimiter", "", "read the scheduling file as CSV separated by this character, or tab")
+	resultsFile := flag.String("csv", "", "also write each process's results as CSV to this file, or only them to standard output for -")
+	svgFile := flag.String("svg", "", "also draw the GANTT charts of the schedules in an SVG image saved to this file")
+	pngFile := flag.String("png", "", "also draw the GANTT charts and waiting times of the schedules in a PNG image saved to this file")
+	tikzFile := flag.String("tikz", "", "also write the GANTT charts of the schedules as TikZ pictures for LaTeX to this file, or only them to standard output for -")
+	mermaidFile := flag.String("mermaid", "", "also write the GANTT charts of the schedules as Mermaid diagrams for Markdown to this file, or only them to standard output for -")
+	traceFile := flag.String("trace", "", "also write the GANTT charts of the schedules as a Chrome trace, for chrome://tracing or Perfetto, to this file, or as a Perfetto protobuf trace if it ends .pftrace")
+	colorMode := flag.String("color", "auto", "colour the GANTT chart by PID: auto when writing to a terminal, always or never")
+	width := flag.Int("width", 0, "draw the GANTT chart this many columns wide with cells as wide as slices ran, by default as wide as the terminal, or with cells of one width if negative or not writing to a terminal")
+	vertical := flag.Bool("vertical", false, "lay the GANTT chart out as a timeline of a line per slice, for long schedules")
+	workbookFile := flag.String("xlsx", "", "also write a summary, each schedule table and the GANTT chart slices of the schedules as sheets of an Excel workbook saved to this file")
+	eventsFile := flag.String("events", "", "also write the arrivals, dispatches, preemptions, completions and idle times of the schedules as JSON lines to this file, or only them to standard output for -")
+	readyFile := flag.String("ready", "", "also write the length of the ready queue of the schedules each time it changed as CSV to this file, or only them to standard output for -")
+	sparkline := flag.Bool("sparkline", false, "follow each schedule table with a sparkline of the length of its ready queue over time")
+	compare := flag.Bool("compare", false, "compare the default schedulers, or those of -algo, and any -policy, on the processes in one table instead")
+	deltas := flag.Bool("deltas", false, "with -compare, also show each process's wait under every scheduler against the first")
+	stats := flag.Bool("stats", false, "follow each schedule table with the standard deviation, minimum, median, 95th and 99th percentiles and maximum of the waiting and turnaround times, and the busy and idle time of each CPU")
+	waitBars := flag.Bool("bars", false, "follow each schedule table with a bar chart of how long each process waited")
+	window := flag.Int64("window", 0, "follow each schedule table with how many processes completed in each window of this many ticks, and their throughput")
+	quiet := flag.Bool("quiet", false, "output only a line of each schedule's average wait, average turnaround, throughput, context switches, CPU utilization and idle time")
+	quietJSON := flag.Bool("json", false, "with -quiet, output each line of metrics as a JSON object")
+	templateFile := flag.String("template", "", "output each schedule by executing the Go text/template in this file with its title, processes, slices and metrics, instead of the chart and tables")
+	sortName := flag.String("sort", "scheduled", "sort schedule table rows by pid, arrival, completion or wait, or keep the order the scheduler left them in")
+	catalogFile := flag.String("messages", "", "translate the headers and labels of the output with the YAML catalog of English messages in this file")
+	renumber := flag.Bool("renumber", false, "give processes whose ID is already used a fresh ID instead of failing")
+	interactive := flag.Bool("interactive", false, "enter the processes and scheduler at prompts instead of reading a scheduling file")
+	serveAddr := flag.String("serve", "", "run as a service on this address, simulating the scheduling files posted to /schedule and serving metrics of them on /metrics")
+	flag.Parse()
+	if *serveAddr != "" {
+		log.Fatal(listenAndServe(*serveAddr))
+	}
+	unit, err := ParseTimeUnit(*unitName, *scale)
+	if err != nil {
+		log.Fatal(err)
+	}
+	out := WithTimeUnit(os.Stdout, unit)
+	color, err := useColor(*colorMode, os.Stdout)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if color {
+		out = WithColor(out)
+	}
+	if *vertical {
+		out = WithVertical(out)
+	}
+	if *catalogFile != "" {
+		f, err := os.Open(*catalogFile)
+		if err != nil {
+			log.Fatalf("%v: error opening message catalog", err)
+		}
+		c, err := LoadCatalog(f)
+		_ = f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+		out = WithCatalog(out, c)
+	}
+	order, err := ParseSortOrder(*sortName)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer closeFile()
+	var selected []registration
+	if *algoNames != "" {
+		if selected, err = parseAlgorithms(*algoNames); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *quantumFlag < 0 {
+		log.Fatalf("%v: quantum %d is not a positive number of ticks", ErrInvalidArgs, *quantumFlag)
+	}
+	// paramsOf is what the schedulers are run with on a scenario: its quantum unless -quantum gives one, and its CPUs.
+	paramsOf := func(s Scenario) runParams {
+		params := runParams{quantum: s.quantum(), cpus: s.cpus()}
+		if *quantumFlag > 0 {
+			params.quantum = *quantumFlag
+		}
+		return params
+	}
+	out = WithSortOrder(out, order)
+	if *stats {
+		out = WithStatistics(out)
+	}
+	if *waitBars {
+		out = WithWaitBars(out)
+	}
+	if *sparkline {
+		out = WithSparkline(out)
+	}
+	if *window > 0 {
+		out = WithThroughputWindows(out, *window)
+	}
+	if *quiet {
+		out = WithQuiet(out, *quietJSON)
+	}
+	if *templateFile != "" {
+		t, err := loadTemplate(*templateFile)
+		if err != nil {
+			log.Fatalf("%v: error loading output template", err)
+		}
+		out = WithTemplate(out, t)
+	}
+	if n := chartWidth(*width, os.Stdout); n > 0 {
+		out = WithWidth(out, n)
+	}
+	// export has schedules also written to the file called name, or only to standard output for -,
+	// in place of the charts and tables, returning what closes the file.
+	export := func(name, what string, with func(w, dst io.Writer) io.Writer) func() {
+		switch name {
+		case "":
+			return func() {}
+		case "-":
+			out = with(discardText(out), os.Stdout)
+			return func() {}
+		}
+		f, err := os.Create(name)
+		if err != nil {
+			log.Fatalf("%v: error creating %s file", err, what)
+		}
+		out = with(out, f)
+		return func() {
+			if err := f.Close(); err != nil {
+				log.Fatalf("%v: error closing %s file", err, what)
+			}
+		}
+	}
+	defer export(*resultsFile, "results", WithResultsCSV)()
+	defer export(*tikzFile, "TikZ", WithTikZ)()
+	defer export(*mermaidFile, "Mermaid", WithMermaid)()
+	defer export(*eventsFile, "events", WithEventLog)()
+	defer export(*readyFile, "ready queue", WithReadyQueueCSV)()
+	if *svgFile != "" {
+		svg := &GanttSVG{}
+		out = WithGanttSVG(out, svg)
+		defer saveChart(*svgFile, svg)
+	}
+	if *traceFile != "" {
+		trace := &TraceExport{}
+		out = WithTraceExport(out, trace)
+		switch strings.ToLower(filepath.Ext(*traceFile)) {
+		case ".pftrace", ".perfetto-trace", ".pb":
+			defer saveChart(*traceFile, PerfettoTrace{trace})
+		default:
+			defer saveChart(*traceFile, trace)
+		}
+	}
+	if *pngFile != "" {
+		img := &ChartPNG{}
+		out = WithChartPNG(out, img)
+		defer saveChart(*pngFile, img)
+	}
+	if *workbookFile != "" {
+		book := &ResultsWorkbook{}
+		out = WithResultsWorkbook(out, book)
+		defer saveChart(*workbookFile, book)
+	}
 
-	// Load and parse processes
-	processes, err := loadProcesses(f)
+	var jitter *Jitter
+	if *jitterName != "" {
+		d, err := ParseDistribution(*jitterName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		jitter = &Jitter{Distribution: d, Arrival: *arrivalJitter, Burst: *burstJitter, Seed: *seed}
+	}
+	prepare := func(processes []Process) []Process {
+		if *hyperperiods > 0 {
+			processes = expandPeriodic(processes, *hyperperiods)
+		}
+		if jitter != nil {
+			processes = jitter.Apply(processes)
+		}
+		return processes
+	}
+
+	files, batch, err := batchFiles(flag.Args())
 	if err != nil {
 		log.Fatal(err)
 	}
+	if batch && !*interactive {
+		if *starvation > 0 {
+			log.Fatalf("%v: -starvation reports on the schedules shown, which batches leave out", ErrInvalidArgs)
+		}
+		batched := selected
+		if *policyName != "" {
+			reg, err := lookupScheduler(*policyName)
+			if err != nil {
+				log.Fatal(err)
+			}
+			batched = append(batched, reg)
+		}
+		if len(batched) == 0 {
+			batched = []registration{schedulers["rr"]}
+		}
+		if jitter != nil && !*quiet {
+			outputJitter(out, *jitter)
+		}
+		for _, reg := range batched {
+			reg := reg
+			load := func(file string) (Scenario, error) {
+				s, err := loadScenario(*format, *delimiter, []string{file}, *renumber)
+				s.Processes = prepare(s.Processes)
+				if err == nil {
+					err = reg.accepts(s.Processes)
+				}
+				return s, err
+			}
+			BatchSchedule(out, files, load, func(s Scenario) Algorithm { return reg.algorithm(paramsOf(s)) })
+		}
+		return
+	}
+
+	var scenario Scenario
+	if *interactive {
+		var policy string
+		if scenario.Processes, policy, err = promptWorkload(os.Stdin, os.Stdout, *policyName == ""); err != nil {
+			log.Fatal(err)
+		}
+		if policy != "" && policy != roundRobinName {
+			*policyName = policy
+		}
+	} else if scenario, err = loadScenario(*format, *delimiter, flag.Args(), *renumber); err != nil {
+		log.Fatal(err)
+	}
+	processes, params := prepare(scenario.Processes), paramsOf(scenario)
+	if !*quiet {
+		outputScenario(out, scenario)
+		if jitter != nil {
+			outputJitter(out, *jitter)
+		}
+	}
 
-	// First-come, first-serve scheduling
-	//FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
+	var policies []registration
+	if *policyName != "" {
+		reg, err := lookupScheduler(*policyName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		policies = append(policies, reg)
+	}
 
-	//SJFSchedule(os.Stdout, "Shortest-job-first", processes)
+	if *compare {
+		if *starvation > 0 {
+			log.Fatalf("%v: -starvation reports on the schedules shown, which -compare leaves out", ErrInvalidArgs)
+		}
+		compared := selected
+		if len(compared) == 0 {
+			compared = defaultSchedulers()
+		}
+		compared = append(compared, policies...)
+		algorithms := make([]Algorithm, 0, len(compared))
+		for _, reg := range compared {
+			if err := reg.accepts(processes); err != nil {
+				log.Fatal(err)
+			}
+			algorithms = append(algorithms, reg.algorithm(params))
+		}
+		CompareSideBySide(out, "Comparison", processes, algorithms, *deltas)
+		return
+	}
 
-	//SJFPrioritySchedule(os.Stdout, "Priority", processes)
+	selected = append(selected, policies...)
+	if len(selected) == 0 {
+		selected = []registration{schedulers["rr"]}
+	}
+	for _, reg := range selected {
+		if err := reg.accepts(processes); err != nil {
+			log.Fatal(err)
+		}
+	}
+	for _, reg := range selected {
+		reg.output(out, reg.title, processes, params)
+		if *starvation > 0 && !custom(out) {
+			outputStarved(out, reg.algorithm(params).Run(processes), *starvation)
+		}
+	}
+}
 
-	RRSchedule(os.Stdout, "Round-robin", processes)
+// loadScenario loads the scenario in the scheduling file, URL or standard input named by args, in the
+// given format or separated by delimiter. Processes sharing an ID are an error unless renumber, which
+// gives each after the first a fresh ID and logs the change.
+func loadScenario(format, delimiter string, args []string, renumber bool) (Scenario, error) {
+	var (
+		in   io.Reader
+		name string
+		path string // the name the format is told from, leaving out any URL query
+		u    *url.URL
+		ok   bool
+	)
+	if len(args) == 1 {
+		u, ok = workloadURL(args[0])
+	}
+	if ok {
+		b, err := fetchWorkload(u, fetchTimeout, maxFetchSize)
+		if err != nil {
+			return Scenario{}, err
+		}
+		in, name, path = bytes.NewReader(b), u.String(), u.Path
+	} else {
+		f, closeFile, err := openProcessingFile(append([]string{os.Args[0]}, args...)...)
+		if err != nil {
+			return Scenario{}, err
+		}
+		defer closeFile()
+		in, name, path = f, f.Name(), f.Name()
+	}
+
+	// Load and parse processes
+	load, err := scenarioLoader(format, path)
+	if err != nil {
+		return Scenario{}, err
+	}
+	if delimiter != "" {
+		comma, err := parseDelimiter(delimiter)
+		if err != nil {
+			return Scenario{}, err
+		}
+		load = processesOnly(delimited(comma))
+	}
+	scenario, err := load(in)
+	if err != nil {
+		return Scenario{}, inFile(err, name)
+	}
+	if !renumber {
+		if err := checkUnique(scenario.Processes); err != nil {
+			return Scenario{}, fmt.Errorf("%w in %s; run with -renumber to give duplicates fresh IDs", err, name)
+		}
+		return scenario, nil
+	}
+	for _, c := range renumberDuplicates(scenario.Processes) {
+		log.Printf("%s: renumbered duplicate process %d to %d", name, c.from, c.to)
+	}
+	return scenario, nil
 }
 
+// openProcessingFile opens the scheduling file named by the argument after the binary name, or standard
+// input when that is "-" or there is none, returning it with a function that closes it.
 func openProcessingFile(args ...string) (*os.File, func(), error) {
-	if len(args) != 2 {
-		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
+	if len(args) > 2 {
+		return nil, nil, fmt.Errorf("%w: must give at most one scheduling file to process", ErrInvalidArgs)
+	}
+	if len(args) < 2 || args[1] == "-" {
+		// Standard input is left open for the process to close.
+		return os.Stdin, func() {}, nil
 	}
 	// Read in CSV process CSV file
 	f, err := os.Open(args[1])
@@ -64,516 +410,1008 @@ type (
 		ArrivalTime   int64
 		BurstDuration int64
 		Priority      int64
+		// Class names the queue a process belongs to in multilevel queue scheduling.
+		Class string
+		// Nice is the Unix-style niceness weighting a process under fair scheduling.
+		Nice int64
+		// Deadline is the absolute time by which the process should complete; zero means none.
+		Deadline int64
+		// Period makes the process a periodic task releasing a job of BurstDuration every Period ticks.
+		Period int64
+		// Repeat limits a periodic task to this many jobs; zero releases jobs for the whole simulation.
+		Repeat int64
+		// Job numbers the jobs of a periodic task from 1 once expandPeriodic has released them; it is zero
+		// for every other process.
+		Job int64
+		// User owns the process for fair-share scheduling.
+		User string
+		// Warp is how far ahead of its virtual time a latency-sensitive process is scheduled under BVT.
+		Warp int64
+		// Runtime is the CPU budget a deadline server may use each Period.
+		Runtime int64
+		// Threads is how many CPUs a multi-threaded job occupies at once under gang scheduling.
+		Threads int64
+		// Tickets is the share of the CPU a process is entitled to under proportional-share scheduling;
+		// zero means the default of one.
+		Tickets int64
+		// Bursts alternates CPU and I/O bursts, starting and ending with a CPU burst; BurstDuration is
+		// their CPU total. A process without Bursts is a single CPU burst.
+		Bursts []int64
+		// DependsOn lists the processes that must complete before this one may run.
+		DependsOn []int64
+		// PriorityChanges are scheduled changes to the priority of the process, in time order.
+		PriorityChanges []PriorityChange
+		// Spawns are the children the process forks as it runs, in the order they are forked.
+		Spawns []Spawn
+		// Affinity is the set of CPUs the process may run on in multi-CPU scheduling; empty means any.
+		Affinity []int
+		// Locks are the resources the process holds for parts of its burst, in the order it takes them.
+		Locks []Lock
+		// Memory is how much memory the process needs to be admitted under admission control.
+		Memory int64
+		// Suspensions are the times an operator stops and later resumes the process, in time order.
+		Suspensions []Suspension
+		// MaxCPU kills the process once it has run this long without finishing; zero means no limit.
+		MaxCPU int64
+		// MaxTurnaround kills the process if it has not finished this long after it arrived; zero means
+		// no limit.
+		MaxTurnaround int64
+		// Count makes the process a template loaded as this many copies, with consecutive IDs from its own;
+		// loaded processes are never templates.
+		Count int64
+		// Stagger is how long after each copy of a template the next one arrives.
+		Stagger int64
+	}
+	// Lock is a resource held exclusively from when its process has run At ticks until it has run
+	// Hold more.
+	Lock struct {
+		Resource string
+		At       int64
+		Hold     int64
+	}
+	// Spawn is a child process forked once its parent has run for After ticks. A zero Priority
+	// inherits the parent's priority at the time of the fork.
+	Spawn struct {
+		After         int64
+		ProcessID     int64
+		BurstDuration int64
+		Priority      int64
+	}
+	// PriorityChange sets the priority of a process from time At on.
+	PriorityChange struct {
+		At       int64
+		Priority int64
+	}
+	// Suspension stops a process from running from time From until it is resumed at Until.
+	Suspension struct {
+		From  int64
+		Until int64
 	}
 	TimeSlice struct {
 		PID   int64
 		Start int64
 		Stop  int64
+		// Queue optionally names the ready queue the slice was dispatched from.
+		Queue string
+		// CPU is the processor the slice ran on in multi-CPU simulations.
+		CPU int
+	}
+	// ProcessResult is the timing of a single process in a finished schedule.
+	ProcessResult struct {
+		Process
+		Wait       int64
+		Turnaround int64
+		Completion int64
+		// Blocked is the time spent waiting for locks held by other processes.
+		Blocked int64
+		// Admission is the time spent queued for memory before being admitted.
+		Admission int64
+		// Killed is set when the process was terminated for exceeding its MaxCPU or MaxTurnaround, in
+		// which case Completion is when it was killed.
+		Killed bool
+	}
+	// Result is the outcome of running a scheduler over a slice of processes.
+	Result struct {
+		Processes []ProcessResult
+		Gantt     []TimeSlice
+		// Columns are scheduler-specific values appended to each row of the schedule table.
+		Columns []Column
+		// ExcludeKilled leaves killed processes out of the averages and throughput.
+		ExcludeKilled bool
+	}
+	// Column is an extra schedule table column holding one value per process.
+	Column struct {
+		Header string
+		Values []string
 	}
 )
 
-//region Schedulers
-
-// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
-// • an output writer
-// • a title for the chart
-// • a slice of processes
-func FCFSSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		totalWait += float64(waitingTime)
-
-		start := waitingTime + processes[i].ArrivalTime
-
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
-
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
-
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
-		serviceTime += processes[i].BurstDuration
+// defaultQuantum is the time quantum used by round-robin scheduling when none is given.
+const defaultQuantum = 2
+
+// Gantt slices with these PIDs mark time the CPU ran no process.
+const (
+	// idlePID marks a CPU with nothing to run.
+	idlePID = -1
+	// heldPID marks a CPU deliberately kept idle while a process was ready.
+	heldPID = -2
+	// overheadPID marks a CPU busy deciding what to run next.
+	overheadPID = -3
+)
 
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
+// newResult builds a Result from each process's completion time, deriving turnaround and wait. Wait is
+// the turnaround less the CPU time, as for a scheduler running each process's bursts straight through;
+// the simulator, which blocks processes for their I/O, takes that out as well.
+func newResult(processes []Process, completion []int64, gantt []TimeSlice) Result {
+	r := Result{
+		Processes: make([]ProcessResult, len(processes)),
+		Gantt:     gantt,
 	}
-
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-}
-
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
-	var (
-		n                  = len(processes)
-		turnAroundTimes    = make([]int64, n)
-		waitingTimes       = make([]int64, n)
-		gantt              = make([]TimeSlice, 0)
-		lastCompletion     int64
-		schedule           = make([][]string, n)
-		insertedProcess    = 0
-		processesMapIdx    = make(map[int64]int)
-		totalBurstDuration int64
-		currentTime        = processes[0].ArrivalTime
-		minHeap            = &ProcessMinHeap{}
-		tempBurstDuration  = make([]int64, n)
-	)
-
-	sort.Slice(processes, func(i, j int) bool {
-		return processes[i].ArrivalTime < processes[j].ArrivalTime
-	})
-
-	heap.Init(minHeap)
-
 	for i, p := range processes {
-		processesMapIdx[p.ProcessID] = i
-		totalBurstDuration += p.BurstDuration
-		tempBurstDuration[i] = p.BurstDuration
+		turnaround := completion[i] - p.ArrivalTime
+		r.Processes[i] = ProcessResult{
+			Process:    p,
+			Wait:       turnaround - p.BurstDuration,
+			Turnaround: turnaround,
+			Completion: completion[i],
+		}
 	}
-
-	for {
-		if insertedProcess < n {
-			for _, p := range processes {
-				if p.ArrivalTime != currentTime {
-					continue
-				}
-				insertedProcess++
-				heap.Push(minHeap, p)
+	if periodicJobs(processes) {
+		jobs := make([]string, len(processes))
+		for i, p := range processes {
+			if p.Job > 0 {
+				jobs[i] = fmt.Sprint(p.Job)
 			}
 		}
-
-		pMinPriority := heap.Pop(minHeap).(Process)
-		pMinPriority.BurstDuration--
-		gantt = appendGantt(gantt, pMinPriority, currentTime)
-		currentTime++
-		gantt = setStop(gantt, currentTime)
-		if pMinPriority.BurstDuration > 0 {
-			heap.Push(minHeap, pMinPriority)
-		} else {
-			idx := processesMapIdx[pMinPriority.ProcessID]
-			turnAroundTimes[idx] = currentTime - pMinPriority.ArrivalTime
-			waitingTimes[idx] = turnAroundTimes[idx] - tempBurstDuration[idx]
-		}
-
-		if minHeap.Len() == 0 && insertedProcess == n {
-			break
-		}
+		r.Columns = append(r.Columns, Column{Header: "Job", Values: jobs})
 	}
+	return r
+}
 
-	for i := range processes {
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTimes[i]
-		if lastCompletion < completion {
-			lastCompletion = completion
-		}
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTimes[i]),
-			fmt.Sprint(turnAroundTimes[i]),
-			fmt.Sprint(completion),
-		}
+// IOTime is the total length of the process's I/O bursts.
+func (p Process) IOTime() int64 {
+	var t int64
+	for i := 1; i < len(p.Bursts); i += 2 {
+		t += p.Bursts[i]
 	}
+	return t
+}
 
-	aveWait := float64(sum(waitingTimes)) / float64(n)
-	aveTurnaround := float64(sum(turnAroundTimes)) / float64(n)
-	aveThroughput := float64(n) / float64(lastCompletion)
+//region Schedulers
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+func FCFSSchedule(w io.Writer, title string, processes []Process) {
+	outputResult(w, title, fcfs(processes))
 }
 
-// Implement min-heap
+// fcfs runs each process to completion in the order they arrived, those arriving together in the order given.
+func fcfs(processes []Process) Result {
+	return simulate(processes, policy{pick: firstReady})
+}
 
-type ProcessMinHeap []Process
+// SJFPrioritySchedule outputs a preemptive priority schedule of processes in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+//
+// The ready process with the highest priority (lowest number) runs, preempting the running one as soon as a
+// process with a strictly higher priority arrives.
+func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
+	outputResult(w, title, eventDriven(processes, func(p Process, _ int64) int64 {
+		return p.Priority
+	}))
+}
 
-func (h ProcessMinHeap) Len() int {
-	return len(h)
+// SJFSchedule outputs a preemptive shortest-job-first (shortest remaining time first) schedule of processes
+// in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+func SJFSchedule(w io.Writer, title string, processes []Process) {
+	outputResult(w, title, eventDriven(processes, func(_ Process, remaining int64) int64 {
+		return remaining
+	}))
 }
 
-func (h ProcessMinHeap) Less(i, j int) bool {
-	return h[i].Priority < h[j].Priority
+func appendGantt(gantt []TimeSlice, process Process, start int64) []TimeSlice {
+	if len(gantt) == 0 || gantt[len(gantt)-1].PID != process.ProcessID {
+		timeSlice := TimeSlice{
+			PID:   process.ProcessID,
+			Start: start,
+		}
+		gantt = append(gantt, timeSlice)
+	}
+	return gantt
 }
 
-func (h ProcessMinHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
+// appendSlice appends s to gantt, extending the last slice instead when s continues it on the same queue.
+func appendSlice(gantt []TimeSlice, s TimeSlice) []TimeSlice {
+	if last := len(gantt) - 1; last >= 0 && gantt[last].PID == s.PID && gantt[last].Queue == s.Queue &&
+		gantt[last].Stop == s.Start {
+		gantt[last].Stop = s.Stop
+		return gantt
+	}
+	return append(gantt, s)
 }
 
-func (h *ProcessMinHeap) Push(x interface{}) {
-	*h = append(*h, x.(Process))
+func setStop(gantt []TimeSlice, stop int64) []TimeSlice {
+	if len(gantt) > 0 {
+		gantt[len(gantt)-1].Stop = stop
+	}
+	return gantt
 }
 
-func (h *ProcessMinHeap) Pop() interface{} {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[0 : n-1]
-	return x
+// RRSchedule outputs a round-robin schedule of processes in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the time quantum each process may run before being preempted
+func RRSchedule(w io.Writer, title string, processes []Process, quantum int64) {
+	outputResult(w, title, roundRobin(processes, quantum))
 }
 
-// end min-heap
+// roundRobin runs processes from a FIFO ready queue for at most quantum ticks at a time.
+// Processes arriving during a slice are queued ahead of the preempted process.
+// A non-positive quantum falls back to defaultQuantum. Processes with I/O bursts are run on the simulator,
+// which blocks them for their I/O.
+func roundRobin(processes []Process, quantum int64) Result {
+	if quantum < 1 {
+		quantum = defaultQuantum
+	}
+	if blocking(processes) {
+		return simulate(processes, policy{pick: firstReady, quantum: quantum})
+	}
+	r, _ := roundRobinBy(processes, func(Process) int64 { return quantum })
+	return r
+}
 
-func SJFSchedule(w io.Writer, title string, processes []Process) {
+// roundRobinBy is roundRobin with the quantum of each dispatch given by quantum, returning every
+// dispatch alongside the schedule.
+func roundRobinBy(processes []Process, quantum func(Process) int64) (Result, []QuantumDecision) {
 	var (
-		n                = len(processes)
-		remainTime       = make([]int64, n)
-		t                int64
-		curIdx           int
-		gantt            = make([]TimeSlice, 0)
-		numsOfCompletion = 0
-		minRemainTime    = int64(math.MaxInt64)
-		isCheck          = false
-		waitingTimes     = make([]int64, n)
-		lastCompletion   int64
-		schedule         = make([][]string, n)
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		gantt      = make([]TimeSlice, 0)
+		queue      = make([]int, 0, n)
+		decisions  []QuantumDecision
+		next       int
+		done       int
+		timer      int64
 	)
 
 	for i := range processes {
-		remainTime[i] = processes[i].BurstDuration
+		remaining[i] = processes[i].BurstDuration
 	}
 
-	for numsOfCompletion < n {
-		for i := range processes {
-			if processes[i].ArrivalTime <= t && remainTime[i] < minRemainTime && remainTime[i] > 0 {
-				minRemainTime = remainTime[i]
-				curIdx = i
-				isCheck = true
-			}
+	admit := func() {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			queue = append(queue, order[next])
+			next++
 		}
+	}
 
-		if !isCheck {
-			t++
+	for done < n {
+		admit()
+		if len(queue) == 0 {
+			// CPU is idle until the next arrival.
+			timer = processes[order[next]].ArrivalTime
 			continue
 		}
 
-		gantt = appendGantt(gantt, processes[curIdx], t)
+		i := queue[0]
+		queue = queue[1:]
 
-		remainTime[curIdx]--
+		q := quantum(processes[i])
+		run := q
+		if remaining[i] < run {
+			run = remaining[i]
+		}
+		if run > 0 {
+			decisions = append(decisions, QuantumDecision{
+				TimeSlice: TimeSlice{PID: processes[i].ProcessID, Start: timer, Stop: timer + run},
+				Quantum:   q,
+			})
+			gantt = appendGantt(gantt, processes[i], timer)
+			timer += run
+			remaining[i] -= run
+			gantt = setStop(gantt, timer)
+		}
 
-		minRemainTime = remainTime[curIdx]
-		if minRemainTime == 0 {
-			minRemainTime = int64(math.MaxInt64)
+		admit()
+		if remaining[i] > 0 {
+			queue = append(queue, i)
+			continue
 		}
+		completion[i] = timer
+		done++
+	}
 
-		if remainTime[curIdx] == 0 {
-			numsOfCompletion++
-			isCheck = false
+	return newResult(processes, completion, gantt), decisions
+}
 
-			stop := t + 1
-			gantt = setStop(gantt, stop)
+// arrivalOrder returns the indices of processes sorted by arrival time, keeping input order for ties.
+func arrivalOrder(processes []Process) []int {
+	order := make([]int, len(processes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return processes[order[i]].ArrivalTime < processes[order[j]].ArrivalTime
+	})
+	return order
+}
 
-			waitingTimes[curIdx] = stop - processes[curIdx].BurstDuration - processes[curIdx].ArrivalTime
-			if waitingTimes[curIdx] < 0 {
-				waitingTimes[curIdx] = 0
-			}
-		}
+//endregion
+
+//region Output helpers
 
-		t++
+// saveChart saves the charts of the schedules run to the file called name, once they have all run.
+func saveChart(name string, chart io.WriterTo) {
+	f, err := os.Create(name)
+	if err != nil {
+		log.Fatalf("%v: error creating chart file", err)
+	}
+	if _, err := chart.WriteTo(f); err != nil {
+		log.Fatalf("%v: error writing chart file", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("%v: error closing chart file", err)
 	}
+}
 
-	turnAroundTimes := findTurnAroundTimes(processes, waitingTimes)
+// outputResult outputs the title, GANTT chart and schedule table of a Result.
+func outputResult(w io.Writer, title string, r Result) {
+	var (
+		n        = len(r.Processes)
+		schedule = make([][]string, n)
+		unit     = unitOf(w)
+	)
+	if n == 0 {
+		return
+	}
+	exportResult(w, title, r)
+	if outputCustom(w, title, r) {
+		return
+	}
 
-	for i := range processes {
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTimes[i]
-		if lastCompletion < completion {
-			lastCompletion = completion
+	for row, i := range tableOrder(w, r.Processes) {
+		p := r.Processes[i]
+		schedule[row] = []string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.Priority),
+			unit.format(p.BurstDuration),
+			unit.format(p.ArrivalTime),
+			unit.format(p.Wait),
+			unit.format(p.Turnaround),
+			unit.format(p.Completion),
 		}
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTimes[i]),
-			fmt.Sprint(turnAroundTimes[i]),
-			fmt.Sprint(completion),
+		for _, c := range r.Columns {
+			schedule[row] = append(schedule[row], c.Values[i])
 		}
 	}
 
-	aveWait := float64(sum(waitingTimes)) / float64(n)
-	aveTurnaround := float64(sum(turnAroundTimes)) / float64(n)
-	aveThroughput := float64(n) / float64(lastCompletion)
+	extra := make([]string, len(r.Columns))
+	for i, c := range r.Columns {
+		extra[i] = c.Header
+	}
 
 	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	outputGantt(w, r.Gantt)
+	outputSchedule(w, schedule, r, extra...)
+	outputStatistics(w, r)
+	outputWaitBars(w, r)
+	outputSparkline(w, r)
+	outputThroughputWindows(w, r)
+	outputDeadlines(w, r)
+	outputTasks(w, r)
 }
 
-func findTurnAroundTimes(processes []Process, waitingTimes []int64) []int64 {
-	turnAroundTimes := make([]int64, len(processes))
-	for i := range processes {
-		turnAroundTimes[i] = processes[i].BurstDuration + waitingTimes[i]
-	}
-	return turnAroundTimes
+func outputTitle(w io.Writer, title string) {
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
 }
 
-func appendGantt(gantt []TimeSlice, process Process, start int64) []TimeSlice {
-	if len(gantt) == 0 || gantt[len(gantt)-1].PID != process.ProcessID {
-		timeSlice := TimeSlice{
-			PID:   process.ProcessID,
-			Start: start,
+func outputGantt(w io.Writer, gantt []TimeSlice) {
+	_, _ = fmt.Fprintln(w, msg(w, "Gantt schedule"))
+	if options(w).vertical {
+		outputVertical(w, gantt)
+		return
+	}
+	if width := options(w).width; width > 0 && len(gantt) > 0 {
+		outputProportional(w, gantt, width)
+		return
+	}
+	if cpus := cpuCount(gantt); cpus > 1 {
+		outputLanes(w, gantt, cpus)
+		return
+	}
+	gantt = idleGaps(gantt)
+	_, _ = fmt.Fprint(w, "|")
+	for i := range gantt {
+		_, _ = fmt.Fprint(w, ganttCell(w, gantt[i]), "|")
+	}
+	_, _ = fmt.Fprintln(w)
+	if queued(gantt) {
+		_, _ = fmt.Fprint(w, "|")
+		for i := range gantt {
+			padding := strings.Repeat(" ", (8-len(gantt[i].Queue))/2)
+			_, _ = fmt.Fprint(w, padding, gantt[i].Queue, padding, "|")
 		}
-		gantt = append(gantt, timeSlice)
+		_, _ = fmt.Fprintln(w)
 	}
-	return gantt
-}
-
-func setStop(gantt []TimeSlice, stop int64) []TimeSlice {
-	if len(gantt) > 0 {
-		gantt[len(gantt)-1].Stop = stop
+	unit := unitOf(w)
+	for i := range gantt {
+		_, _ = fmt.Fprint(w, unit.format(gantt[i].Start), "\t")
+		if len(gantt)-1 == i {
+			_, _ = fmt.Fprint(w, unit.format(gantt[i].Stop))
+		}
 	}
-	return gantt
+	_, _ = fmt.Fprintf(w, "\n\n")
 }
 
-func sum(nums []int64) int64 {
-	var s int64
-	for _, n := range nums {
-		s += n
+// idleGaps returns a single CPU's chart with an idle slice filling each gap between slices, so that the
+// time the CPU sat idle is marked with "-" as it is on each lane of outputLanes.
+func idleGaps(gantt []TimeSlice) []TimeSlice {
+	chart := make([]TimeSlice, 0, len(gantt))
+	for i, s := range gantt {
+		if i > 0 && chart[len(chart)-1].Stop < s.Start {
+			chart = append(chart, TimeSlice{PID: idlePID, Start: chart[len(chart)-1].Stop, Stop: s.Start, CPU: s.CPU})
+		}
+		chart = append(chart, s)
 	}
-	return s
+	return chart
 }
 
-func RRSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		n                 = len(processes)
-		timeQuanta        = 2 // change by requirement
-		timer             int64
-		tempBurstDuration = make([]int64, n)
-		maxProcessIdx     int
-		flag              bool
-		completed         = make([]bool, n)
-		queue             = make([]int, n)
-		turnAroundTimes   = make([]int64, n)
-		waitingTimes      = make([]int64, n)
-		gantt             = make([]TimeSlice, 0)
-		lastCompletion    int64
-		schedule          = make([][]string, n)
-	)
-
-	if n == 0 {
-		return
+// cpuCount is the number of CPUs a chart's slices ran on.
+func cpuCount(gantt []TimeSlice) int {
+	cpus := 1
+	for i := range gantt {
+		if gantt[i].CPU >= cpus {
+			cpus = gantt[i].CPU + 1
+		}
 	}
+	return cpus
+}
 
-	for i := range processes {
-		tempBurstDuration[i] = processes[i].BurstDuration
+// outputLanes outputs one chart per CPU from the same start time, marking the time a CPU was idle with "-".
+// The lanes share a time axis: a slice is as many cells wide as there are times any CPU's slices began or
+// ended within it, so that the same time falls in the same column of every lane.
+func outputLanes(w io.Writer, gantt []TimeSlice, cpus int) {
+	unit := unitOf(w)
+	start := gantt[0].Start
+	for _, s := range gantt {
+		if s.Start < start {
+			start = s.Start
+		}
 	}
-
-	for timer < processes[0].ArrivalTime {
-		timer++
+	var bounds []int64
+	for _, t := range laneBounds(gantt) {
+		if t > start {
+			bounds = append(bounds, t)
+		}
 	}
-	queue[0] = 1
-
-	for {
-		flag = true
-		for _, burst := range tempBurstDuration {
-			if burst != 0 {
-				flag = false
-				break
+	span := func(s TimeSlice) int {
+		n := 0
+		for _, t := range bounds {
+			if t > s.Start && t <= s.Stop {
+				n++
 			}
 		}
-
-		if flag {
-			break
+		if n == 0 {
+			n = 1
 		}
+		return n
+	}
 
-		for i := 0; i < n && queue[i] != 0; i++ {
-			ctr := 0
-			for ctr < timeQuanta && tempBurstDuration[queue[0]-1] > 0 {
-				gantt = appendGantt(gantt, processes[queue[0]-1], timer)
-				tempBurstDuration[queue[0]-1]--
-				timer++
-				ctr++
-				queue, maxProcessIdx = checkNewArrival(timer, processes, maxProcessIdx, queue)
-			}
-			gantt = setStop(gantt, timer)
-
-			if tempBurstDuration[queue[0]-1] == 0 && !completed[queue[0]-1] {
-				turnAroundTimes[queue[0]-1] = timer
-				completed[queue[0]-1] = true
-			}
-
-			ide := true
-			if queue[n-1] == 0 {
-				for _, idx := range queue {
-					if idx != 0 && !completed[idx-1] {
-						ide = false
-					}
-				}
-			} else {
-				ide = false
-			}
-
-			if ide {
-				timer++
-				queue, maxProcessIdx = checkNewArrival(timer, processes, maxProcessIdx, queue)
+	for cpu := 0; cpu < cpus; cpu++ {
+		var (
+			lane []TimeSlice
+			stop = start
+		)
+		for _, s := range gantt {
+			if s.CPU != cpu {
+				continue
 			}
-
-			for j := 0; j < n-1; j++ {
-				if queue[j+1] != 0 {
-					queue[j], queue[j+1] = queue[j+1], queue[j]
-				}
+			if stop < s.Start {
+				lane = append(lane, TimeSlice{PID: idlePID, Start: stop, Stop: s.Start, CPU: cpu})
 			}
+			lane = append(lane, s)
+			stop = s.Stop
 		}
-	}
 
-	for i := 0; i < n; i++ {
-		turnAroundTimes[i] = turnAroundTimes[i] - processes[i].ArrivalTime
-		waitingTimes[i] = turnAroundTimes[i] - processes[i].BurstDuration
-	}
-
-	for i := range processes {
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTimes[i]
-		if lastCompletion < completion {
-			lastCompletion = completion
+		_, _ = fmt.Fprintf(w, "%s %d\n", msg(w, "CPU"), cpu)
+		_, _ = fmt.Fprint(w, "|")
+		for i := range lane {
+			_, _ = fmt.Fprint(w, laneCell(w, lane[i], span(lane[i])), "|")
 		}
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTimes[i]),
-			fmt.Sprint(turnAroundTimes[i]),
-			fmt.Sprint(completion),
+		_, _ = fmt.Fprintln(w)
+		for i := range lane {
+			_, _ = fmt.Fprint(w, unit.format(lane[i].Start), strings.Repeat("\t", span(lane[i])))
+			if len(lane)-1 == i {
+				_, _ = fmt.Fprint(w, unit.format(lane[i].Stop))
+			}
 		}
+		_, _ = fmt.Fprintln(w)
 	}
-
-	aveWait := float64(sum(waitingTimes)) / float64(n)
-	aveTurnaround := float64(sum(turnAroundTimes)) / float64(n)
-	aveThroughput := float64(n) / float64(lastCompletion)
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	_, _ = fmt.Fprintln(w)
 }
 
-func checkNewArrival(timer int64, processes []Process, maxProcessIdx int, queue []int) ([]int, int) {
-	n := len(processes)
-	if timer <= processes[n-1].ArrivalTime {
-		var newArrival bool
-		for i := maxProcessIdx + 1; i < n; i++ {
-			if processes[i].ArrivalTime <= timer && maxProcessIdx < i {
-				maxProcessIdx = i
-				newArrival = true
-			}
-		}
-
-		if newArrival {
-			zeroIdx := -1
-			for i := range queue {
-				if queue[i] == 0 {
-					zeroIdx = i
-					break
-				}
-			}
-			if zeroIdx > -1 {
-				queue[zeroIdx] = maxProcessIdx + 1
+// laneBounds returns the times slices of a GANTT chart began or ended on any CPU, in order.
+func laneBounds(gantt []TimeSlice) []int64 {
+	seen := make(map[int64]bool, 2*len(gantt))
+	var bounds []int64
+	for _, s := range gantt {
+		for _, t := range []int64{s.Start, s.Stop} {
+			if !seen[t] {
+				seen[t] = true
+				bounds = append(bounds, t)
 			}
 		}
 	}
-	return queue, maxProcessIdx
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+	return bounds
 }
 
-//endregion
-
-//region Output helpers
+// ganttCell is the chart cell of a slice: its label centred in the cell, coloured for the PID if w is to
+// colour charts.
+func ganttCell(w io.Writer, s TimeSlice) string {
+	return laneCell(w, s, 1)
+}
 
-func outputTitle(w io.Writer, title string) {
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+// laneCell is the chart cell of a slice spanning n cells of a lane, and the borders between them.
+func laneCell(w io.Writer, s TimeSlice, n int) string {
+	pid := sliceLabel(s)
+	padding := strings.Repeat(" ", (8*n-len(pid))/2)
+	cell := padding + pid + padding
+	if options(w).color {
+		cell = ansiColor(s.PID) + cell + ansiReset
+	}
+	return cell
 }
 
-func outputGantt(w io.Writer, gantt []TimeSlice) {
-	_, _ = fmt.Fprintln(w, "Gantt schedule")
-	_, _ = fmt.Fprint(w, "|")
-	for i := range gantt {
-		pid := fmt.Sprint(gantt[i].PID)
-		padding := strings.Repeat(" ", (8-len(pid))/2)
-		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
+// sliceLabel is the chart cell text of a slice: its PID, "-" when the CPU was idle, "idle"
+// when the CPU was held idle on purpose, or "sched" when it was deciding what to run.
+func sliceLabel(s TimeSlice) string {
+	switch s.PID {
+	case idlePID:
+		return "-"
+	case heldPID:
+		return "idle"
+	case overheadPID:
+		return "sched"
 	}
-	_, _ = fmt.Fprintln(w)
+	return fmt.Sprint(s.PID)
+}
+
+// queued reports whether any slice of the chart is annotated with its queue.
+func queued(gantt []TimeSlice) bool {
 	for i := range gantt {
-		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
-		if len(gantt)-1 == i {
-			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
+		if gantt[i].Queue != "" {
+			return true
 		}
 	}
-	_, _ = fmt.Fprintf(w, "\n\n")
+	return false
 }
 
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
-	_, _ = fmt.Fprintln(w, "Schedule table")
+// outputSchedule outputs the schedule table of r, its footer showing the averages and worst cases, and the
+// CPU utilization; any extra headers name columns appended to the standard ones.
+func outputSchedule(w io.Writer, rows [][]string, r Result, extra ...string) {
+	s := summarize(r)
+	_, _ = fmt.Fprintln(w, msg(w, "Schedule table"))
 	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
+	table.SetHeader(append(msgs(w, "ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"), msgs(w, extra...)...))
 	table.AppendBulk(rows)
-	table.SetFooter([]string{"", "", "", "",
-		fmt.Sprintf("Average\n%.2f", wait),
-		fmt.Sprintf("Average\n%.2f", turnaround),
-		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
+	// Footer cells keep a line per value, which wrapping would reflow into one another.
+	table.SetAutoWrapText(false)
+	unit := unitOf(w)
+	table.SetFooter(append([]string{"", "", "", "",
+		msg(w, "Average") + "\n" + unit.formatAverage(s.wait) + "\n" + msg(w, "Max") + "\n" + worst(unit, s.worstWait, s.worstWait.Wait),
+		msg(w, "Average") + "\n" + unit.formatAverage(s.turnaround) + "\n" + msg(w, "Max") + "\n" + worst(unit, s.worstTurnaround, s.worstTurnaround.Turnaround),
+		msg(w, "Throughput") + "\n" + unit.formatRate(s.throughput) + "\n" + msg(w, "Makespan") + "\n" + unit.formatFooter(s.makespan)},
+		blanks(len(extra))...))
 	table.Render()
+	outputCPUTime(w, r.Gantt)
+}
+
+// worst is a worst-case time t of a schedule table's footer, with the PID of the process p it belongs to.
+func worst(unit TimeUnit, p ProcessResult, t int64) string {
+	return fmt.Sprintf("%s (P%d)", unit.formatFooter(t), p.ProcessID)
+}
+
+// blanks returns n single-space cells, which keep table borders that empty footer cells would drop.
+func blanks(n int) []string {
+	cells := make([]string, n)
+	for i := range cells {
+		cells[i] = " "
+	}
+	return cells
 }
 
 //endregion
 
 //region Loading processes.
 
-var ErrInvalidArgs = errors.New("invalid args")
+var (
+	ErrInvalidArgs       = errors.New("invalid args")
+	ErrInvalidColumn     = errors.New("invalid column")
+	ErrDependencyCycle   = errors.New("dependency cycle")
+	ErrUnknownDependency = errors.New("unknown dependency")
+)
 
+// processFields sets the optional fields that may follow the positional columns as key=value pairs.
+var processFields = map[string]func(p *Process, v string) error{
+	"class": func(p *Process, v string) error {
+		p.Class = v
+		return nil
+	},
+	"nice": func(p *Process, v string) (err error) {
+		if p.Nice, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return err
+		}
+		if p.Nice < minNice || p.Nice > maxNice {
+			return fmt.Errorf("%w: nice must be between %d and %d, got %d", ErrInvalidColumn, minNice, maxNice, p.Nice)
+		}
+		return nil
+	},
+	"deadline": func(p *Process, v string) (err error) {
+		p.Deadline, err = strconv.ParseInt(v, 10, 64)
+		return err
+	},
+	"period": func(p *Process, v string) (err error) {
+		p.Period, err = strconv.ParseInt(v, 10, 64)
+		return err
+	},
+	"repeat": func(p *Process, v string) (err error) {
+		p.Repeat, err = strconv.ParseInt(v, 10, 64)
+		return err
+	},
+	"user": func(p *Process, v string) error {
+		p.User = v
+		return nil
+	},
+	"warp": func(p *Process, v string) (err error) {
+		p.Warp, err = strconv.ParseInt(v, 10, 64)
+		return err
+	},
+	"runtime": func(p *Process, v string) (err error) {
+		p.Runtime, err = strconv.ParseInt(v, 10, 64)
+		return err
+	},
+	"threads": func(p *Process, v string) (err error) {
+		p.Threads, err = strconv.ParseInt(v, 10, 64)
+		return err
+	},
+	"bursts": func(p *Process, v string) error {
+		fields := strings.Split(v, ";")
+		if len(fields)%2 == 0 {
+			return fmt.Errorf("%w: bursts %q must alternate CPU and I/O and end with CPU", ErrInvalidColumn, v)
+		}
+		p.Bursts = make([]int64, len(fields))
+		p.BurstDuration = 0
+		for i, f := range fields {
+			b, err := strconv.ParseInt(strings.TrimSpace(f), 10, 64)
+			if err != nil {
+				return err
+			}
+			if b < 1 {
+				return fmt.Errorf("%w: bursts %q must be positive", ErrInvalidColumn, v)
+			}
+			p.Bursts[i] = b
+			if i%2 == 0 {
+				p.BurstDuration += b
+			}
+		}
+		return nil
+	},
+	"depends": func(p *Process, v string) error {
+		for _, f := range strings.Split(v, ";") {
+			id, err := strconv.ParseInt(strings.TrimSpace(f), 10, 64)
+			if err != nil {
+				return err
+			}
+			p.DependsOn = append(p.DependsOn, id)
+		}
+		return nil
+	},
+	"reprioritize": func(p *Process, v string) error {
+		for _, f := range strings.Split(v, ";") {
+			at, priority, ok := strings.Cut(f, ":")
+			if !ok {
+				return fmt.Errorf("%w: priority change %q is not time:priority", ErrInvalidColumn, f)
+			}
+			var (
+				c   PriorityChange
+				err error
+			)
+			if c.At, err = strconv.ParseInt(strings.TrimSpace(at), 10, 64); err != nil {
+				return err
+			}
+			if c.Priority, err = strconv.ParseInt(strings.TrimSpace(priority), 10, 64); err != nil {
+				return err
+			}
+			if n := len(p.PriorityChanges); n > 0 && c.At < p.PriorityChanges[n-1].At {
+				return fmt.Errorf("%w: priority changes %q are not in time order", ErrInvalidColumn, v)
+			}
+			p.PriorityChanges = append(p.PriorityChanges, c)
+		}
+		return nil
+	},
+	"spawn": func(p *Process, v string) error {
+		for _, f := range strings.Split(v, ";") {
+			fields := strings.Split(f, ":")
+			if len(fields) < 3 || len(fields) > 4 {
+				return fmt.Errorf("%w: spawn %q is not after:pid:burst[:priority]", ErrInvalidColumn, f)
+			}
+			values := make([]int64, 4)
+			for i := range fields {
+				var err error
+				if values[i], err = strconv.ParseInt(strings.TrimSpace(fields[i]), 10, 64); err != nil {
+					return err
+				}
+			}
+			p.Spawns = append(p.Spawns, Spawn{After: values[0], ProcessID: values[1], BurstDuration: values[2], Priority: values[3]})
+		}
+		return nil
+	},
+	"affinity": func(p *Process, v string) error {
+		for _, f := range strings.Split(v, ";") {
+			c, err := strconv.Atoi(strings.TrimSpace(f))
+			if err != nil {
+				return err
+			}
+			if c < 0 {
+				return fmt.Errorf("%w: affinity %q names a negative CPU", ErrInvalidColumn, v)
+			}
+			p.Affinity = append(p.Affinity, c)
+		}
+		return nil
+	},
+	"suspend": func(p *Process, v string) error {
+		for _, f := range strings.Split(v, ";") {
+			from, until, ok := strings.Cut(f, ":")
+			if !ok {
+				return fmt.Errorf("%w: suspension %q is not from:until", ErrInvalidColumn, f)
+			}
+			var (
+				s   Suspension
+				err error
+			)
+			if s.From, err = strconv.ParseInt(strings.TrimSpace(from), 10, 64); err != nil {
+				return err
+			}
+			if s.Until, err = strconv.ParseInt(strings.TrimSpace(until), 10, 64); err != nil {
+				return err
+			}
+			if s.Until <= s.From {
+				return fmt.Errorf("%w: suspension %q must resume after it starts", ErrInvalidColumn, f)
+			}
+			if n := len(p.Suspensions); n > 0 && s.From < p.Suspensions[n-1].Until {
+				return fmt.Errorf("%w: suspensions %q overlap or are not in time order", ErrInvalidColumn, v)
+			}
+			p.Suspensions = append(p.Suspensions, s)
+		}
+		return nil
+	},
+	"maxcpu": func(p *Process, v string) (err error) {
+		if p.MaxCPU, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return err
+		}
+		if p.MaxCPU < 0 {
+			return fmt.Errorf("%w: maxcpu must not be negative, got %d", ErrInvalidColumn, p.MaxCPU)
+		}
+		return nil
+	},
+	"maxturnaround": func(p *Process, v string) (err error) {
+		if p.MaxTurnaround, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return err
+		}
+		if p.MaxTurnaround < 0 {
+			return fmt.Errorf("%w: maxturnaround must not be negative, got %d", ErrInvalidColumn, p.MaxTurnaround)
+		}
+		return nil
+	},
+	"count": func(p *Process, v string) (err error) {
+		if p.Count, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return err
+		}
+		if p.Count < 1 {
+			return fmt.Errorf("%w: count must be positive, got %d", ErrInvalidColumn, p.Count)
+		}
+		return nil
+	},
+	"stagger": func(p *Process, v string) (err error) {
+		if p.Stagger, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return err
+		}
+		if p.Stagger < 0 {
+			return fmt.Errorf("%w: stagger must not be negative, got %d", ErrInvalidColumn, p.Stagger)
+		}
+		return nil
+	},
+	"memory": func(p *Process, v string) (err error) {
+		if p.Memory, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return err
+		}
+		if p.Memory < 0 {
+			return fmt.Errorf("%w: memory must not be negative, got %d", ErrInvalidColumn, p.Memory)
+		}
+		return nil
+	},
+	"locks": func(p *Process, v string) error {
+		for _, f := range strings.Split(v, ";") {
+			fields := strings.Split(f, ":")
+			if len(fields) != 3 || strings.TrimSpace(fields[0]) == "" {
+				return fmt.Errorf("%w: lock %q is not resource:at:hold", ErrInvalidColumn, f)
+			}
+			lk := Lock{Resource: strings.TrimSpace(fields[0])}
+			var err error
+			if lk.At, err = strconv.ParseInt(strings.TrimSpace(fields[1]), 10, 64); err != nil {
+				return err
+			}
+			if lk.Hold, err = strconv.ParseInt(strings.TrimSpace(fields[2]), 10, 64); err != nil {
+				return err
+			}
+			if lk.At < 0 || lk.Hold < 1 {
+				return fmt.Errorf("%w: lock %q must start at or after 0 and hold for a positive time", ErrInvalidColumn, f)
+			}
+			p.Locks = append(p.Locks, lk)
+		}
+		return nil
+	},
+	"tickets": setTickets,
+	"weight":  setTickets,
+}
+
+func setTickets(p *Process, v string) (err error) {
+	if p.Tickets, err = strconv.ParseInt(v, 10, 64); err != nil {
+		return err
+	}
+	if p.Tickets < 1 {
+		return fmt.Errorf("%w: tickets must be positive, got %d", ErrInvalidColumn, p.Tickets)
+	}
+	return nil
+}
+
+// loadProcesses reads processes as CSV rows of <ProcessID>,<Burst Duration>,<Arrival Time>[,<Priority>[,<Tickets>]]
+// optionally followed by key=value columns such as class=batch, or under a header row naming the columns.
+// Lines starting with # are skipped.
 func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("%w: reading CSV", err)
+	return loadDelimited(r, ',')
+}
+
+// loadDelimited reads processes like loadProcesses from rows whose columns are separated by comma. Rows are
+// read and checked one at a time, so only the processes themselves are held in memory.
+func loadDelimited(r io.Reader, comma rune) ([]Process, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	if comma != '#' {
+		// Lines starting with # are comments, such as the one the generate subcommand writes.
+		cr.Comment = '#'
 	}
 
-	processes := make([]Process, len(rows))
-	for i := range rows {
-		processes[i].ProcessID = mustStrToInt(rows[i][0])
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
+	var (
+		processes = make([]Process, 0)
+		header    []string
+		errs      ParseErrors
+	)
+	for first := true; ; first = false {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var pe *csv.ParseError
+		if errors.As(err, &pe) {
+			errs = append(errs, &ParseError{Line: pe.Line, Column: pe.Column, Err: pe.Err})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading CSV", err)
+		}
+		line, _ := cr.FieldPos(0)
+		if first && headed(row) {
+			var he *ParseError
+			if header, he = headerColumns(row); he != nil {
+				he.Line = line
+				return nil, ParseErrors{he}
+			}
+			continue
+		}
+
+		var (
+			p       Process
+			rowErrs ParseErrors
+		)
+		if header != nil {
+			p, rowErrs = headedProcess(header, row, line, len(processes)+1)
+		} else {
+			p, rowErrs = positionalProcess(row, line)
+		}
+		if len(rowErrs) > 0 {
+			errs = append(errs, rowErrs...)
+			continue
 		}
+		processes = append(processes, p.instances()...)
+	}
+	if len(errs) > 0 {
+		return nil, errs
 	}
 
+	if err := checkProcesses(processes); err != nil {
+		return nil, err
+	}
 	return processes, nil
 }
 
-func mustStrToInt(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+// positionalProcess builds the process on CSV line line from a row of positional columns and key=value
+// columns, along with every value in the row that could not be loaded.
+func positionalProcess(row []string, line int) (Process, ParseErrors) {
+	var (
+		p    Process
+		errs ParseErrors
+	)
+	if len(row) < 3 {
+		err := fmt.Errorf("%w: need <ProcessID>,<Burst Duration>,<Arrival Time>, got %d columns", ErrInvalidColumn, len(row))
+		return p, ParseErrors{{Line: line, Err: err}}
+	}
+	fail := func(c int, err error) {
+		e := parseError(row[c], err)
+		e.Line, e.Column = line, c+1
+		errs = append(errs, e)
 	}
 
-	return i
+	for c, v := range []*int64{&p.ProcessID, &p.BurstDuration, &p.ArrivalTime, &p.Priority} {
+		if c >= len(row) {
+			break
+		}
+		var err error
+		if *v, err = strconv.ParseInt(strings.TrimSpace(row[c]), 10, 64); err != nil {
+			fail(c, err)
+		}
+	}
+	c := 4
+	if len(row) > c && !strings.Contains(row[c], "=") {
+		if err := setTickets(&p, strings.TrimSpace(row[c])); err != nil {
+			fail(c, err)
+		}
+		c++
+	}
+	for ; c < len(row); c++ {
+		if err := setProcessField(&p, row[c]); err != nil {
+			fail(c, err)
+		}
+	}
+	return p, errs
+}
+
+// checkProcesses reports problems that span the fields of several processes, or of several fields of one.
+func checkProcesses(processes []Process) error {
+	if err := checkSpawns(processes); err != nil {
+		return err
+	}
+	if err := checkDependencies(processes); err != nil {
+		return err
+	}
+	return checkLocks(processes)
+}
+
+func setProcessField(p *Process, column string) error {
+	key, value, ok := strings.Cut(column, "=")
+	if !ok {
+		return fmt.Errorf("%w: %q is not a key=value pair", ErrInvalidColumn, column)
+	}
+	set, ok := processFields[strings.ToLower(strings.TrimSpace(key))]
+	if !ok {
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidColumn, key)
+	}
+	return set(p, strings.TrimSpace(value))
 }
 
 //endregion
@@ -50,6 +50,33 @@ func TestFCFSSchedule(t *testing.T) {
 			},
 			wantOut: loadFixture(t, "fcfs_test.txt"),
 		},
+		{
+			name: "idle gap",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 2,
+						Priority:      1,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   5,
+						BurstDuration: 3,
+						Priority:      1,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   6,
+						BurstDuration: 1,
+						Priority:      1,
+					},
+				},
+				title: "First-come, First-serve",
+			},
+			wantOut: loadFixture(t, "fcfs_idle_test.txt"),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -82,6 +109,35 @@ func Test_loadProcesses(t *testing.T) {
 			},
 			wantErr: io.ErrUnexpectedEOF,
 		},
+		{
+			name: "template",
+			args: args{
+				r: strings.NewReader("1,4,0,2,count=3,stagger=5,deadline=10\n7,2,1,1,count=1"),
+			},
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 2, Deadline: 10},
+				{ProcessID: 2, ArrivalTime: 5, BurstDuration: 4, Priority: 2, Deadline: 15},
+				{ProcessID: 3, ArrivalTime: 10, BurstDuration: 4, Priority: 2, Deadline: 20},
+				{ProcessID: 7, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+			},
+		},
+		{
+			name: "comments",
+			args: args{
+				r: strings.NewReader("# generate -n 2 -seed 1\n1,5,0,2\n# between\n2,9,3,1\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+			},
+		},
+		{
+			name: "bad value",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,9,3,1,nice=40\n"),
+			},
+			wantErr: ErrInvalidColumn,
+		},
 		{
 			name: "success",
 			args: args{
@@ -110,6 +166,333 @@ func Test_loadProcesses(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "optional columns",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,class=system
+2,9,3
+3,6,3,3, Class = batch,deadline=20`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					Class:         "system",
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+				},
+				{
+					ProcessID:     3,
+					ArrivalTime:   3,
+					BurstDuration: 6,
+					Priority:      3,
+					Class:         "batch",
+					Deadline:      20,
+				},
+			},
+		},
+		{
+			name: "tickets column",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,3
+2,9,3,1,weight=2,user=bob`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					Tickets:       3,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+					Tickets:       2,
+					User:          "bob",
+				},
+			},
+		},
+		{
+			name: "I/O bursts",
+			args: args{
+				r: strings.NewReader(`1,0,0,2,bursts=3;4;2`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					Bursts:        []int64{3, 4, 2},
+				},
+			},
+		},
+		{
+			name: "bursts ending in I/O",
+			args: args{
+				r: strings.NewReader(`1,0,0,2,bursts=3;4`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "dependencies",
+			args: args{
+				r: strings.NewReader(`1,5,0,2
+2,9,3,1,depends=1
+3,6,3,3,depends=1;2`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+					DependsOn:     []int64{1},
+				},
+				{
+					ProcessID:     3,
+					ArrivalTime:   3,
+					BurstDuration: 6,
+					Priority:      3,
+					DependsOn:     []int64{1, 2},
+				},
+			},
+		},
+		{
+			name: "dependency cycle",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,depends=3
+2,9,3,1,depends=1
+3,6,3,3,depends=2`),
+			},
+			wantErr: ErrDependencyCycle,
+		},
+		{
+			name: "unknown dependency",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,depends=7`),
+			},
+			wantErr: ErrUnknownDependency,
+		},
+		{
+			name: "priority changes",
+			args: args{
+				r: strings.NewReader(`3,6,3,3,reprioritize=20:1;30:4`),
+			},
+			want: []Process{
+				{
+					ProcessID:       3,
+					ArrivalTime:     3,
+					BurstDuration:   6,
+					Priority:        3,
+					PriorityChanges: []PriorityChange{{At: 20, Priority: 1}, {At: 30, Priority: 4}},
+				},
+			},
+		},
+		{
+			name: "priority changes out of order",
+			args: args{
+				r: strings.NewReader(`3,6,3,3,reprioritize=30:4;20:1`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "spawns",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,spawn=1:7:3;4:8:2:1`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					Spawns: []Spawn{
+						{After: 1, ProcessID: 7, BurstDuration: 3},
+						{After: 4, ProcessID: 8, BurstDuration: 2, Priority: 1},
+					},
+				},
+			},
+		},
+		{
+			name: "spawn after the parent finishes",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,spawn=6:7:3`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "spawn of an existing process",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,spawn=1:2:3
+2,9,3,1`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "affinity",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,affinity=0;2`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					Affinity:      []int{0, 2},
+				},
+			},
+		},
+		{
+			name: "locks",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,locks=disk:0:2;net:1:3`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					Locks:         []Lock{{Resource: "disk", At: 0, Hold: 2}, {Resource: "net", At: 1, Hold: 3}},
+				},
+			},
+		},
+		{
+			name: "lock held past the burst",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,locks=disk:3:3`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "lock taken while held",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,locks=disk:0:3;disk:2:1`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "negative memory",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,memory=-1`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "suspensions",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,suspend=1:3;6:9`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					Suspensions:   []Suspension{{From: 1, Until: 3}, {From: 6, Until: 9}},
+				},
+			},
+		},
+		{
+			name: "overlapping suspensions",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,suspend=1:4;3:9`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "limits",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,maxcpu=3,maxturnaround=8`),
+			},
+			want: []Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					MaxCPU:        3,
+					MaxTurnaround: 8,
+				},
+			},
+		},
+		{
+			name: "negative limit",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,maxturnaround=-1`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "header row",
+			args: args{
+				r: strings.NewReader(`arrival,pid,burst,deadline,class
+0,1,5,,batch
+3,2,9,12,`),
+			},
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Class: "batch"},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Deadline: 12},
+			},
+		},
+		{
+			name: "unknown header column",
+			args: args{
+				r: strings.NewReader("pid,burst,colour\n1,5,red"),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "header without burst",
+			args: args{
+				r: strings.NewReader("pid,arrival\n1,5"),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "row longer than header",
+			args: args{
+				r: strings.NewReader("pid,burst\n1,5,2"),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "nice out of range",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,nice=20`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "non-positive tickets",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,0`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name: "unknown column",
+			args: args{
+				r: strings.NewReader(`1,5,0,2,colour=red`),
+			},
+			wantErr: ErrInvalidColumn,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -259,6 +642,7 @@ func TestRRSchedule(t *testing.T) {
 	type args struct {
 		processes []Process
 		title     string
+		quantum   int64
 	}
 	tests := []struct {
 		name    string
@@ -294,17 +678,46 @@ func TestRRSchedule(t *testing.T) {
 						Priority:      2,
 					},
 				},
-				title: "Round-robin",
+				title:   "Round-robin",
+				quantum: 2,
 			},
 			wantOut: loadFixture(t, "rrs_test.txt"),
 		},
+		{
+			name: "larger quantum with idle CPU",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 5,
+						Priority:      3,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   1,
+						BurstDuration: 4,
+						Priority:      1,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   12,
+						BurstDuration: 2,
+						Priority:      4,
+					},
+				},
+				title:   "Round-robin",
+				quantum: 3,
+			},
+			wantOut: loadFixture(t, "rrs_q3_test.txt"),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			var w bytes.Buffer
-			RRSchedule(&w, tt.args.title, tt.args.processes)
+			RRSchedule(&w, tt.args.title, tt.args.processes, tt.args.quantum)
 			if got := w.String(); got != tt.wantOut {
 				t.Errorf("RRSchedule() = %v, want %v", got, tt.wantOut)
 			}
@@ -344,10 +757,24 @@ func Test_openProcessingFile1(t *testing.T) {
 			want: tmpFile,
 		},
 		{
-			name: "not enough args",
+			name: "no file reads stdin",
 			args: args{
 				args: []string{"binary_name"},
 			},
+			want: os.Stdin,
+		},
+		{
+			name: "dash reads stdin",
+			args: args{
+				args: []string{"binary_name", "-"},
+			},
+			want: os.Stdin,
+		},
+		{
+			name: "too many args",
+			args: args{
+				args: []string{"binary_name", tmpFile.Name(), tmpFile.Name()},
+			},
 			wantErr: true,
 		},
 		{
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// MemorySchedule outputs a round-robin schedule of processes admitted while they fit in memory in a GANTT
+// chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes, each needing its Memory while it is resident
+// • the memory capacity shared by admitted processes
+// • the time quantum each process may run before being preempted
+//
+// Arriving processes wait in a job queue until enough memory is free, and their admission delay is shown
+// apart from the time they spent waiting for the CPU.
+func MemorySchedule(w io.Writer, title string, processes []Process, capacity, quantum int64) {
+	if capacity < 1 {
+		capacity = 1
+	}
+	r := admitted(processes, capacity, quantum)
+	outputResult(w, title, r)
+	outputAdmission(w, r, capacity)
+}
+
+// admitted runs processes round-robin for quantum ticks at a time, admitting them while they fit in capacity.
+func admitted(processes []Process, capacity, quantum int64) Result {
+	if quantum < 1 {
+		quantum = defaultQuantum
+	}
+	if capacity < 1 {
+		capacity = 1
+	}
+	return simulate(processes, policy{pick: firstReady, quantum: quantum, memory: capacity})
+}
+
+// outputAdmission outputs how long processes were queued for memory.
+func outputAdmission(w io.Writer, r Result, capacity int64) {
+	if len(r.Processes) == 0 {
+		return
+	}
+	var (
+		total   int64
+		delayed int
+	)
+	for _, p := range r.Processes {
+		total += p.Admission
+		if p.Admission > 0 {
+			delayed++
+		}
+	}
+	_, _ = fmt.Fprintf(w, "%s: %d; %s: %d/%d; %s: %s\n", msg(w, "Memory capacity"), capacity, msg(w, "Delayed"), delayed,
+		len(r.Processes), msg(w, "Average admission delay"), unitOf(w).formatAverage(float64(total)/float64(len(r.Processes))))
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMemorySchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		capacity  int64
+		quantum   int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "admission delay",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Memory: 6},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Memory: 5},
+					{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Memory: 4},
+				},
+				title:    "Admission control",
+				capacity: 10,
+				quantum:  2,
+			},
+			wantOut: loadFixture(t, "memory_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			MemorySchedule(&w, tt.args.title, tt.args.processes, tt.args.capacity, tt.args.quantum)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("MemorySchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
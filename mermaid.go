@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WithMermaid returns a writer to w that has schedules written to it also write their GANTT charts to dst
+// as Mermaid gantt diagrams, each fenced as a mermaid code block that Markdown viewers such as GitHub's
+// draw. Each diagram has a section per process, or per CPU for a schedule run on several. Mermaid only
+// knows dates, so times are written as ticks counted in milliseconds, and its axis shows them below 1000.
+func WithMermaid(w io.Writer, dst io.Writer) io.Writer {
+	ow := options(w)
+	ow.mermaid = &mermaidWriter{w: dst}
+	return ow
+}
+
+// mermaidWriter writes the GANTT charts of schedules as Mermaid diagrams.
+type mermaidWriter struct {
+	w io.Writer
+}
+
+// write writes the chart of a schedule titled title.
+func (mw *mermaidWriter) write(title string, gantt []TimeSlice) error {
+	if len(gantt) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	b.WriteString("```mermaid\ngantt\n")
+	fmt.Fprintf(&b, "    title %s\n", strings.ReplaceAll(title, "\n", " "))
+	b.WriteString("    dateFormat x\n    axisFormat %L\n    todayMarker off\n")
+	for _, lane := range (svgChart{gantt: gantt}).lanes() {
+		fmt.Fprintf(&b, "    section %s\n", lane.name)
+		for _, s := range lane.slices {
+			fmt.Fprintf(&b, "    %s : %d, %d\n", sliceLabel(s), s.Start, s.Stop)
+		}
+	}
+	b.WriteString("```\n\n")
+
+	_, err := io.WriteString(mw.w, b.String())
+	return err
+}
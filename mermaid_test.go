@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestWithMermaid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		schedule func(w io.Writer)
+		wantOut  string
+	}{
+		{
+			name: "section per process",
+			schedule: func(w io.Writer) {
+				RRSchedule(w, "Round-robin", []Process{
+					{ProcessID: 1, BurstDuration: 3, Priority: 1},
+					{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+				}, 2)
+			},
+			wantOut: "```mermaid\ngantt\n    title Round-robin\n    dateFormat x\n    axisFormat %L\n    todayMarker off\n" +
+				"    section P1\n    1 : 0, 2\n    1 : 4, 5\n" +
+				"    section P2\n    2 : 2, 4\n" +
+				"```\n\n",
+		},
+		{
+			name: "section per CPU",
+			schedule: func(w io.Writer) {
+				SMPSchedule(w, "SMP", []Process{
+					{ProcessID: 1, BurstDuration: 4},
+					{ProcessID: 2, BurstDuration: 2},
+					{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+				}, SMPParams{CPUs: 2, Quantum: 2})
+			},
+			wantOut: "```mermaid\ngantt\n    title SMP\n    dateFormat x\n    axisFormat %L\n    todayMarker off\n" +
+				"    section CPU 0\n    1 : 0, 2\n    3 : 2, 3\n" +
+				"    section CPU 1\n    2 : 0, 2\n    1 : 2, 4\n" +
+				"```\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var text, got bytes.Buffer
+			tt.schedule(WithMermaid(discardText(&text), &got))
+			if got.String() != tt.wantOut {
+				t.Errorf("Mermaid = %v, want %v", got.String(), tt.wantOut)
+			}
+			if text.Len() != 0 {
+				t.Errorf("discardText() wrote %q", text.String())
+			}
+		})
+	}
+}
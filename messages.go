@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog translates the headers and labels of text output, each English message the key of its
+// translation. Messages it leaves out stay in English.
+type Catalog map[string]string
+
+// ErrUnknownMessage is returned when a catalog translates a message the output does not have.
+var ErrUnknownMessage = errors.New("unknown message")
+
+// messages are the headers and labels of text output that a catalog may translate.
+var messages = []string{
+	"Gantt schedule", "CPU", "Key",
+	"Schedule table", "ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit", "Average", "Throughput",
+	"Statistics", "Mean", "Std dev", "Min", "Median", "Max", "Slowdown", "Mean weighted by burst", "Makespan", "Fairness", "Gini", "CPU utilization",
+	"By priority", "Processes", "Waiting time", "Throughput over time", "Window", "Completed",
+	"Ready queue", "max",
+	"P95", "P99", "Start", "Stop", "Vruntime progression", "Vruntime", "Dispatch trace", "Level", "Quantum",
+	"Deadlines", "Deadline", "Lateness", "Missed", "Class metrics", "Class", "CPU time", "Share",
+	"CPU share", "User", "Contended share", "Starved processes", "Reason",
+	"Schedulability", "Period", "WCET", "Response", "Schedulable", "Periodic tasks", "Jobs", "Average response",
+	"Worst response", "Aperiodic requests", "Batch summary", "File", "Error",
+	"Algorithm", "vs NP optimal", "vs P optimal", "Optimal (non-preemptive)", "Best found (non-preemptive)",
+	"Optimal (preemptive)", "Metric", "Average wait", "Burst-weighted wait", "Average turnaround",
+	"Burst-weighted turnaround", "Average slowdown", "Fairness of waits", "Gini of waits", "Context switches",
+	"Idle time", "Wait vs NP optimal", "Wait vs P optimal", "Wait vs NP best found", "Deadlines met",
+	"Total tardiness", "Max lateness", "Average wait, priority", "Wait by process, against",
+	"CPU utilization (%)", "Old", "New", "Delta",
+	"Effective", "I/O", "Device wait", "Parent", "Blocked", "Memory", "Admission delay", "Suspended", "Depends on",
+	"Released", "Killed", "Depth", "Overruns", "Throttled", "Misses", "Job", "Tickets", "Score", "Lag", "Max lag",
+	"Server", "Warp", "Virtual time", "Energy", "Migrations", "Migration cost", "Affinity", "Static", "Dynamic",
+	"Held idle", "Running", "Idle", "Per process", "Average depth", "Finished in", "queue", "Gang fragmentation",
+	"Aging coefficient", "Jitter", "Seed", "Blocked on locks", "Without priority inheritance", "Memory capacity",
+	"Delayed", "Average admission delay", "Array swaps", "Starvation avoided", "yes", "no", "Longest wait",
+	"Without aging", "Utilization", "Liu & Layland bound", "not schedulable, utilization exceeds 1",
+	"schedulable, within the Liu & Layland bound", "schedulable by response-time analysis",
+	"not schedulable by response-time analysis", "SMT contention", "Work lost", "none", "Threshold", "Scenario",
+	"CPUs",
+}
+
+// LoadCatalog reads a catalog as a YAML mapping of English messages to their translations:
+//
+//	Schedule table: Tabla de planificación
+//	Wait: Espera
+//	Turnaround: Retorno
+func LoadCatalog(r io.Reader) (Catalog, error) {
+	var c Catalog
+	if err := yaml.NewDecoder(r).Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: reading catalog", err)
+	}
+	known := make(map[string]bool, len(messages))
+	for _, m := range messages {
+		known[m] = true
+	}
+	var unknown []string
+	for m := range c {
+		if !known[m] {
+			unknown = append(unknown, m)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%w: %q (known: %q)", ErrUnknownMessage, unknown, messages)
+	}
+	return c, nil
+}
+
+// WithCatalog returns a writer to w that has the headers and labels of schedules written to it translated
+// by c.
+func WithCatalog(w io.Writer, c Catalog) io.Writer {
+	ow := options(w)
+	ow.catalog = c
+	return ow
+}
+
+// msg is message as translated for outputs to w.
+func msg(w io.Writer, message string) string {
+	if t, ok := options(w).catalog[message]; ok {
+		return t
+	}
+	return message
+}
+
+// msgs translates each of messages for outputs to w.
+func msgs(w io.Writer, messages ...string) []string {
+	translated := make([]string, len(messages))
+	for i, m := range messages {
+		translated[i] = msg(w, m)
+	}
+	return translated
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		in      string
+		want    Catalog
+		wantErr error
+	}{
+		{
+			name: "translations",
+			in:   "Wait: Espera\nTurnaround: Retorno\n",
+			want: Catalog{"Wait": "Espera", "Turnaround": "Retorno"},
+		},
+		{name: "empty", in: ""},
+		{name: "unknown message", in: "Wiat: Espera\n", wantErr: ErrUnknownMessage},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := LoadCatalog(strings.NewReader(tt.in))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LoadCatalog() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadCatalog() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithCatalog(t *testing.T) {
+	t.Parallel()
+	c := Catalog{
+		"Gantt schedule": "Diagrama de Gantt",
+		"Schedule table": "Tabla de planificación",
+		"Wait":           "Espera",
+		"Average":        "Media",
+	}
+	var w bytes.Buffer
+	FCFSSchedule(WithCatalog(&w, c), "FCFS", []Process{{ProcessID: 1, BurstDuration: 2}})
+	got := w.String()
+	for _, want := range []string{"Diagrama de Gantt\n", "Tabla de planificación\n", "| ESPERA |", "MEDIA", "TURNAROUND"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FCFSSchedule() = %v, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestMessagesCoverHeaders(t *testing.T) {
+	t.Parallel()
+	c := make(Catalog, len(messages))
+	for _, m := range messages {
+		c[m] = "<" + m + ">"
+	}
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, Priority: 2, Class: "system", Deadline: 6, User: "ann"},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1, Class: "batch", Deadline: 5, User: "bob"},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2, Priority: 3, Class: "interactive", User: "bob"},
+	}
+	periodic := []Process{
+		{ProcessID: 1, BurstDuration: 1, Period: 4},
+		{ProcessID: 2, BurstDuration: 2, Period: 6},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+	}
+	algorithms := []Algorithm{
+		{Name: "<FCFS>", Run: func(p []Process) Result { return simulate(p, policy{pick: firstReady}) }},
+		{Name: "<SJF>", Run: func(p []Process) Result { return simulate(p, policy{pick: shortestRemaining}) }},
+	}
+	var w bytes.Buffer
+	out := WithCatalog(WithThroughputWindows(WithStatistics(&w), 4), c)
+	outputResult(out, "<Schedule>", simulate(processes, policy{pick: firstReady}))
+	CFSSchedule(out, "<CFS>", processes, DefaultCFSParams)
+	EDFSchedule(out, "<EDF>", processes)
+	MultilevelQueueSchedule(out, "<MLQ>", processes, DefaultQueueLevels, StrictPriority)
+	RealTimeSchedule(out, "<Real-time>", processes, RealTimeDeadline, 2)
+	FeedbackQuantaSchedule(out, "<Feedback>", processes, 3, Quanta{})
+	StarvationSchedule(out, "<Starvation>", processes, algorithms[1], 1)
+	FairShareSchedule(out, "<Fair-share>", processes, 2)
+	RMSchedule(out, "<RM>", periodic, 1)
+	AperiodicServerSchedule(out, "<Server>", periodic, AperiodicServer{Kind: PollingServer, Budget: 1, Period: 4}, 1)
+	CompareSchedules(out, "<Compare>", processes, algorithms)
+	CompareSideBySide(out, "<Side by side>", processes, algorithms, true)
+	outputDiff(out, quietMetrics{Schedule: "<Schedule>"}, quietMetrics{Schedule: "<Schedule>"}, 0)
+	BatchSchedule(out, []string{"<file>"}, func(string) (Scenario, error) {
+		return Scenario{Processes: processes}, nil
+	}, func(Scenario) Algorithm { return algorithms[0] })
+
+	// Every table's header, and the labels down the side of the comparison, should have been translated.
+	lines := strings.Split(w.String(), "\n")
+	for i := 1; i+1 < len(lines); i++ {
+		if !strings.HasPrefix(lines[i], "+-") || strings.HasPrefix(lines[i-1], "|") || strings.HasPrefix(lines[i-1], "+") {
+			continue
+		}
+		header := strings.Split(lines[i+1], "|")
+		for _, cell := range header {
+			if cell = strings.TrimSpace(cell); cell != "" && !strings.HasPrefix(cell, "<") {
+				t.Errorf("header %q of table at line %d is missing from the catalog", cell, i+2)
+			}
+		}
+		if len(header) < 2 || strings.TrimSpace(header[1]) != "<METRIC>" {
+			continue
+		}
+		for _, row := range lines[i+3:] {
+			if !strings.HasPrefix(row, "|") {
+				break
+			}
+			if label := strings.TrimSpace(strings.Split(row, "|")[1]); label != "" && !strings.HasPrefix(label, "<") {
+				t.Errorf("row %q of table at line %d is missing from the catalog", label, i+2)
+			}
+		}
+	}
+}
+
+func TestMessagesCoverReports(t *testing.T) {
+	t.Parallel()
+	c := make(Catalog, len(messages))
+	for _, m := range messages {
+		c[m] = "<" + m + ">"
+	}
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 6, Priority: 3, Memory: 8},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1, Memory: 8},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+	}
+	aged := simulate(processes, policy{pick: highestPriority})
+	tests := []struct {
+		name   string
+		report func(w io.Writer)
+	}{
+		{name: "held idle", report: func(w io.Writer) { DelayedSJFSchedule(w, "<Delay>", processes, 2) }},
+		{name: "deadlines met", report: func(w io.Writer) {
+			outputDeadlines(w, simulate([]Process{{ProcessID: 1, BurstDuration: 2, Deadline: 1}}, policy{pick: earliestDeadline}))
+		}},
+		{name: "energy", report: func(w io.Writer) { outputEnergy(w, smpUsage{energy: 4, idleEnergy: 1}, 2) }},
+		{name: "depth", report: func(w io.Writer) { outputDepth(w, []int{0, 2}) }},
+		{name: "gang fragmentation", report: func(w io.Writer) { outputGangUsage(w, gangUsage{busy: 4, fragmentation: 2}) }},
+		{name: "aging coefficient", report: func(w io.Writer) { AgedSJFSchedule(w, "<Aged>", processes, 0.5) }},
+		{name: "jitter", report: func(w io.Writer) { outputJitter(w, Jitter{Arrival: 1, Burst: 2, Seed: 3}) }},
+		{name: "priority inversion", report: func(w io.Writer) { outputInversion(w, aged, aged) }},
+		{name: "lottery seed", report: func(w io.Writer) { LotterySchedule(w, "<Lottery>", processes, 2, 1) }},
+		{name: "admission", report: func(w io.Writer) { outputAdmission(w, admitted(processes, 8, 2), 8) }},
+		{name: "array swaps", report: func(w io.Writer) { O1Schedule(w, "<O(1)>", processes, DefaultO1Params) }},
+		{name: "starvation avoided", report: func(w io.Writer) { outputStarvation(w, aged, aged) }},
+		{name: "random seed", report: func(w io.Writer) { RandomSchedule(w, "<Random>", processes, 2, 1) }},
+		{name: "contention", report: func(w io.Writer) { outputContention(w, smpUsage{busy: 4, contended: 2, lost: 1}) }},
+		{name: "none starved", report: func(w io.Writer) { outputStarved(w, aged, 100) }},
+		{name: "scenario", report: func(w io.Writer) { outputScenario(w, Scenario{Name: "<Named>", Processes: processes}) }},
+	}
+	translated := regexp.MustCompile(`<[^>]*>|\(P\d+\)|uniform`)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			tt.report(WithCatalog(&w, c))
+			lines := strings.Split(strings.TrimRight(w.String(), "\n"), "\n")
+			// The report is the last line, after any schedule it follows.
+			last := lines[len(lines)-1]
+			if rest := translated.ReplaceAllString(last, ""); strings.IndexFunc(rest, unicode.IsLetter) >= 0 {
+				t.Errorf("report %q has English missing from the catalog", last)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics counts the schedules simulated by a long-lived service, by algorithm, and serves them to
+// Prometheus in its text exposition format. The zero Metrics is ready to use, and is safe for concurrent
+// use.
+type Metrics struct {
+	mu         sync.Mutex
+	algorithms map[string]*algorithmMetrics
+}
+
+// algorithmMetrics are the totals of the schedules simulated by one algorithm.
+type algorithmMetrics struct {
+	runs      int64
+	processes int64
+	wait      float64 // the sum of the average wait of each run, in ticks
+	seconds   float64 // the time spent simulating
+}
+
+// metricLabel escapes a label value as the exposition format requires.
+var metricLabel = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Observe counts a schedule r simulated by algorithm in d.
+func (m *Metrics) Observe(algorithm string, r Result, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.algorithms == nil {
+		m.algorithms = make(map[string]*algorithmMetrics)
+	}
+	a, ok := m.algorithms[algorithm]
+	if !ok {
+		a = &algorithmMetrics{}
+		m.algorithms[algorithm] = a
+	}
+	a.runs++
+	a.processes += int64(len(r.Processes))
+	a.wait += summarize(r).wait
+	a.seconds += d.Seconds()
+}
+
+// ServeHTTP serves the metrics, for mounting on /metrics.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = fmt.Fprint(w, m.String())
+}
+
+// String is the metrics in the Prometheus text exposition format.
+func (m *Metrics) String() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.algorithms))
+	for name := range m.algorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	metric := func(name, kind, help string, value func(a *algorithmMetrics) string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+		for _, n := range names {
+			fmt.Fprintf(&b, "%s{algorithm=\"%s\"} %s\n", name, metricLabel.Replace(n), value(m.algorithms[n]))
+		}
+	}
+	metric("scheduler_runs_total", "counter", "Schedules simulated.", func(a *algorithmMetrics) string {
+		return fmt.Sprint(a.runs)
+	})
+	metric("scheduler_processes_total", "counter", "Processes scheduled.", func(a *algorithmMetrics) string {
+		return fmt.Sprint(a.processes)
+	})
+	metric("scheduler_average_wait_ticks", "gauge", "Average wait of the processes of a schedule, averaged over the schedules simulated.", func(a *algorithmMetrics) string {
+		return fmt.Sprint(a.wait / float64(a.runs))
+	})
+	fmt.Fprint(&b, "# HELP scheduler_simulation_seconds Time spent simulating schedules.\n# TYPE scheduler_simulation_seconds summary\n")
+	for _, n := range names {
+		a := m.algorithms[n]
+		fmt.Fprintf(&b, "scheduler_simulation_seconds_sum{algorithm=\"%s\"} %g\n", metricLabel.Replace(n), a.seconds)
+		fmt.Fprintf(&b, "scheduler_simulation_seconds_count{algorithm=\"%s\"} %d\n", metricLabel.Replace(n), a.runs)
+	}
+	return b.String()
+}
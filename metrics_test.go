@@ -0,0 +1,48 @@
+package main
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+	var (
+		m         Metrics
+		processes = []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 2}}
+	)
+	m.Observe("Round-robin", roundRobin(processes, 2), 250*time.Millisecond)
+	m.Observe("Round-robin", roundRobin(processes[:1], 2), 250*time.Millisecond)
+	m.Observe(`Round-robin "q=10"`, roundRobin(processes, 10), time.Second)
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	want := `# HELP scheduler_runs_total Schedules simulated.
+# TYPE scheduler_runs_total counter
+scheduler_runs_total{algorithm="Round-robin"} 2
+scheduler_runs_total{algorithm="Round-robin \"q=10\""} 1
+# HELP scheduler_processes_total Processes scheduled.
+# TYPE scheduler_processes_total counter
+scheduler_processes_total{algorithm="Round-robin"} 3
+scheduler_processes_total{algorithm="Round-robin \"q=10\""} 2
+# HELP scheduler_average_wait_ticks Average wait of the processes of a schedule, averaged over the schedules simulated.
+# TYPE scheduler_average_wait_ticks gauge
+scheduler_average_wait_ticks{algorithm="Round-robin"} 0.75
+scheduler_average_wait_ticks{algorithm="Round-robin \"q=10\""} 1
+# HELP scheduler_simulation_seconds Time spent simulating schedules.
+# TYPE scheduler_simulation_seconds summary
+scheduler_simulation_seconds_sum{algorithm="Round-robin"} 0.5
+scheduler_simulation_seconds_count{algorithm="Round-robin"} 2
+scheduler_simulation_seconds_sum{algorithm="Round-robin \"q=10\""} 1
+scheduler_simulation_seconds_count{algorithm="Round-robin \"q=10\""} 1
+`
+	if got := string(body); got != want {
+		t.Errorf("/metrics = %v, want %v", got, want)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/plain; version=0.0.4; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type (
+	// QueueLevel is one queue of a multilevel queue scheduler.
+	QueueLevel struct {
+		// Class is matched against Process.Class to place processes in this queue.
+		Class string
+		// Quantum is the round-robin quantum within the queue; zero runs the queue first-come, first-serve.
+		Quantum int64
+		// Weight is the number of consecutive ticks the queue is given under WeightedSlicing.
+		Weight int64
+		// Order chooses which process in the queue runs next.
+		Order QueueOrder
+	}
+	// InterQueuePolicy decides which queue of a multilevel queue scheduler gets the CPU.
+	InterQueuePolicy int
+	// QueueOrder decides which process within a queue of a multilevel queue scheduler runs next.
+	QueueOrder int
+)
+
+const (
+	// StrictPriority always serves the first non-empty queue, preempting lower queues on arrival.
+	StrictPriority InterQueuePolicy = iota
+	// WeightedSlicing serves non-empty queues in turn, each for up to its Weight in ticks.
+	WeightedSlicing
+)
+
+const (
+	// ByArrival runs processes in the order they joined the queue.
+	ByArrival QueueOrder = iota
+	// ByShortestRemaining runs the process with the least remaining burst, keeping the earliest on ties.
+	ByShortestRemaining
+	// ByPriority runs the process with the highest priority (lowest number), keeping the earliest on ties.
+	ByPriority
+)
+
+// DefaultQueueLevels are the queues used for the system, interactive and batch classes, highest first.
+var DefaultQueueLevels = []QueueLevel{
+	{Class: "system", Weight: 5},
+	{Class: "interactive", Quantum: defaultQuantum, Weight: 3},
+	{Class: "batch", Weight: 2},
+}
+
+// BatchInteractiveLevels split the CPU three to one between interactive processes, run round-robin, and
+// batch processes, run shortest job first.
+var BatchInteractiveLevels = []QueueLevel{
+	{Class: "interactive", Quantum: defaultQuantum, Weight: 3},
+	{Class: "batch", Weight: 1, Order: ByShortestRemaining},
+}
+
+// MultilevelQueueSchedule outputs a fixed multilevel queue schedule of processes in a GANTT chart
+// and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the queues, highest priority first
+// • the policy choosing between queues
+//
+// Processes whose class matches no queue are placed in the last one. Each queue picks its next process by
+// its Order whenever its current one completes or uses up its quantum. The timing of each class and the
+// share of CPU time it received are shown after the schedule.
+func MultilevelQueueSchedule(w io.Writer, title string, processes []Process, levels []QueueLevel, policy InterQueuePolicy) {
+	if len(levels) == 0 {
+		levels = DefaultQueueLevels
+	}
+	r := multilevelQueue(processes, levels, policy)
+	outputResult(w, title, r)
+	outputQueueShares(w, r, levels)
+}
+
+func multilevelQueue(processes []Process, levels []QueueLevel, policy InterQueuePolicy) Result {
+	if len(levels) == 0 {
+		levels = DefaultQueueLevels
+	}
+
+	var (
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		gantt      = make([]TimeSlice, 0)
+		queues     = make([][]int, len(levels))
+		used       = make([]int64, len(levels)) // ticks the head of each queue has run this quantum
+		current    = -1                         // queue holding the CPU under WeightedSlicing
+		turnLeft   int64
+		next       int
+		done       int
+		timer      int64
+	)
+
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+	}
+
+	admit := func() {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			l := levelOf(levels, processes[order[next]])
+			queues[l] = append(queues[l], order[next])
+			next++
+		}
+	}
+
+	for done < n {
+		admit()
+
+		l := -1
+		switch policy {
+		case WeightedSlicing:
+			if current >= 0 && turnLeft > 0 && len(queues[current]) > 0 {
+				l = current
+				break
+			}
+			for k := 1; k <= len(levels); k++ {
+				if c := (current + k) % len(levels); len(queues[c]) > 0 {
+					l = c
+					break
+				}
+			}
+			if l >= 0 {
+				current, turnLeft = l, levels[l].Weight
+				if turnLeft < 1 {
+					turnLeft = 1
+				}
+			}
+		default:
+			for c := range queues {
+				if len(queues[c]) > 0 {
+					l = c
+					break
+				}
+			}
+		}
+
+		if l < 0 {
+			// CPU is idle until the next arrival.
+			timer = processes[order[next]].ArrivalTime
+			continue
+		}
+
+		if used[l] == 0 {
+			// Move the process the queue's order picks to its head.
+			q, best := queues[l], 0
+			for k := range q {
+				switch levels[l].Order {
+				case ByShortestRemaining:
+					if remaining[q[k]] < remaining[q[best]] {
+						best = k
+					}
+				case ByPriority:
+					if processes[q[k]].Priority < processes[q[best]].Priority {
+						best = k
+					}
+				}
+			}
+			q[0], q[best] = q[best], q[0]
+		}
+
+		i := queues[l][0]
+		if remaining[i] > 0 {
+			gantt = appendGantt(gantt, processes[i], timer)
+			remaining[i]--
+			used[l]++
+			turnLeft--
+			timer++
+			gantt = setStop(gantt, timer)
+		}
+
+		admit()
+		switch {
+		case remaining[i] <= 0:
+			queues[l] = queues[l][1:]
+			used[l] = 0
+			completion[i] = timer
+			done++
+		case levels[l].Quantum > 0 && used[l] >= levels[l].Quantum:
+			queues[l] = append(queues[l][1:], i)
+			used[l] = 0
+		}
+	}
+
+	return newResult(processes, completion, gantt)
+}
+
+// levelOf is the queue a process is placed in: the first whose class matches, or else the last.
+func levelOf(levels []QueueLevel, p Process) int {
+	for l := range levels {
+		if strings.EqualFold(levels[l].Class, p.Class) {
+			return l
+		}
+	}
+	return len(levels) - 1
+}
+
+// outputQueueShares outputs the average wait and turnaround of the processes in each queue, and the share of
+// the CPU time spent running processes that each queue received.
+func outputQueueShares(w io.Writer, r Result, levels []QueueLevel) {
+	if len(r.Processes) == 0 {
+		return
+	}
+	var (
+		members = make([]Result, len(levels))
+		ticks   = make([]int64, len(levels))
+		byPID   = make(map[int64]int, len(r.Processes))
+		busy    int64
+	)
+	for _, p := range r.Processes {
+		l := levelOf(levels, p.Process)
+		members[l].Processes = append(members[l].Processes, p)
+		byPID[p.ProcessID] = l
+	}
+	for _, s := range r.Gantt {
+		if l, ok := byPID[s.PID]; ok {
+			ticks[l] += s.Stop - s.Start
+			busy += s.Stop - s.Start
+		}
+	}
+
+	var rows [][]string
+	for l, m := range members {
+		if len(m.Processes) == 0 {
+			continue
+		}
+		s, share := summarize(m), "-"
+		if busy > 0 {
+			share = fmt.Sprintf("%.1f%%", 100*float64(ticks[l])/float64(busy))
+		}
+		rows = append(rows, []string{
+			levels[l].Class,
+			fmt.Sprint(len(m.Processes)),
+			fmt.Sprintf("%.2f", s.wait),
+			fmt.Sprintf("%.2f", s.turnaround),
+			fmt.Sprint(ticks[l]),
+			share,
+		})
+	}
+
+	_, _ = fmt.Fprintln(w, msg(w, "Class metrics"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "Class", "Processes", "Wait", "Turnaround", "CPU time", "Share"))
+	table.AppendBulk(rows)
+	table.Render()
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMultilevelQueueSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{
+			ProcessID:     1,
+			ArrivalTime:   0,
+			BurstDuration: 6,
+			Class:         "batch",
+		},
+		{
+			ProcessID:     2,
+			ArrivalTime:   1,
+			BurstDuration: 3,
+			Class:         "interactive",
+		},
+		{
+			ProcessID:     3,
+			ArrivalTime:   2,
+			BurstDuration: 2,
+			Class:         "system",
+		},
+		{
+			ProcessID:     4,
+			ArrivalTime:   3,
+			BurstDuration: 2,
+			Class:         "interactive",
+		},
+	}
+	type args struct {
+		processes []Process
+		title     string
+		levels    []QueueLevel
+		policy    InterQueuePolicy
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "strict priority",
+			args: args{
+				processes: processes,
+				title:     "Multilevel queue",
+				levels:    DefaultQueueLevels,
+				policy:    StrictPriority,
+			},
+			wantOut: loadFixture(t, "mlq_strict_test.txt"),
+		},
+		{
+			name: "weighted slicing",
+			args: args{
+				processes: processes,
+				title:     "Multilevel queue",
+				levels:    DefaultQueueLevels,
+				policy:    WeightedSlicing,
+			},
+			wantOut: loadFixture(t, "mlq_weighted_test.txt"),
+		},
+		{
+			name: "policy per class",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Class: "batch"},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Class: "batch"},
+					{ProcessID: 3, ArrivalTime: 1, BurstDuration: 3, Class: "interactive"},
+					{ProcessID: 4, ArrivalTime: 2, BurstDuration: 2, Class: "interactive"},
+				},
+				title:  "Batch and interactive",
+				levels: BatchInteractiveLevels,
+				policy: WeightedSlicing,
+			},
+			wantOut: loadFixture(t, "mlq_classes_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			MultilevelQueueSchedule(&w, tt.args.title, tt.args.processes, tt.args.levels, tt.args.policy)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("MultilevelQueueSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
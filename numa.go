@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// numa reports whether the normalized CPUs are spread over more than one node.
+func (p SMPParams) numa() bool {
+	return p.NodeSize > 0 && p.NodeSize < p.CPUs
+}
+
+// node is the NUMA node CPU c belongs to.
+func (p SMPParams) node(c int) int {
+	return c / p.NodeSize
+}
+
+// outputMigrationCost outputs the time lost to cross-node migrations, and how much each load balancing
+// strategy would lose on the same processes.
+func outputMigrationCost(w io.Writer, processes []Process, params SMPParams, u smpUsage) {
+	unit := unitOf(w)
+	costs := make([]string, len(loadBalanceNames))
+	for b := range loadBalanceNames {
+		params.Balance = LoadBalance(b)
+		_, other := smp(processes, params, true)
+		costs[b] = fmt.Sprintf("%s %s", params.Balance, unit.format(other.overhead))
+	}
+	_, _ = fmt.Fprintf(w, "NUMA migration overhead %s over %d cross-node migrations (%s)\n",
+		unit.format(u.overhead), u.crossNode, strings.Join(costs, ", "))
+}
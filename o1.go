@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type (
+	// O1Params tunes the O(1) scheduler.
+	O1Params struct {
+		// BaseSlice is the timeslice of a nice 0 process; other nice values scale it as the kernel does.
+		BaseSlice int64
+		// MaxSleepAvg is the sleep average that earns the full interactivity bonus.
+		MaxSleepAvg int64
+	}
+	// o1Task is the O(1) scheduler's state for one process.
+	o1Task struct {
+		static   int64 // 100..139, from the nice value
+		prio     int64 // dynamic priority, static adjusted by the interactivity bonus
+		slice    int64 // ticks left of the current timeslice
+		sleepAvg int64
+	}
+	// prioArray holds one FIFO run list per dynamic priority.
+	prioArray [o1MaxPrio - o1MinPrio + 1][]int
+)
+
+const (
+	o1MinPrio   = 100
+	o1MaxPrio   = 139
+	o1Nice0Prio = 120
+	o1MaxBonus  = 10
+)
+
+// DefaultO1Params are the O(1) tunables used when none are given.
+var DefaultO1Params = O1Params{BaseSlice: 4, MaxSleepAvg: 10}
+
+// O1Schedule outputs a simplified Linux O(1) schedule of processes in a GANTT chart annotated with the dynamic
+// priority of each slice, and a table of timing with static and final dynamic priorities, given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the O(1) tunables
+//
+// The highest priority process of the active array runs, preempting lower ones, until its timeslice is used up;
+// it then gets a fresh timeslice and a recalculated dynamic priority and moves to the expired array. When the
+// active array empties the arrays are swapped. Processes here never block, so time spent waiting for the CPU
+// is credited as sleep towards the interactivity bonus instead.
+func O1Schedule(w io.Writer, title string, processes []Process, params O1Params) {
+	r, swaps := o1(processes, params)
+	outputResult(w, title, r)
+	if len(processes) > 0 {
+		_, _ = fmt.Fprintf(w, "%s: %d\n", msg(w, "Array swaps"), swaps)
+	}
+}
+
+// o1 returns the schedule and how many times the active and expired arrays were swapped.
+func o1(processes []Process, params O1Params) (Result, int) {
+	if params.BaseSlice < 1 {
+		params.BaseSlice = DefaultO1Params.BaseSlice
+	}
+	if params.MaxSleepAvg < 1 {
+		params.MaxSleepAvg = DefaultO1Params.MaxSleepAvg
+	}
+
+	var (
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		tasks      = make([]o1Task, n)
+		gantt      = make([]TimeSlice, 0)
+		active     = &prioArray{}
+		expired    = &prioArray{}
+		runnable   = make([]int, 0, n)
+		swaps      int
+		next       int
+		done       int
+		timer      int64
+	)
+
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+	}
+
+	for done < n {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			i := order[next]
+			t := &tasks[i]
+			t.static = o1StaticPrio(processes[i].Nice)
+			t.prio = o1DynamicPrio(*t, params)
+			t.slice = o1Timeslice(t.static, params)
+			active.push(i, t.prio)
+			runnable = append(runnable, i)
+			next++
+		}
+
+		i, ok := active.head()
+		if !ok {
+			if _, ok = expired.head(); !ok {
+				// CPU is idle until the next arrival.
+				timer = processes[order[next]].ArrivalTime
+				continue
+			}
+			active, expired = expired, active
+			swaps++
+			i, _ = active.head()
+		}
+		t := &tasks[i]
+
+		if remaining[i] > 0 {
+			gantt = appendSlice(gantt, TimeSlice{
+				PID:   processes[i].ProcessID,
+				Start: timer,
+				Stop:  timer + 1,
+				Queue: strconv.FormatInt(t.prio, 10),
+			})
+			remaining[i]--
+			t.slice--
+			timer++
+		}
+
+		for _, j := range runnable {
+			if j == i {
+				t.sleepAvg = clamp(t.sleepAvg-1, 0, params.MaxSleepAvg)
+				continue
+			}
+			tasks[j].sleepAvg = clamp(tasks[j].sleepAvg+1, 0, params.MaxSleepAvg)
+		}
+
+		switch {
+		case remaining[i] <= 0:
+			active.pop(t.prio)
+			for k := range runnable {
+				if runnable[k] == i {
+					runnable = append(runnable[:k], runnable[k+1:]...)
+					break
+				}
+			}
+			completion[i] = timer
+			done++
+		case t.slice <= 0:
+			active.pop(t.prio)
+			t.prio = o1DynamicPrio(*t, params)
+			t.slice = o1Timeslice(t.static, params)
+			expired.push(i, t.prio)
+		}
+	}
+
+	r := newResult(processes, completion, gantt)
+	static, dynamic := make([]string, n), make([]string, n)
+	for i := range tasks {
+		static[i] = strconv.FormatInt(tasks[i].static, 10)
+		dynamic[i] = strconv.FormatInt(tasks[i].prio, 10)
+	}
+	r.Columns = append(r.Columns,
+		Column{Header: "Static", Values: static},
+		Column{Header: "Dynamic", Values: dynamic})
+	return r, swaps
+}
+
+// o1StaticPrio maps a nice value onto the kernel's static priority range.
+func o1StaticPrio(nice int64) int64 {
+	return clamp(o1Nice0Prio+nice, o1MinPrio, o1MaxPrio)
+}
+
+// o1DynamicPrio is the static priority raised or lowered by up to 5 according to the task's sleep average.
+func o1DynamicPrio(t o1Task, params O1Params) int64 {
+	bonus := t.sleepAvg*o1MaxBonus/params.MaxSleepAvg - o1MaxBonus/2
+	return clamp(t.static-bonus, o1MinPrio, o1MaxPrio)
+}
+
+// o1Timeslice scales BaseSlice by static priority, giving nice -20 eight times and nice 19 a twentieth of it.
+func o1Timeslice(static int64, params O1Params) int64 {
+	base := params.BaseSlice
+	if static < o1Nice0Prio {
+		base *= 4
+	}
+	slice := base * (o1MaxPrio + 1 - static) / 20
+	if slice < 1 {
+		slice = 1
+	}
+	return slice
+}
+
+func (a *prioArray) push(i int, prio int64) {
+	a[prio-o1MinPrio] = append(a[prio-o1MinPrio], i)
+}
+
+func (a *prioArray) pop(prio int64) {
+	a[prio-o1MinPrio] = a[prio-o1MinPrio][1:]
+}
+
+// head is the first process of the highest priority non-empty run list.
+func (a *prioArray) head() (int, bool) {
+	for _, list := range a {
+		if len(list) > 0 {
+			return list[0], true
+		}
+	}
+	return 0, false
+}
+
+func clamp(v, lo, hi int64) int64 {
+	switch {
+	case v < lo:
+		return lo
+	case v > hi:
+		return hi
+	default:
+		return v
+	}
+}
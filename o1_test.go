@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestO1Schedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		params    O1Params
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			// The same workload as TestCFSSchedule, for comparison.
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 6,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   0,
+						BurstDuration: 6,
+						Nice:          5,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   2,
+						BurstDuration: 3,
+					},
+				},
+				title:  "O(1)",
+				params: DefaultO1Params,
+			},
+			wantOut: loadFixture(t, "o1_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			O1Schedule(&w, tt.args.title, tt.args.processes, tt.args.params)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("O1Schedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_o1Timeslice(t *testing.T) {
+	t.Parallel()
+	params := O1Params{BaseSlice: 20, MaxSleepAvg: 10}
+	for nice, want := range map[int64]int64{-20: 160, 0: 20, 19: 1} {
+		if got := o1Timeslice(o1StaticPrio(nice), params); got != want {
+			t.Errorf("o1Timeslice(nice %d) = %v, want %v", nice, got, want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// optimalNodeLimit bounds the search for an optimal non-preemptive schedule, which is NP-hard in general,
+// so -compare stays quick on large workloads.
+const optimalNodeLimit = 50_000
+
+// optimalPreemptive is the preemptive schedule with the minimum average waiting time. Shortest remaining
+// processing time first is optimal for total completion time, and so for waiting time, with arrivals.
+func optimalPreemptive(processes []Process) Result {
+	return simulate(processes, policy{pick: shortestRemaining, preemptive: true})
+}
+
+// optimalNonPreemptive is the non-preemptive schedule with the minimum average waiting time, found by
+// branch and bound over the order processes run in. It may keep the CPU idle for a short process about to
+// arrive. exact is false when the search gave up after optimalNodeLimit nodes and the best schedule found
+// so far is returned instead.
+func optimalNonPreemptive(processes []Process) (r Result, exact bool) {
+	var (
+		n         = len(processes)
+		best      = make([]int, 0, n)
+		order     = make([]int, 0, n)
+		used      = make([]bool, n)
+		bestC     = int64(-1)
+		nodes     int
+		seen      = map[uint64][][2]int64{} // per set of scheduled processes, the (time, sum) pairs explored
+		byArrival = arrivalOrder(processes)
+		ready     = make(remainingHeap, 0, n)
+	)
+
+	// Seed the search with shortest-job-first so pruning starts from a reasonable bound.
+	greedy := simulate(processes, policy{pick: shortestRemaining})
+	bestC = 0
+	for _, p := range greedy.Processes {
+		bestC += p.Completion
+	}
+	best = completionOrder(greedy)
+
+	var search func(mask uint64, t, sum int64)
+	search = func(mask uint64, t, sum int64) {
+		if nodes++; nodes > optimalNodeLimit {
+			return
+		}
+		if len(order) == n {
+			if sum < bestC {
+				bestC = sum
+				best = append(best[:0], order...)
+			}
+			return
+		}
+		if sum+srptBound(processes, byArrival, used, t, &ready) >= bestC {
+			return
+		}
+		if n <= 64 {
+			for _, s := range seen[mask] {
+				if s[0] <= t && s[1] <= sum {
+					return
+				}
+			}
+			seen[mask] = append(seen[mask], [2]int64{t, sum})
+		}
+
+		for i := range processes {
+			if used[i] {
+				continue
+			}
+			start := t
+			if processes[i].ArrivalTime > start {
+				start = processes[i].ArrivalTime
+			}
+			stop := start + processes[i].BurstDuration
+			used[i] = true
+			order = append(order, i)
+			search(mask|1<<(uint(i)%64), stop, sum+stop)
+			order = order[:len(order)-1]
+			used[i] = false
+		}
+	}
+	search(0, 0, 0)
+
+	return runInOrder(processes, best), nodes <= optimalNodeLimit
+}
+
+// srptBound is a lower bound on the total completion time of the unused processes when starting at t,
+// from the preemptive relaxation that shortest remaining processing time solves exactly. It jumps from
+// one arrival or completion to the next, taking processes in byArrival order and keeping the remaining
+// times of those ready in the heap ready, which it leaves empty.
+func srptBound(processes []Process, byArrival []int, used []bool, t int64, ready *remainingHeap) int64 {
+	var (
+		sum   int64
+		next  int
+		clock = t
+	)
+	arrival := func(i int) int64 {
+		if a := processes[i].ArrivalTime; a > t {
+			return a
+		}
+		return t
+	}
+	skip := func() {
+		for next < len(byArrival) && used[byArrival[next]] {
+			next++
+		}
+	}
+	for skip(); next < len(byArrival) || ready.Len() > 0; skip() {
+		if ready.Len() == 0 && clock < arrival(byArrival[next]) {
+			clock = arrival(byArrival[next])
+		}
+		for ; next < len(byArrival) && arrival(byArrival[next]) <= clock; skip() {
+			burst := processes[byArrival[next]].BurstDuration
+			if burst < 0 {
+				burst = 0
+			}
+			heap.Push(ready, burst)
+			next++
+		}
+		shortest := heap.Pop(ready).(int64)
+		if next < len(byArrival) && clock+shortest > arrival(byArrival[next]) {
+			// Run until the next arrival, which may preempt it.
+			heap.Push(ready, shortest-(arrival(byArrival[next])-clock))
+			clock = arrival(byArrival[next])
+			continue
+		}
+		clock += shortest
+		sum += clock
+	}
+	return sum
+}
+
+// remainingHeap is a min-heap of remaining processing times.
+type remainingHeap []int64
+
+func (h remainingHeap) Len() int            { return len(h) }
+func (h remainingHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h remainingHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *remainingHeap) Push(x interface{}) { *h = append(*h, x.(int64)) }
+func (h *remainingHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// completionOrder is the indices of the processes of a non-preemptive schedule in the order they finished.
+func completionOrder(r Result) []int {
+	order := make([]int, len(r.Processes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return r.Processes[order[i]].Completion < r.Processes[order[j]].Completion
+	})
+	return order
+}
+
+// runInOrder runs processes to completion one after another in the given order, each as soon as it has arrived.
+func runInOrder(processes []Process, order []int) Result {
+	var (
+		completion = make([]int64, len(processes))
+		gantt      = make([]TimeSlice, 0, len(order))
+		t          int64
+	)
+	for _, i := range order {
+		if processes[i].ArrivalTime > t {
+			t = processes[i].ArrivalTime
+		}
+		start := t
+		t += processes[i].BurstDuration
+		completion[i] = t
+		if t > start {
+			gantt = append(gantt, TimeSlice{PID: processes[i].ProcessID, Start: start, Stop: t})
+		}
+	}
+	return newResult(processes, completion, gantt)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func Test_optimalNonPreemptive(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(1))
+	for k := 0; k < 20; k++ {
+		processes := make([]Process, 6)
+		for i := range processes {
+			processes[i] = Process{
+				ProcessID:     int64(i + 1),
+				ArrivalTime:   rng.Int63n(10),
+				BurstDuration: 1 + rng.Int63n(8),
+			}
+		}
+
+		r, exact := optimalNonPreemptive(processes)
+		if !exact {
+			t.Fatalf("optimalNonPreemptive(%v) gave up", processes)
+		}
+		if got, want := totalCompletion(r), bruteForceCompletion(processes); got != want {
+			t.Errorf("optimalNonPreemptive(%v) total completion = %v, want %v", processes, got, want)
+		}
+	}
+}
+
+func Test_srptBound(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(1))
+	for k := 0; k < 50; k++ {
+		var (
+			processes = make([]Process, 8)
+			used      = make([]bool, len(processes))
+			start     = rng.Int63n(6)
+			rest      []Process
+		)
+		for i := range processes {
+			processes[i] = Process{ProcessID: int64(i + 1), ArrivalTime: rng.Int63n(20), BurstDuration: rng.Int63n(8)}
+			if used[i] = rng.Intn(3) == 0; !used[i] {
+				p := processes[i]
+				if p.ArrivalTime < start {
+					p.ArrivalTime = start
+				}
+				rest = append(rest, p)
+			}
+		}
+
+		var ready remainingHeap
+		got := srptBound(processes, arrivalOrder(processes), used, start, &ready)
+		if want := totalCompletion(optimalPreemptive(rest)); got != want {
+			t.Errorf("srptBound(%v, %v, %d) = %d, want %d", processes, used, start, got, want)
+		}
+	}
+}
+
+func totalCompletion(r Result) int64 {
+	var sum int64
+	for _, p := range r.Processes {
+		sum += p.Completion
+	}
+	return sum
+}
+
+// bruteForceCompletion is the least total completion time over every order the processes could run in.
+func bruteForceCompletion(processes []Process) int64 {
+	order := make([]int, len(processes))
+	for i := range order {
+		order[i] = i
+	}
+	best := int64(-1)
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(order) {
+			if sum := totalCompletion(runInOrder(processes, order)); best < 0 || sum < best {
+				best = sum
+			}
+			return
+		}
+		for i := k; i < len(order); i++ {
+			order[k], order[i] = order[i], order[k]
+			permute(k + 1)
+			order[k], order[i] = order[i], order[k]
+		}
+	}
+	permute(0)
+	return best
+}
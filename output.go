@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"text/template"
+)
+
+// optionWriter is an output writer carrying options for the schedules written to it.
+type optionWriter struct {
+	io.Writer
+	unit      TimeUnit
+	color     bool
+	width     int
+	vertical  bool
+	stats     bool
+	waitBars  bool
+	window    int64
+	sparkline bool
+	quiet     int
+	template  *template.Template
+	order     SortOrder
+	catalog   Catalog
+	results   *resultsCSV
+	svg       *GanttSVG
+	png       *ChartPNG
+	tikz      *tikzWriter
+	mermaid   *mermaidWriter
+	trace     *TraceExport
+	events    *eventLog
+	ready     *readyQueueCSV
+	workbook  *ResultsWorkbook
+}
+
+// options returns the options of outputs to w.
+func options(w io.Writer) optionWriter {
+	if ow, ok := w.(optionWriter); ok {
+		return ow
+	}
+	return optionWriter{Writer: w}
+}
+
+// WithTimeUnit returns a writer to w that has schedules written to it show their GANTT charts and schedule
+// tables in unit u instead of ticks.
+func WithTimeUnit(w io.Writer, u TimeUnit) io.Writer {
+	ow := options(w)
+	ow.unit = u
+	return ow
+}
+
+// discardText returns a writer with the options of w that throws away the charts and tables written to it,
+// leaving only its exports.
+func discardText(w io.Writer) io.Writer {
+	ow := options(w)
+	ow.Writer = io.Discard
+	return ow
+}
+
+// unitOf is the time unit outputs to w are shown in.
+func unitOf(w io.Writer) TimeUnit {
+	return options(w).unit
+}
+
+// WithResultsCSV returns a writer to w that has schedules written to it also write the timing of each
+// process to dst as CSV, under a single header row, so the results of several schedules can be loaded
+// into one spreadsheet. Times are bare numbers of the writer's time unit.
+func WithResultsCSV(w io.Writer, dst io.Writer) io.Writer {
+	ow := options(w)
+	ow.results = &resultsCSV{w: csv.NewWriter(dst)}
+	return ow
+}
+
+// WithGanttSVG returns a writer to w that has schedules written to it also add their GANTT charts to g.
+func WithGanttSVG(w io.Writer, g *GanttSVG) io.Writer {
+	ow := options(w)
+	ow.svg = g
+	return ow
+}
+
+// exportResult writes a schedule written to w to the exports it asks for.
+func exportResult(w io.Writer, title string, r Result) {
+	o := options(w)
+	if o.results != nil {
+		_ = o.results.write(title, r, o.unit)
+	}
+	if o.svg != nil {
+		o.svg.add(title, r.Gantt, o.unit)
+	}
+	if o.png != nil {
+		o.png.add(title, r, o.unit)
+	}
+	if o.tikz != nil {
+		_ = o.tikz.write(title, r.Gantt, o.unit)
+	}
+	if o.mermaid != nil {
+		_ = o.mermaid.write(title, r.Gantt)
+	}
+	if o.trace != nil {
+		o.trace.add(title, r, o.unit)
+	}
+	if o.events != nil {
+		_ = o.events.write(title, r, o.unit)
+	}
+	if o.workbook != nil {
+		o.workbook.add(title, r, o.unit)
+	}
+	if o.ready != nil {
+		_ = o.ready.write(title, r, o.unit)
+	}
+}
+
+// resultsCSV writes the timing of each process in a schedule as CSV rows.
+type resultsCSV struct {
+	w      *csv.Writer
+	headed bool
+}
+
+// write writes a row for each process in r, scheduled under title, with the time it first ran after its
+// arrival as its response and its slowdown. Processes that never ran have no response, and those without
+// a burst no slowdown.
+func (rc *resultsCSV) write(title string, r Result, u TimeUnit) error {
+	if !rc.headed {
+		header := []string{"schedule", "id", "priority", "burst", "arrival", "wait", "turnaround", "completion", "response", "slowdown"}
+		if err := rc.w.Write(header); err != nil {
+			return err
+		}
+		rc.headed = true
+	}
+
+	for _, p := range r.Processes {
+		response, slowed := "", ""
+		if t, ok := responseTime(p, r.Gantt); ok {
+			response = u.value(t)
+		}
+		if s, ok := slowdown(p); ok {
+			slowed = strconv.FormatFloat(s, 'g', -1, 64)
+		}
+		row := []string{
+			title,
+			strconv.FormatInt(p.ProcessID, 10),
+			strconv.FormatInt(p.Priority, 10),
+			u.value(p.BurstDuration),
+			u.value(p.ArrivalTime),
+			u.value(p.Wait),
+			u.value(p.Turnaround),
+			u.value(p.Completion),
+			response,
+			slowed,
+		}
+		if err := rc.w.Write(row); err != nil {
+			return err
+		}
+	}
+	rc.w.Flush()
+	return rc.w.Error()
+}
+
+// responseTime is how long after its arrival p first ran in gantt, if it ran.
+func responseTime(p ProcessResult, gantt []TimeSlice) (int64, bool) {
+	first := int64(-1)
+	for _, s := range gantt {
+		if s.PID == p.ProcessID && s.Start >= p.ArrivalTime && (first < 0 || s.Start < first) {
+			first = s.Start
+		}
+	}
+	return first - p.ArrivalTime, first >= 0
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestWithResultsCSV(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+	}
+	tests := []struct {
+		name string
+		unit TimeUnit
+		want string
+	}{
+		{
+			name: "ticks",
+			want: "schedule,id,priority,burst,arrival,wait,turnaround,completion,response,slowdown\n" +
+				"FCFS,1,1,3,0,0,3,3,0,1\n" +
+				"FCFS,2,2,2,1,2,4,5,2,2\n" +
+				"RR,1,1,3,0,2,5,5,0,1.6666666666666667\n" +
+				"RR,2,2,2,1,1,3,4,1,1.5\n",
+		},
+		{
+			name: "milliseconds",
+			unit: TimeUnit{Name: "ms", Scale: 0.5},
+			want: "schedule,id,priority,burst,arrival,wait,turnaround,completion,response,slowdown\n" +
+				"FCFS,1,1,1.5,0,0,1.5,1.5,0,1\n" +
+				"FCFS,2,2,1,0.5,1,2,2.5,1,2\n" +
+				"RR,1,1,1.5,0,1,2.5,2.5,0,1.6666666666666667\n" +
+				"RR,2,2,1,0.5,0.5,1.5,2,0.5,1.5\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got bytes.Buffer
+			w := WithResultsCSV(WithTimeUnit(io.Discard, tt.unit), &got)
+			FCFSSchedule(w, "FCFS", processes)
+			RRSchedule(w, "RR", processes, 2)
+			if got.String() != tt.want {
+				t.Errorf("results CSV = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"io"
+	"log"
+	"path/filepath"
+	"text/template"
+)
+
+// TemplateData is what an output template is executed with for each schedule: its title, its Result, and
+// its metrics. Times, averages and throughput are in ticks, for the template's functions to show in the
+// writer's time unit, named by Unit unless bare ticks.
+type TemplateData struct {
+	Title string
+	Result
+	Wait            float64
+	Turnaround      float64
+	Throughput      float64
+	Makespan        int64
+	ContextSwitches int
+	Unit            string
+}
+
+// templateFuncs are the functions output templates may call to show times as the tables do, in unit u:
+// • time shows a number of ticks
+// • average shows an average of ticks
+// • rate shows a rate per tick
+// • label shows the PID a slice ran, - for an idle CPU or idle for one held idle
+// • response gives how long after arriving a process first ran, or -1 if it never did
+func templateFuncs(u TimeUnit, gantt []TimeSlice) template.FuncMap {
+	return template.FuncMap{
+		"time":    u.format,
+		"average": u.formatAverage,
+		"rate":    u.formatRate,
+		"label":   sliceLabel,
+		"response": func(p ProcessResult) int64 {
+			t, ok := responseTime(p, gantt)
+			if !ok {
+				return -1
+			}
+			return t
+		},
+	}
+}
+
+// loadTemplate parses the output template, a Go text/template, in the file called name.
+func loadTemplate(name string) (*template.Template, error) {
+	return template.New(filepath.Base(name)).Funcs(templateFuncs(TimeUnit{}, nil)).ParseFiles(name)
+}
+
+// WithTemplate returns a writer to w that has schedules written to it output by executing t with their
+// TemplateData, in place of their GANTT charts and tables.
+func WithTemplate(w io.Writer, t *template.Template) io.Writer {
+	ow := options(w)
+	ow.template = t
+	return ow
+}
+
+// custom reports whether w has schedules output by a template or as quiet lines of metrics, which leave out
+// whatever else their schedulers report.
+func custom(w io.Writer) bool {
+	o := options(w)
+	return o.template != nil || o.quiet != quietOff
+}
+
+// outputCustom outputs a schedule titled title by the template or as the quiet line of metrics w asks for,
+// in place of its GANTT chart and tables, and reports whether it did.
+func outputCustom(w io.Writer, title string, r Result) bool {
+	if !custom(w) {
+		return false
+	}
+	if t := options(w).template; t != nil {
+		outputTemplate(w, t, title, r)
+	} else {
+		outputMetrics(w, title, r)
+	}
+	return true
+}
+
+// outputTemplate executes t with the TemplateData of a schedule titled title, logging any error.
+func outputTemplate(w io.Writer, t *template.Template, title string, r Result) {
+	var (
+		unit = unitOf(w)
+		s    = summarize(r)
+	)
+	t, err := t.Clone()
+	if err == nil {
+		err = t.Funcs(templateFuncs(unit, r.Gantt)).Execute(w, TemplateData{
+			Title:           title,
+			Result:          r,
+			Wait:            s.wait,
+			Turnaround:      s.turnaround,
+			Throughput:      s.throughput,
+			Makespan:        s.makespan,
+			ContextSwitches: contextSwitches(r.Gantt),
+			Unit:            unit.Name,
+		})
+	}
+	if err != nil {
+		log.Printf("%v: error executing output template", err)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWithTemplate(t *testing.T) {
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "report.tmpl")
+	text := `{{.Title}}: {{len .Processes}} processes, wait {{average .Wait}}, {{rate .Throughput}}, {{.ContextSwitches}} switches{{if .Unit}} in {{.Unit}}{{end}}
+{{range .Processes}}P{{.ProcessID}} waited {{time .Wait}}, first ran after {{time (response .)}}
+{{end}}{{range .Gantt}}[{{label .}} {{time .Start}}-{{time .Stop}}]{{end}}
+`
+	if err := os.WriteFile(name, []byte(text), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := loadTemplate(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+	}
+
+	tests := []struct {
+		name    string
+		unit    TimeUnit
+		wantOut string
+	}{
+		{
+			name: "ticks",
+			wantOut: "Round-robin: 2 processes, wait 1.50, 0.40/t, 2 switches\n" +
+				"P1 waited 2, first ran after 0\n" +
+				"P2 waited 1, first ran after 1\n" +
+				"[1 0-2][2 2-4][1 4-5]\n",
+		},
+		{
+			name: "unit",
+			unit: TimeUnit{Name: "ms", Scale: 2},
+			wantOut: "Round-robin: 2 processes, wait 3.00ms, 0.20/ms, 2 switches in ms\n" +
+				"P1 waited 4ms, first ran after 0ms\n" +
+				"P2 waited 2ms, first ran after 2ms\n" +
+				"[1 0ms-4ms][2 4ms-8ms][1 8ms-10ms]\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputResult(WithTemplate(WithTimeUnit(&w, tt.unit), tmpl), "Round-robin", roundRobin(processes, 2))
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("outputResult() = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_loadTemplate_error(t *testing.T) {
+	t.Parallel()
+	name := filepath.Join(t.TempDir(), "bad.tmpl")
+	if err := os.WriteFile(name, []byte("{{unknown .}}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadTemplate(name); err == nil {
+		t.Error("loadTemplate() error = nil, want one for an undefined function")
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// DecisionCost is what a scheduling decision costs the CPU, in ticks, so policies that scan the whole ready
+// queue can be weighed against those keeping it in a heap. A decision among n ready processes costs
+// Decision + Scan×n + Heap×⌈log₂(n+1)⌉ ticks. The zero DecisionCost makes decisions free.
+type DecisionCost struct {
+	// Decision is charged for every decision.
+	Decision int64
+	// Scan is charged for each process the decision is made among, as for an O(n) scan of the ready queue.
+	Scan int64
+	// Heap is charged for each level of a binary heap holding the processes, as for an O(log n) pop.
+	Heap int64
+}
+
+// of is the cost of a decision among n ready processes.
+func (c DecisionCost) of(n int) int64 {
+	return c.Decision + c.Scan*int64(n) + c.Heap*int64(bits.Len(uint(n)))
+}
+
+// OverheadSchedule outputs a shortest-job-first schedule of processes in a GANTT chart and a table of timing,
+// charging the CPU for each scheduling decision, given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • what each decision costs
+//
+// The CPU spends the cost of each decision before the process picked runs, marked "sched" in the chart,
+// while the processes still ready go on waiting.
+func OverheadSchedule(w io.Writer, title string, processes []Process, cost DecisionCost) {
+	r := costedSJF(processes, cost)
+	outputResult(w, title, r)
+	if len(processes) > 0 {
+		ticks, decisions := overheadTicks(r.Gantt)
+		_, _ = fmt.Fprintf(w, "Scheduler overhead: %s in %d decisions\n", unitOf(w).format(ticks), decisions)
+	}
+}
+
+// costedSJF runs processes shortest-job-first, the CPU spending the cost of each decision before the process
+// picked runs.
+func costedSJF(processes []Process, cost DecisionCost) Result {
+	return simulate(processes, policy{pick: shortestRemaining, overhead: cost})
+}
+
+// chargeOverhead appends to gantt the CPU spending cost ticks deciding what to run from timer, the processes
+// left in ready waiting meanwhile, and returns the chart and the time the decision was made.
+func chargeOverhead(gantt []TimeSlice, ready []*job, aging Aging, timer, cost int64) ([]TimeSlice, int64) {
+	gantt = append(gantt, TimeSlice{PID: overheadPID, Start: timer, Stop: timer + cost})
+	for _, j := range ready {
+		for t := int64(0); t < cost; t++ {
+			j.waited++
+			aging.age(j)
+		}
+	}
+	return gantt, timer + cost
+}
+
+// overheadTicks is the time the chart's CPUs spent deciding what to run, and how many decisions they made
+// that cost any.
+func overheadTicks(gantt []TimeSlice) (ticks int64, decisions int) {
+	for _, s := range gantt {
+		if s.PID == overheadPID {
+			ticks += s.Stop - s.Start
+			decisions++
+		}
+	}
+	return ticks, decisions
+}
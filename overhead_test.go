@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestOverheadSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{
+			ProcessID:     1,
+			ArrivalTime:   0,
+			BurstDuration: 3,
+		},
+		{
+			ProcessID:     2,
+			ArrivalTime:   1,
+			BurstDuration: 2,
+		},
+		{
+			ProcessID:     3,
+			ArrivalTime:   1,
+			BurstDuration: 1,
+		},
+	}
+	var w bytes.Buffer
+	OverheadSchedule(&w, "SJF with decision overhead", processes, DecisionCost{Decision: 1, Scan: 1})
+	if got, want := w.String(), loadFixture(t, "overhead_test.txt"); got != want {
+		t.Errorf("OverheadSchedule() = %v, want %v", got, want)
+	}
+}
+
+func TestDecisionCost_of(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		cost DecisionCost
+		n    int
+		want int64
+	}{
+		{name: "free", n: 5},
+		{name: "flat", cost: DecisionCost{Decision: 2}, n: 5, want: 2},
+		{name: "scan", cost: DecisionCost{Decision: 1, Scan: 1}, n: 5, want: 6},
+		{name: "heap", cost: DecisionCost{Heap: 1}, n: 7, want: 3},
+		{name: "heap grows a level", cost: DecisionCost{Heap: 1}, n: 8, want: 4},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cost.of(tt.n); got != tt.want {
+				t.Errorf("of(%d) = %d, want %d", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_simulate_overhead(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 1}}
+	got := simulate(processes, schedulerPolicy(remainingTime{}, Dispatch{Preemptive: true, Cost: DecisionCost{Decision: 1}}))
+	wantGantt := []TimeSlice{
+		{PID: overheadPID, Start: 0, Stop: 1},
+		{PID: overheadPID, Start: 1, Stop: 2},
+		{PID: 2, Start: 2, Stop: 3},
+		{PID: overheadPID, Start: 3, Stop: 4},
+		{PID: 1, Start: 4, Stop: 7},
+	}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("simulate() gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+	if ticks, decisions := overheadTicks(got.Gantt); ticks != 3 || decisions != 3 {
+		t.Errorf("overheadTicks() = %d, %d, want 3, 3", ticks, decisions)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// ParseError is a value in a scheduling file that could not be loaded, located by its line and column
+	// in CSV or by its process and field in JSON and YAML.
+	ParseError struct {
+		File    string
+		Line    int
+		Column  int
+		Process int
+		Field   string
+		Value   string
+		Err     error
+	}
+	// ParseErrors are all the values in a scheduling file that could not be loaded, in file order.
+	ParseErrors []*ParseError
+)
+
+func (e *ParseError) Error() string {
+	var at []string
+	if e.File != "" {
+		at = append(at, e.File)
+	}
+	if e.Line > 0 {
+		at = append(at, fmt.Sprintf("line %d", e.Line))
+	}
+	if e.Column > 0 {
+		at = append(at, fmt.Sprintf("column %d", e.Column))
+	}
+	if e.Process > 0 {
+		at = append(at, fmt.Sprintf("process %d", e.Process))
+	}
+	if e.Field != "" {
+		at = append(at, fmt.Sprintf("field %q", e.Field))
+	}
+	msg := e.Err.Error()
+	if e.Value != "" {
+		msg = fmt.Sprintf("%q: %v", e.Value, e.Err)
+	}
+	if len(at) == 0 {
+		return msg
+	}
+	return strings.Join(at, ", ") + ": " + msg
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+func (errs ParseErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is reports whether any of the errors is target.
+func (errs ParseErrors) Is(target error) bool {
+	for _, e := range errs {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseError returns a problem with value, leaving strconv's own copy of the value out of its message.
+func parseError(value string, err error) *ParseError {
+	var ne *strconv.NumError
+	if errors.As(err, &ne) {
+		err = ne.Err
+	}
+	return &ParseError{Value: value, Err: err}
+}
+
+// inFile returns err with any problems it holds located in the named file.
+func inFile(err error, file string) error {
+	var errs ParseErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			e.File = file
+		}
+	}
+	return err
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		load func() error
+		want []string
+	}{
+		{
+			name: "positional",
+			load: func() error {
+				_, err := loadProcesses(strings.NewReader("1,x,0\n2,5,0,2,nice=40,depends=y\n3,4\n4,2,1,2"))
+				return inFile(err, "processes.csv")
+			},
+			want: []string{
+				`processes.csv, line 1, column 2: "x": invalid syntax`,
+				`processes.csv, line 2, column 5: "nice=40": invalid column: nice must be between -20 and 19, got 40`,
+				`processes.csv, line 2, column 6: "depends=y": invalid syntax`,
+				`processes.csv, line 3: invalid column: need <ProcessID>,<Burst Duration>,<Arrival Time>, got 2 columns`,
+			},
+		},
+		{
+			name: "headed",
+			load: func() error {
+				_, err := loadProcesses(strings.NewReader("burst,pid,nice\n5,1,3\nz,2,\n4,3,99,"))
+				return err
+			},
+			want: []string{
+				`line 3, column 1: "z": invalid syntax`,
+				`line 4, column 4: invalid column: more columns than the header`,
+			},
+		},
+		{
+			name: "header",
+			load: func() error {
+				_, err := loadProcesses(strings.NewReader("pid,burst,colour\n1,2,red"))
+				return err
+			},
+			want: []string{`line 1, column 3: "colour": invalid column: unknown column in header`},
+		},
+		{
+			name: "JSON",
+			load: func() error {
+				_, err := loadProcessesJSON(strings.NewReader(`[{"id": 1}, {"id": 2, "burst": "q", "colour": "red"}]`))
+				return err
+			},
+			want: []string{
+				`process 1, field "burst": invalid column: missing`,
+				`process 2, field "burst": "q": invalid syntax`,
+				`process 2, field "colour": invalid column: unknown field`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.load()
+			var errs ParseErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("error = %v, want ParseErrors", err)
+			}
+			got := strings.Split(err.Error(), "\n")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("error =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestParseErrors_Is(t *testing.T) {
+	t.Parallel()
+	errs := ParseErrors{parseError("x", &strconv.NumError{Func: "ParseInt", Num: "x", Err: strconv.ErrSyntax}), {Err: ErrInvalidColumn}}
+	for _, target := range []error{strconv.ErrSyntax, ErrInvalidColumn} {
+		if !errors.Is(errs, target) {
+			t.Errorf("errors.Is(%v, %v) = false, want true", errs, target)
+		}
+	}
+	if errors.Is(errs, ErrDependencyCycle) {
+		t.Errorf("errors.Is(%v, %v) = true, want false", errs, ErrDependencyCycle)
+	}
+}
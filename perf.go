@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var (
+	// perfEvent matches a scheduler event printed by perf script: the task, its PID, the CPU, an optional
+	// column of flags, the time in seconds and the event with its fields.
+	perfEvent = regexp.MustCompile(`^\s*.+?\s+(\d+)\s+\[(\d+)\]\s+(?:\S+\s+)?(\d+\.\d+):\s+(?:sched:)?(sched_\w+):\s*(.*)$`)
+	// perfField matches a key=value field of an event.
+	perfField = regexp.MustCompile(`(\w+)=(\S+)`)
+	// perfLegacyTask matches a task written comm:pid [prio], the way older perf versions print events.
+	perfLegacyTask = regexp.MustCompile(`\S+:(\d+)\s+\[(\d+)\]`)
+)
+
+// perfTask is what a trace shows of one task.
+type perfTask struct {
+	pid      int64
+	arrival  int64 // nanoseconds, when the task was first woken or seen running
+	onCPU    int64 // nanoseconds run in total
+	prio     int64
+	havePrio bool
+}
+
+// perfTrace returns a loader of perf script output of scheduler events, as printed by
+//
+//	perf sched record -- <command>
+//	perf script
+//
+// into one process per task: arriving when it was first woken, or first ran if it was never seen woken,
+// with a burst of its total time on a CPU, both counted in ticks of tick from the first event.
+// A task's kernel priority sets its nice value and ranks its priority from 1. Idle tasks and tasks
+// that never ran are left out, and lines other than sched_wakeup, sched_wakeup_new and sched_switch
+// events are skipped.
+func perfTrace(tick time.Duration) func(r io.Reader) ([]Process, error) {
+	return func(r io.Reader) ([]Process, error) {
+		return loadPerfTrace(r, tick)
+	}
+}
+
+func loadPerfTrace(r io.Reader, tick time.Duration) ([]Process, error) {
+	var (
+		tasks   = make(map[int64]*perfTask)
+		running = make(map[int64]int64) // the PID running on each CPU
+		since   = make(map[int64]int64) // when it started running there
+		start   = int64(-1)
+		last    int64
+		errs    ParseErrors
+	)
+	task := func(pid, t int64) *perfTask {
+		k, ok := tasks[pid]
+		if !ok {
+			k = &perfTask{pid: pid, arrival: t}
+			tasks[pid] = k
+		}
+		return k
+	}
+	prio := func(k *perfTask, s string) {
+		if p, err := strconv.ParseInt(s, 10, 64); err == nil && !k.havePrio {
+			k.prio, k.havePrio = p, true
+		}
+	}
+	stop := func(cpu, t int64) {
+		if pid := running[cpu]; pid != 0 {
+			task(pid, since[cpu]).onCPU += t - since[cpu]
+		}
+	}
+
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		m := perfEvent.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+		cpu, _ := strconv.ParseInt(m[2], 10, 64)
+		secs, err := strconv.ParseFloat(m[3], 64)
+		if err != nil {
+			errs = append(errs, &ParseError{Line: line, Value: m[3], Err: err})
+			continue
+		}
+		t := int64(math.Round(secs * 1e9))
+		if start < 0 {
+			start = t
+		}
+		last = t
+		fields := make(map[string]string)
+		for _, f := range perfField.FindAllStringSubmatch(m[5], -1) {
+			fields[f[1]] = f[2]
+		}
+		legacy := perfLegacyTask.FindAllStringSubmatch(m[5], -1)
+
+		switch m[4] {
+		case "sched_wakeup", "sched_wakeup_new":
+			pidField, prioField := fields["pid"], fields["prio"]
+			if pidField == "" && len(legacy) > 0 {
+				pidField, prioField = legacy[0][1], legacy[0][2]
+			}
+			pid, err := strconv.ParseInt(pidField, 10, 64)
+			if err != nil {
+				errs = append(errs, &ParseError{Line: line, Value: sc.Text(), Err: fmt.Errorf("%w: wakeup without a pid", ErrInvalidColumn)})
+				continue
+			}
+			if pid != 0 {
+				prio(task(pid, t), prioField)
+			}
+		case "sched_switch":
+			prev, prevPrio, next, nextPrio := fields["prev_pid"], fields["prev_prio"], fields["next_pid"], fields["next_prio"]
+			if next == "" && len(legacy) == 2 {
+				prev, prevPrio, next, nextPrio = legacy[0][1], legacy[0][2], legacy[1][1], legacy[1][2]
+			}
+			prevPID, prevErr := strconv.ParseInt(prev, 10, 64)
+			nextPID, err := strconv.ParseInt(next, 10, 64)
+			if prevErr != nil || err != nil {
+				errs = append(errs, &ParseError{Line: line, Value: sc.Text(), Err: fmt.Errorf("%w: switch without both pids", ErrInvalidColumn)})
+				continue
+			}
+			if _, ok := running[cpu]; !ok {
+				// The task switched out has been running since before the trace began.
+				running[cpu], since[cpu] = prevPID, start
+			}
+			stop(cpu, t)
+			if k, ok := tasks[prevPID]; ok {
+				prio(k, prevPrio)
+			}
+			running[cpu], since[cpu] = nextPID, t
+			if nextPID != 0 {
+				prio(task(nextPID, t), nextPrio)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading perf trace", err)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("%w: no scheduler events in perf trace", ErrInvalidArgs)
+	}
+	for cpu := range running {
+		stop(cpu, last)
+	}
+
+	ticks := func(ns int64) int64 {
+		return int64(math.Round(float64(ns) / float64(tick)))
+	}
+	processes := make([]Process, 0, len(tasks))
+	for _, k := range tasks {
+		if k.onCPU <= 0 {
+			continue
+		}
+		p := Process{ProcessID: k.pid, ArrivalTime: ticks(k.arrival - start), BurstDuration: ticks(k.onCPU)}
+		if p.BurstDuration < 1 {
+			p.BurstDuration = 1
+		}
+		if k.havePrio {
+			// Kernel priorities 100 to 139 are nice -20 to 19; real-time priorities below them rank higher.
+			if p.Nice = k.prio - 120; p.Nice < minNice {
+				p.Nice = minNice
+			} else if p.Nice > maxNice {
+				p.Nice = maxNice
+			}
+			p.Priority = k.prio + 1
+		}
+		processes = append(processes, p)
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		if processes[i].ArrivalTime != processes[j].ArrivalTime {
+			return processes[i].ArrivalTime < processes[j].ArrivalTime
+		}
+		return processes[i].ProcessID < processes[j].ProcessID
+	})
+	return processes, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_loadPerfTrace(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		r       io.Reader
+		want    []Process
+		wantErr error
+	}{
+		{
+			name: "perf script",
+			r: strings.NewReader(`# ========
+# captured on    : Mon Oct 12 09:30:00 2026
+            bash  2000 [000]  100.000000: sched:sched_wakeup: comm=make pid=2001 prio=120 target_cpu=000
+            bash  2000 [000]  100.002000: sched:sched_switch: prev_comm=bash prev_pid=2000 prev_prio=120 prev_state=S ==> next_comm=make next_pid=2001 next_prio=120
+         swapper     0 [001]  100.003000: sched:sched_wakeup_new: comm=cc1 pid=2002 prio=110 target_cpu=001
+         swapper     0 [001]  100.003000: sched:sched_switch: prev_comm=swapper/1 prev_pid=0 prev_prio=120 prev_state=R ==> next_comm=cc1 next_pid=2002 next_prio=110
+            make  2001 [000]  100.006400: sched:sched_switch: prev_comm=make prev_pid=2001 prev_prio=120 prev_state=S ==> next_comm=swapper/0 next_pid=0 next_prio=120
+             cc1  2002 [001]  100.010000: sched:sched_switch: prev_comm=cc1 prev_pid=2002 prev_prio=110 prev_state=R ==> next_comm=make next_pid=2001 next_prio=120
+            make  2001 [001]  100.012000: sched:sched_stat_runtime: comm=make pid=2001 runtime=2000000 [ns] vruntime=1
+`),
+			want: []Process{
+				{ProcessID: 2000, ArrivalTime: 0, BurstDuration: 2, Priority: 121},
+				{ProcessID: 2001, ArrivalTime: 0, BurstDuration: 6, Priority: 121},
+				{ProcessID: 2002, ArrivalTime: 3, BurstDuration: 7, Priority: 111, Nice: -10},
+			},
+		},
+		{
+			name: "older perf",
+			r: strings.NewReader(`            make  2001 [000]     5.000000: sched_switch: make:2001 [120] R ==> cc1:2002 [110]
+             cc1  2002 [000]     5.004000: sched_switch: cc1:2002 [110] S ==> swapper/0:0 [120]
+`),
+			want: []Process{{ProcessID: 2002, ArrivalTime: 0, BurstDuration: 4, Priority: 111, Nice: -10}},
+		},
+		{
+			name:    "bad pid",
+			r:       strings.NewReader("bash 2000 [000] 1.000000: sched:sched_wakeup: comm=make pid=abc prio=120\n"),
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name:    "no events",
+			r:       strings.NewReader("# nothing recorded\n"),
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadPerfTrace(tt.r, time.Millisecond)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadPerfTrace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadPerfTrace() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
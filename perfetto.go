@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Fields and values of Perfetto's trace protos, from protos/perfetto/trace in the Perfetto tree.
+const (
+	pfTracePacket = 1 // Trace.packet
+
+	pfTimestamp       = 8  // TracePacket.timestamp
+	pfSequenceID      = 10 // TracePacket.trusted_packet_sequence_id
+	pfTrackEvent      = 11 // TracePacket.track_event
+	pfSequenceFlags   = 13 // TracePacket.sequence_flags
+	pfTrackDescriptor = 60 // TracePacket.track_descriptor
+
+	pfTrackUUID    = 1 // TrackDescriptor.uuid
+	pfTrackName    = 2 // TrackDescriptor.name
+	pfTrackProcess = 3 // TrackDescriptor.process
+	pfTrackParent  = 5 // TrackDescriptor.parent_uuid
+	pfTrackCounter = 8 // TrackDescriptor.counter
+
+	pfProcessPID  = 1 // ProcessDescriptor.pid
+	pfProcessName = 6 // ProcessDescriptor.process_name
+
+	pfEventType    = 9  // TrackEvent.type
+	pfEventTrack   = 11 // TrackEvent.track_uuid
+	pfEventName    = 23 // TrackEvent.name
+	pfEventCounter = 30 // TrackEvent.counter_value
+
+	pfSliceBegin   = 1 // TrackEvent.TYPE_SLICE_BEGIN
+	pfSliceEnd     = 2 // TrackEvent.TYPE_SLICE_END
+	pfCounter      = 4 // TrackEvent.TYPE_COUNTER
+	pfStateCleared = 1 // TracePacket.SEQ_INCREMENTAL_STATE_CLEARED
+
+	// pfSequence numbers the one sequence of packets written.
+	pfSequence = 1
+)
+
+// PerfettoTrace writes the schedules of a trace as a Perfetto protobuf trace, for the Perfetto UI or
+// trace processor: a process per schedule, named by its title, holding a track per CPU with a slice for
+// each time a process ran on it, a track per process with a slice for each time it ran, named by the CPU,
+// and a counter of the processes ready to run but waiting.
+type PerfettoTrace struct {
+	*TraceExport
+}
+
+// WriteTo writes the trace as protobuf.
+func (t PerfettoTrace) WriteTo(w io.Writer) (int64, error) {
+	var (
+		trace protoMessage
+		uuid  uint64
+	)
+	packet := func(p protoMessage) {
+		p.varint(pfSequenceID, pfSequence)
+		trace.message(pfTracePacket, p)
+	}
+	track := func(name string, parent uint64, counter bool) uint64 {
+		uuid++
+		var d, p protoMessage
+		d.varint(pfTrackUUID, uuid)
+		d.varint(pfTrackParent, parent)
+		d.string(pfTrackName, name)
+		if counter {
+			d.message(pfTrackCounter, nil)
+		}
+		p.message(pfTrackDescriptor, d)
+		packet(p)
+		return uuid
+	}
+	event := func(ns uint64, track uint64, typ uint64, name string, value int64) {
+		var e, p protoMessage
+		e.varint(pfEventType, typ)
+		e.varint(pfEventTrack, track)
+		if name != "" {
+			e.string(pfEventName, name)
+		}
+		if typ == pfCounter {
+			e.varint(pfEventCounter, uint64(value))
+		}
+		p.varint(pfTimestamp, ns)
+		p.message(pfTrackEvent, e)
+		packet(p)
+	}
+
+	var first protoMessage
+	first.varint(pfSequenceFlags, pfStateCleared)
+	packet(first)
+	for i, sc := range t.schedules {
+		ns := func(tick int64) uint64 { return uint64(math.Round(float64(tick) * sc.us * 1000)) }
+
+		uuid++
+		schedule := uuid
+		var proc, d, p protoMessage
+		proc.varint(pfProcessPID, uint64(i+1))
+		proc.string(pfProcessName, sc.title)
+		d.varint(pfTrackUUID, schedule)
+		d.message(pfTrackProcess, proc)
+		p.message(pfTrackDescriptor, d)
+		packet(p)
+
+		cpus := make([]uint64, cpuCount(sc.r.Gantt))
+		for cpu := range cpus {
+			cpus[cpu] = track(fmt.Sprintf("CPU %d", cpu), schedule, false)
+		}
+		processes := make(map[int64]uint64)
+		for _, r := range sc.r.Processes {
+			if _, ok := processes[r.ProcessID]; !ok {
+				processes[r.ProcessID] = track("P"+strconv.FormatInt(r.ProcessID, 10), schedule, false)
+			}
+		}
+		ready := track("Ready queue", schedule, true)
+
+		slices := make([]TimeSlice, 0, len(sc.r.Gantt))
+		for _, s := range sc.r.Gantt {
+			if s.PID != idlePID {
+				slices = append(slices, s)
+			}
+		}
+		sort.SliceStable(slices, func(i, j int) bool { return slices[i].Start < slices[j].Start })
+		for _, s := range slices {
+			name := "P" + sliceLabel(s)
+			if s.PID < 0 {
+				name = sliceLabel(s)
+			}
+			event(ns(s.Start), cpus[s.CPU], pfSliceBegin, name, 0)
+			event(ns(s.Stop), cpus[s.CPU], pfSliceEnd, "", 0)
+			if pt, ok := processes[s.PID]; ok {
+				event(ns(s.Start), pt, pfSliceBegin, fmt.Sprintf("CPU %d", s.CPU), 0)
+				event(ns(s.Stop), pt, pfSliceEnd, "", 0)
+			}
+		}
+		for _, c := range readyQueue(sc.r) {
+			event(ns(c.at), ready, pfCounter, "", c.ready)
+		}
+	}
+	n, err := w.Write(trace)
+	return int64(n), err
+}
+
+// readyCount is how many processes were ready to run but waiting from a time on.
+type readyCount struct {
+	at, ready int64
+}
+
+// readyQueue returns the length of the ready queue of a schedule each time it changed: the processes that
+// had arrived and not completed, less those running.
+func readyQueue(r Result) []readyCount {
+	change := make(map[int64]int64)
+	for _, p := range r.Processes {
+		change[p.ArrivalTime]++
+		change[p.Completion]--
+	}
+	for _, s := range r.Gantt {
+		if s.PID >= 0 {
+			change[s.Start]--
+			change[s.Stop]++
+		}
+	}
+	times := make([]int64, 0, len(change))
+	for t := range change {
+		times = append(times, t)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+
+	var (
+		counts []readyCount
+		ready  int64
+	)
+	for _, t := range times {
+		ready += change[t]
+		if len(counts) == 0 || counts[len(counts)-1].ready != ready {
+			counts = append(counts, readyCount{at: t, ready: ready})
+		}
+	}
+	return counts
+}
+
+// protoMessage is a protobuf message encoded field by field.
+type protoMessage []byte
+
+func (m *protoMessage) uvarint(v uint64) {
+	for v >= 0x80 {
+		*m = append(*m, byte(v)|0x80)
+		v >>= 7
+	}
+	*m = append(*m, byte(v))
+}
+
+// varint adds a varint field.
+func (m *protoMessage) varint(field int, v uint64) {
+	m.uvarint(uint64(field) << 3)
+	m.uvarint(v)
+}
+
+// string adds a length-delimited string field.
+func (m *protoMessage) string(field int, s string) {
+	m.uvarint(uint64(field)<<3 | 2)
+	m.uvarint(uint64(len(s)))
+	*m = append(*m, s...)
+}
+
+// message adds an embedded message field.
+func (m *protoMessage) message(field int, v protoMessage) {
+	m.string(field, string(v))
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// protoFields decodes the varint and length-delimited fields of a protobuf message, by field number.
+func protoFields(t *testing.T, m []byte) map[int][]interface{} {
+	t.Helper()
+	fields := make(map[int][]interface{})
+	for len(m) > 0 {
+		key, n := binary.Uvarint(m)
+		if n <= 0 {
+			t.Fatalf("bad field key in %x", m)
+		}
+		m = m[n:]
+		field := int(key >> 3)
+		switch key & 7 {
+		case 0:
+			v, n := binary.Uvarint(m)
+			if n <= 0 {
+				t.Fatalf("bad varint in %x", m)
+			}
+			fields[field], m = append(fields[field], v), m[n:]
+		case 2:
+			l, n := binary.Uvarint(m)
+			if n <= 0 || uint64(len(m)-n) < l {
+				t.Fatalf("bad length in %x", m)
+			}
+			fields[field], m = append(fields[field], m[n:n+int(l)]), m[n+int(l):]
+		default:
+			t.Fatalf("unexpected wire type %d", key&7)
+		}
+	}
+	return fields
+}
+
+func TestPerfettoTrace(t *testing.T) {
+	t.Parallel()
+	tr := &TraceExport{}
+	RRSchedule(WithTraceExport(io.Discard, tr), "Round-robin", []Process{
+		{ProcessID: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+	}, 2)
+	var w bytes.Buffer
+	if _, err := (PerfettoTrace{tr}).WriteTo(&w); err != nil {
+		t.Fatal(err)
+	}
+
+	type event struct {
+		ns    uint64
+		track string
+		typ   uint64
+		name  string
+	}
+	var (
+		processes []string
+		tracks    = make(map[uint64]string)
+		events    []event
+		counters  []uint64
+	)
+	for _, p := range protoFields(t, w.Bytes())[pfTracePacket] {
+		packet := protoFields(t, p.([]byte))
+		if got := packet[pfSequenceID]; !reflect.DeepEqual(got, []interface{}{uint64(pfSequence)}) {
+			t.Errorf("packet sequence = %v, want %d", got, pfSequence)
+		}
+		for _, d := range packet[pfTrackDescriptor] {
+			desc := protoFields(t, d.([]byte))
+			uuid := desc[pfTrackUUID][0].(uint64)
+			if proc, ok := desc[pfTrackProcess]; ok {
+				processes = append(processes, string(protoFields(t, proc[0].([]byte))[pfProcessName][0].([]byte)))
+				continue
+			}
+			tracks[uuid] = string(desc[pfTrackName][0].([]byte))
+		}
+		for _, e := range packet[pfTrackEvent] {
+			ev := protoFields(t, e.([]byte))
+			typ := ev[pfEventType][0].(uint64)
+			if typ == pfCounter {
+				counters = append(counters, packet[pfTimestamp][0].(uint64), ev[pfEventCounter][0].(uint64))
+				continue
+			}
+			got := event{ns: packet[pfTimestamp][0].(uint64), track: tracks[ev[pfEventTrack][0].(uint64)], typ: typ}
+			if name, ok := ev[pfEventName]; ok {
+				got.name = string(name[0].([]byte))
+			}
+			events = append(events, got)
+		}
+	}
+
+	if want := []string{"Round-robin"}; !reflect.DeepEqual(processes, want) {
+		t.Errorf("processes = %v, want %v", processes, want)
+	}
+	wantEvents := []event{
+		{0, "CPU 0", pfSliceBegin, "P1"}, {2e6, "CPU 0", pfSliceEnd, ""},
+		{0, "P1", pfSliceBegin, "CPU 0"}, {2e6, "P1", pfSliceEnd, ""},
+		{2e6, "CPU 0", pfSliceBegin, "P2"}, {4e6, "CPU 0", pfSliceEnd, ""},
+		{2e6, "P2", pfSliceBegin, "CPU 0"}, {4e6, "P2", pfSliceEnd, ""},
+		{4e6, "CPU 0", pfSliceBegin, "P1"}, {5e6, "CPU 0", pfSliceEnd, ""},
+		{4e6, "P1", pfSliceBegin, "CPU 0"}, {5e6, "P1", pfSliceEnd, ""},
+	}
+	if !reflect.DeepEqual(events, wantEvents) {
+		t.Errorf("events = %v, want %v", events, wantEvents)
+	}
+	// P2 waits from its arrival at 1 until P1's quantum ends at 2, and then P1 waits for it until 4.
+	if want := []uint64{0, 0, 1e6, 1, 4e6, 0}; !reflect.DeepEqual(counters, want) {
+		t.Errorf("ready queue = %v, want %v", counters, want)
+	}
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Layout of a PNG chart, in pixels, besides those it shares with SVG charts.
+const (
+	pngWaitHeading = 24
+	pngWaitBar     = 14
+	pngWaitGap     = 4
+	pngGlyphScale  = 2 // pixels per dot of the font
+)
+
+var (
+	pngWhite = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	pngBlack = color.RGBA{A: 0xff}
+	pngGrey  = color.RGBA{R: 0xbb, G: 0xbb, B: 0xbb, A: 0xff}
+)
+
+// pngFont is a font of 3 by 5 dots, each glyph's rows read left to right and top to bottom. Letters are
+// upper case only; runes it lacks are drawn as ?.
+var pngFont = map[rune]string{
+	'0': "111101101101111", '1': "010110010010111", '2': "111001111100111", '3': "111001111001111",
+	'4': "101101111001001", '5': "111100111001111", '6': "111100111101111", '7': "111001001001001",
+	'8': "111101111101111", '9': "111101111001111",
+	'A': "010101111101101", 'B': "110101110101110", 'C': "011100100100011", 'D': "110101101101110",
+	'E': "111100110100111", 'F': "111100110100100", 'G': "011100101101011", 'H': "101101111101101",
+	'I': "111010010010111", 'J': "001001001101010", 'K': "101101110101101", 'L': "100100100100111",
+	'M': "101111111101101", 'N': "110101101101101", 'O': "010101101101010", 'P': "110101110100100",
+	'Q': "010101101110011", 'R': "110101110101101", 'S': "011100010001110", 'T': "111010010010010",
+	'U': "101101101101111", 'V': "101101101101010", 'W': "101101111111101", 'X': "101101010101101",
+	'Y': "101101010010010", 'Z': "111001010100111",
+	' ': "000000000000000", '.': "000000000000010", ',': "000000000010100", ':': "000010000010000",
+	'-': "000000111000000", '+': "000010111010000", '=': "000111000111000", '_': "000000000000111",
+	'/': "001001010100100", '(': "010100100100010", ')': "010001001001010", '<': "001010100010001",
+	'>': "100010001010100", '%': "101001010100101", '?': "111001010000010",
+}
+
+// ChartPNG collects the schedules written to a writer to draw, one under another, in one PNG image: the
+// GANTT chart of each and a bar chart of how long each of its processes waited.
+type ChartPNG struct {
+	charts []pngChart
+}
+
+// pngChart is what is drawn of one schedule.
+type pngChart struct {
+	svgChart
+	processes []ProcessResult
+}
+
+// WithChartPNG returns a writer to w that has schedules written to it also add their charts to c.
+func WithChartPNG(w io.Writer, c *ChartPNG) io.Writer {
+	ow := options(w)
+	ow.png = c
+	return ow
+}
+
+// add adds the charts of a schedule titled title, with times shown in unit u.
+func (c *ChartPNG) add(title string, r Result, u TimeUnit) {
+	if len(r.Gantt) > 0 {
+		c.charts = append(c.charts, pngChart{svgChart: svgChart{title: title, gantt: r.Gantt, unit: u}, processes: r.Processes})
+	}
+}
+
+// WriteTo writes the charts as a PNG image. Each GANTT chart draws a lane per process, or per CPU for a
+// schedule run on several, as the SVG ones do; text is drawn in capitals.
+func (c *ChartPNG) WriteTo(w io.Writer) (int64, error) {
+	var (
+		width  = svgMargin + svgLabelWidth + svgPlotWidth + svgMargin
+		height = svgMargin
+	)
+	for _, ch := range c.charts {
+		height += ch.height() + svgMargin
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(pngWhite), image.Point{}, draw.Src)
+	y := svgMargin
+	for _, ch := range c.charts {
+		ch.draw(img, y)
+		y += ch.height() + svgMargin
+	}
+
+	cw := &countingWriter{w: w}
+	err := png.Encode(cw, img)
+	return cw.n, err
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// height is how tall the charts of the schedule are drawn.
+func (ch pngChart) height() int {
+	return ch.svgChart.height() + pngWaitHeading + len(ch.processes)*(pngWaitBar+pngWaitGap)
+}
+
+// draw draws the charts of the schedule with their top at y.
+func (ch pngChart) draw(img *image.RGBA, y int) {
+	var (
+		start, stop = ch.span()
+		scale       = float64(svgPlotWidth) / float64(stop-start)
+		left        = svgMargin + svgLabelWidth
+		x           = func(t int64) int { return left + int(math.Round(float64(t-start)*scale)) }
+	)
+	pngText(img, svgMargin, y+9, ch.title, pngBlack)
+	y += svgTitle
+
+	for _, lane := range ch.lanes() {
+		pngText(img, svgMargin, y+6, lane.name, pngBlack)
+		for _, s := range lane.slices {
+			x0, x1 := x(s.Start), x(s.Stop)
+			pngRect(img, x0, y, x1, y+svgLaneHeight, pngColor(s.PID))
+			if x1 > x0 {
+				pngRect(img, x1-1, y, x1, y+svgLaneHeight, pngWhite)
+			}
+			if label := sliceLabel(s); x1-x0 >= pngTextWidth(label)+4 {
+				pngText(img, (x0+x1-pngTextWidth(label))/2, y+6, label, pngBlack)
+			}
+		}
+		y += svgLaneHeight + svgLaneGap
+	}
+
+	pngRect(img, left, y, left+svgPlotWidth+1, y+1, pngBlack)
+	step := svgTickStep(stop - start)
+	for t := start - start%step; t <= stop; t += step {
+		label := ch.unit.value(t)
+		pngRect(img, x(t), y, x(t)+1, y+5, pngBlack)
+		pngText(img, x(t)-pngTextWidth(label)/2, y+8, label, pngBlack)
+	}
+	caption := "time (" + ch.timeName() + ")"
+	pngText(img, left+svgPlotWidth-pngTextWidth(caption), y+22, caption, pngBlack)
+	y += svgAxis
+
+	pngText(img, svgMargin, y+8, "wait ("+ch.timeName()+")", pngBlack)
+	y += pngWaitHeading
+	var longest int64
+	for _, p := range ch.processes {
+		if p.Wait > longest {
+			longest = p.Wait
+		}
+	}
+	for _, p := range ch.processes {
+		pngText(img, svgMargin, y+2, "P"+strconv.FormatInt(p.ProcessID, 10), pngBlack)
+		end := left
+		if longest > 0 {
+			end += int(math.Round(float64(p.Wait) / float64(longest) * (svgPlotWidth - 64)))
+		}
+		pngRect(img, left, y, end, y+pngWaitBar, pngColor(p.ProcessID))
+		pngText(img, end+6, y+2, ch.unit.value(p.Wait), pngBlack)
+		y += pngWaitBar + pngWaitGap
+	}
+}
+
+// timeName is what the chart's times are counted in.
+func (ch pngChart) timeName() string {
+	if ch.unit.Name == "" {
+		return "ticks"
+	}
+	return ch.unit.Name
+}
+
+// pngColor is the fill of a PID's bars, or grey for a CPU held idle or deciding what to run.
+func pngColor(pid int64) color.RGBA {
+	if pid == heldPID || pid == overheadPID {
+		return pngGrey
+	}
+	return hslColor(pidHue(pid), 0.65, 0.6)
+}
+
+// hslColor converts a colour from hue, in degrees, saturation and lightness to RGB.
+func hslColor(h, s, l float64) color.RGBA {
+	c := (1 - math.Abs(2*l-1)) * s
+	hp := h / 60
+	x := c * (1 - math.Abs(math.Mod(hp, 2)-1))
+	var r, g, b float64
+	switch {
+	case hp < 1:
+		r, g = c, x
+	case hp < 2:
+		r, g = x, c
+	case hp < 3:
+		g, b = c, x
+	case hp < 4:
+		g, b = x, c
+	case hp < 5:
+		r, b = x, c
+	default:
+		r, b = c, x
+	}
+	m := l - c/2
+	byteOf := func(v float64) uint8 { return uint8(math.Round((v + m) * 255)) }
+	return color.RGBA{R: byteOf(r), G: byteOf(g), B: byteOf(b), A: 0xff}
+}
+
+// pngRect fills the rectangle from x0, y0 up to x1, y1.
+func pngRect(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	draw.Draw(img, image.Rect(x0, y0, x1, y1), image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// pngText draws s in capitals with its top left corner at x, y.
+func pngText(img *image.RGBA, x, y int, s string, c color.RGBA) {
+	for _, r := range strings.ToUpper(strings.ReplaceAll(s, "µ", "u")) {
+		glyph, ok := pngFont[r]
+		if !ok {
+			glyph = pngFont['?']
+		}
+		for i, dot := range glyph {
+			if dot == '1' {
+				dx, dy := x+i%3*pngGlyphScale, y+i/3*pngGlyphScale
+				pngRect(img, dx, dy, dx+pngGlyphScale, dy+pngGlyphScale, c)
+			}
+		}
+		x += 4 * pngGlyphScale
+	}
+}
+
+// pngTextWidth is how wide s is drawn, leaving out the space after its last glyph.
+func pngTextWidth(s string) int {
+	n := len([]rune(strings.ReplaceAll(s, "µ", "u")))
+	if n == 0 {
+		return 0
+	}
+	return n*4*pngGlyphScale - pngGlyphScale
+}
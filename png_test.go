@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"testing"
+)
+
+func TestChartPNG(t *testing.T) {
+	t.Parallel()
+	c := &ChartPNG{}
+	RRSchedule(WithChartPNG(io.Discard, c), "Round-robin", []Process{
+		{ProcessID: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+	}, 2)
+
+	var w bytes.Buffer
+	n, err := c.WriteTo(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(w.Len()) {
+		t.Errorf("ChartPNG.WriteTo() = %d, wrote %d bytes", n, w.Len())
+	}
+	img, err := png.Decode(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := img.Bounds(), image.Rect(0, 0, 816, 208); got != want {
+		t.Errorf("bounds = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+		want color.Color
+	}{
+		{name: "P1 runs first", x: 100, y: 50, want: pngColor(1)},
+		{name: "P2 runs after P1's quantum", x: 400, y: 75, want: pngColor(2)},
+		{name: "P2 waits for P1", x: 100, y: 75, want: pngWhite},
+		{name: "P1 waits longest", x: 700, y: 160, want: pngColor(1)},
+		{name: "P2 waits half as long", x: 400, y: 180, want: pngColor(2)},
+		{name: "past P2's wait", x: 500, y: 180, want: pngWhite},
+	}
+	for _, tt := range tests {
+		if got := color.RGBAModel.Convert(img.At(tt.x, tt.y)); got != tt.want {
+			t.Errorf("%s: pixel (%d, %d) = %v, want %v", tt.name, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func Test_hslColor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		h, s, l float64
+		want    color.RGBA
+	}{
+		{h: 0, s: 1, l: 0.5, want: color.RGBA{R: 0xff, A: 0xff}},
+		{h: 120, s: 1, l: 0.5, want: color.RGBA{G: 0xff, A: 0xff}},
+		{h: 240, s: 1, l: 0.5, want: color.RGBA{B: 0xff, A: 0xff}},
+		{h: 60, s: 0, l: 1, want: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}},
+	}
+	for _, tt := range tests {
+		if got := hslColor(tt.h, tt.s, tt.l); got != tt.want {
+			t.Errorf("hslColor(%v, %v, %v) = %v, want %v", tt.h, tt.s, tt.l, got, tt.want)
+		}
+	}
+}
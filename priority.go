@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// Aging improves the effective priority of processes while they wait, so low priority processes cannot starve.
+type Aging struct {
+	// Interval is how many ticks a process must wait for each improvement; zero disables aging.
+	Interval int64
+	// Step is how much the priority number drops at each improvement, never going below 1.
+	Step int64
+}
+
+// PreemptivePrioritySchedule outputs a preemptive priority schedule of processes in a GANTT chart
+// and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • how waiting processes age, if at all
+//
+// The running process is preempted as soon as a process with a strictly higher priority
+// (lower number) is ready, and keeps the CPU on ties. Ties among ready processes go to the one first in
+// the ready queue: processes join it as they arrive, and a preempted process rejoins at the back, behind
+// any of the same priority that arrived while it ran.
+func PreemptivePrioritySchedule(w io.Writer, title string, processes []Process, aging Aging) {
+	prioritySchedule(w, title, processes, aging, true)
+}
+
+// NonPreemptivePrioritySchedule outputs a non-preemptive priority schedule of processes in a GANTT chart
+// and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • how waiting processes age, if at all
+//
+// Whenever the CPU is free the highest priority (lowest number) ready process is run to completion.
+func NonPreemptivePrioritySchedule(w io.Writer, title string, processes []Process, aging Aging) {
+	prioritySchedule(w, title, processes, aging, false)
+}
+
+func prioritySchedule(w io.Writer, title string, processes []Process, aging Aging, preemptive bool) {
+	r := simulate(processes, policy{pick: highestPriority, preemptive: preemptive, aging: aging})
+	outputResult(w, title, r)
+	if aging.Interval > 0 && len(processes) > 0 {
+		outputStarvation(w, r, simulate(processes, policy{pick: highestPriority, preemptive: preemptive}))
+	}
+}
+
+// highestPriority picks the ready job with the lowest effective priority number, keeping the earliest on ties.
+func highestPriority(ready []*job, _ int64) int {
+	best := 0
+	for i := range ready {
+		if ready[i].priority < ready[best].priority {
+			best = i
+		}
+	}
+	return best
+}
+
+// age counts a tick of waiting against j, improving its effective priority every Interval ticks.
+func (a Aging) age(j *job) {
+	if a.Interval <= 0 {
+		return
+	}
+	j.sinceAged++
+	if j.sinceAged < a.Interval {
+		return
+	}
+	j.sinceAged = 0
+
+	step := a.Step
+	if step < 1 {
+		step = 1
+	}
+	if j.priority > 1 {
+		j.priority -= step
+		if j.priority < 1 {
+			j.priority = 1
+		}
+	}
+}
+
+// outputStarvation compares the longest wait of an aged schedule against the same schedule without aging.
+func outputStarvation(w io.Writer, aged, baseline Result) {
+	longest := func(r Result) ProcessResult {
+		worst := r.Processes[0]
+		for _, p := range r.Processes {
+			if p.Wait > worst.Wait {
+				worst = p
+			}
+		}
+		return worst
+	}
+
+	a, b := longest(aged), longest(baseline)
+	verdict := "no"
+	if a.Wait < b.Wait {
+		verdict = "yes"
+	}
+	unit := unitOf(w)
+	_, _ = fmt.Fprintf(w, "%s: %s; %s: %s (P%d); %s: %s (P%d)\n", msg(w, "Starvation avoided"), msg(w, verdict),
+		msg(w, "Longest wait"), unit.format(a.Wait), a.ProcessID, msg(w, "Without aging"), unit.format(b.Wait), b.ProcessID)
+}
+
+// PriorityRRSchedule outputs a round-robin schedule with priority queues of processes in a GANTT chart
+// and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the time quantum shared by every priority level
+//
+// Ready processes are grouped by priority and the highest priority (lowest number) non-empty group is run
+// round-robin. A process arriving with a higher priority preempts the running one, which goes to the back
+// of its group.
+func PriorityRRSchedule(w io.Writer, title string, processes []Process, quantum int64) {
+	if quantum < 1 {
+		quantum = defaultQuantum
+	}
+	outputResult(w, title, simulate(processes, policy{pick: highestPriority, preemptive: true, quantum: quantum}))
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPreemptivePrioritySchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		aging     Aging
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 3,
+						Priority:      3,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   1,
+						BurstDuration: 4,
+						Priority:      2,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   2,
+						BurstDuration: 6,
+						Priority:      4,
+					},
+					{
+						ProcessID:     4,
+						ArrivalTime:   3,
+						BurstDuration: 4,
+						Priority:      6,
+					},
+					{
+						ProcessID:     5,
+						ArrivalTime:   5,
+						BurstDuration: 2,
+						Priority:      10,
+					},
+				},
+				title: "Priority",
+			},
+			wantOut: loadFixture(t, "sjfp_test.txt"),
+		},
+		{
+			name: "equal priority does not preempt",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 4,
+						Priority:      2,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   1,
+						BurstDuration: 3,
+						Priority:      1,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   2,
+						BurstDuration: 2,
+						Priority:      1,
+					},
+					{
+						ProcessID:     4,
+						ArrivalTime:   6,
+						BurstDuration: 2,
+						Priority:      3,
+					},
+				},
+				title: "Preemptive priority",
+			},
+			wantOut: loadFixture(t, "ppri_test.txt"),
+		},
+		{
+			name: "aging avoids starvation",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 3,
+						Priority:      5,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   0,
+						BurstDuration: 3,
+						Priority:      1,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   3,
+						BurstDuration: 3,
+						Priority:      1,
+					},
+					{
+						ProcessID:     4,
+						ArrivalTime:   6,
+						BurstDuration: 3,
+						Priority:      1,
+					},
+					{
+						ProcessID:     5,
+						ArrivalTime:   9,
+						BurstDuration: 3,
+						Priority:      2,
+					},
+				},
+				title: "Preemptive priority",
+				aging: Aging{Interval: 2, Step: 2},
+			},
+			wantOut: loadFixture(t, "ppri_aging_test.txt"),
+		},
+		{
+			name: "scheduled priority changes",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 6,
+						Priority:      2,
+					},
+					{
+						ProcessID:       2,
+						ArrivalTime:     1,
+						BurstDuration:   4,
+						Priority:        3,
+						PriorityChanges: []PriorityChange{{At: 3, Priority: 1}},
+					},
+					{
+						ProcessID:       3,
+						ArrivalTime:     2,
+						BurstDuration:   3,
+						Priority:        1,
+						PriorityChanges: []PriorityChange{{At: 4, Priority: 4}},
+					},
+				},
+				title: "Preemptive priority",
+			},
+			wantOut: loadFixture(t, "ppri_changes_test.txt"),
+		},
+		{
+			name: "spawned children",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 4,
+						Priority:      2,
+						Spawns: []Spawn{
+							{After: 1, ProcessID: 3, BurstDuration: 2, Priority: 1},
+							{After: 3, ProcessID: 4, BurstDuration: 1},
+						},
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   0,
+						BurstDuration: 2,
+						Priority:      3,
+					},
+				},
+				title: "Preemptive priority",
+			},
+			wantOut: loadFixture(t, "ppri_spawn_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			PreemptivePrioritySchedule(&w, tt.args.title, tt.args.processes, tt.args.aging)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("PreemptivePrioritySchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestNonPreemptivePrioritySchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		aging     Aging
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 4,
+						Priority:      2,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   1,
+						BurstDuration: 3,
+						Priority:      1,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   2,
+						BurstDuration: 2,
+						Priority:      1,
+					},
+					{
+						ProcessID:     4,
+						ArrivalTime:   6,
+						BurstDuration: 2,
+						Priority:      3,
+					},
+				},
+				title: "Non-preemptive priority",
+			},
+			wantOut: loadFixture(t, "nppri_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			NonPreemptivePrioritySchedule(&w, tt.args.title, tt.args.processes, tt.args.aging)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("NonPreemptivePrioritySchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestPriorityRRSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		quantum   int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "round-robin within the highest priority",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 4,
+						Priority:      3,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   0,
+						BurstDuration: 5,
+						Priority:      2,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   0,
+						BurstDuration: 8,
+						Priority:      2,
+					},
+					{
+						ProcessID:     4,
+						ArrivalTime:   0,
+						BurstDuration: 7,
+						Priority:      1,
+					},
+					{
+						ProcessID:     5,
+						ArrivalTime:   3,
+						BurstDuration: 3,
+						Priority:      3,
+					},
+				},
+				title:   "Priority round-robin",
+				quantum: 2,
+			},
+			wantOut: loadFixture(t, "prr_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			PriorityRRSchedule(&w, tt.args.title, tt.args.processes, tt.args.quantum)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("PriorityRRSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// priorityClass is the summary of the processes of a schedule given one priority.
+type priorityClass struct {
+	priority  int64
+	processes int
+	summary
+}
+
+// priorityClasses breaks the summary of a schedule down by the priority each process was given, highest
+// priority first, leaving out killed processes when the averages do. Schedules of processes all given the
+// same priority, as when they were loaded without one, have no breakdown.
+func priorityClasses(r Result) []priorityClass {
+	levels := make(map[int64][]ProcessResult)
+	for _, p := range r.Processes {
+		if p.Killed && r.ExcludeKilled {
+			continue
+		}
+		levels[p.Priority] = append(levels[p.Priority], p)
+	}
+	if len(levels) < 2 {
+		return nil
+	}
+	classes := make([]priorityClass, 0, len(levels))
+	for priority, processes := range levels {
+		classes = append(classes, priorityClass{
+			priority:  priority,
+			processes: len(processes),
+			summary:   summarize(Result{Processes: processes}),
+		})
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].priority < classes[j].priority })
+	return classes
+}
+
+// outputPriorityClasses outputs a table of the average wait, turnaround and slowdown of the processes of a
+// schedule at each priority, if they were given more than one.
+func outputPriorityClasses(w io.Writer, r Result) {
+	classes := priorityClasses(r)
+	if len(classes) == 0 {
+		return
+	}
+	unit := unitOf(w)
+	rows := make([][]string, len(classes))
+	for i, c := range classes {
+		rows[i] = []string{fmt.Sprint(c.priority), fmt.Sprint(c.processes), unit.formatAverage(c.wait),
+			unit.formatAverage(c.turnaround), fmt.Sprintf("%.2f", c.slowdown)}
+	}
+	_, _ = fmt.Fprintln(w, msg(w, "By priority"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "Priority", "Processes", "Wait", "Turnaround", "Slowdown"))
+	table.SetColumnAlignment(sideBySideAlignment(5))
+	table.AppendBulk(rows)
+	table.Render()
+}
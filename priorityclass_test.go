@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOutputPriorityClasses(t *testing.T) {
+	t.Parallel()
+	r := Result{Processes: []ProcessResult{
+		{Process: Process{ProcessID: 1, Priority: 1, BurstDuration: 2}, Wait: 1, Turnaround: 3, Completion: 3},
+		{Process: Process{ProcessID: 2, Priority: 2, BurstDuration: 3}, Wait: 4, Turnaround: 7, Completion: 7},
+		{Process: Process{ProcessID: 3, Priority: 1, BurstDuration: 4}, Wait: 4, Turnaround: 8, Completion: 8},
+		{Process: Process{ProcessID: 4, Priority: 3}, Wait: 9, Turnaround: 9, Completion: 9, Killed: true},
+	}, ExcludeKilled: true}
+	tests := []struct {
+		name    string
+		r       Result
+		wantOut string
+	}{
+		{
+			name: "priorities",
+			r:    r,
+			wantOut: strings.Join([]string{
+				"By priority",
+				"+----------+-----------+--------+------------+----------+",
+				"| PRIORITY | PROCESSES |  WAIT  | TURNAROUND | SLOWDOWN |",
+				"+----------+-----------+--------+------------+----------+",
+				"| 1        |         2 | 2.50ms |     5.50ms |     1.75 |",
+				"| 2        |         1 | 4.00ms |     7.00ms |     2.33 |",
+				"+----------+-----------+--------+------------+----------+",
+				"",
+			}, "\n"),
+		},
+		{
+			name: "one priority",
+			r:    Result{Processes: r.Processes[:1]},
+		},
+		{
+			name: "one priority counted",
+			r:    Result{Processes: []ProcessResult{r.Processes[0], r.Processes[3]}, ExcludeKilled: true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputPriorityClasses(WithTimeUnit(&w, TimeUnit{Name: "ms"}), tt.r)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("outputPriorityClasses() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_priorityWaits(t *testing.T) {
+	t.Parallel()
+	processes := []ProcessResult{
+		{Process: Process{ProcessID: 1, Priority: 2, BurstDuration: 2}, Wait: 3, Turnaround: 5, Completion: 5},
+		{Process: Process{ProcessID: 2, Priority: 1, BurstDuration: 3}, Wait: 0, Turnaround: 3, Completion: 3},
+		{Process: Process{ProcessID: 3, Priority: 3, BurstDuration: 1}, Killed: true},
+	}
+	results := []Result{
+		{Processes: processes, ExcludeKilled: true},
+		{Processes: processes},
+		{Processes: processes[:1]},
+	}
+	want := [][]string{
+		{"Average wait, priority 1", "0.00", "0.00", "-"},
+		{"Average wait, priority 2", "3.00", "3.00", "-"},
+		{"Average wait, priority 3", "-", "0.00", "-"},
+	}
+	if got := priorityWaits(io.Discard, results); !reflect.DeepEqual(got, want) {
+		t.Errorf("priorityWaits() = %v, want %v", got, want)
+	}
+}
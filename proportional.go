@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// WithWidth returns a writer to w that has the GANTT charts of schedules written to it drawn width
+// columns wide, each slice's cell as wide as it ran for, rather than in cells of one width.
+func WithWidth(w io.Writer, width int) io.Writer {
+	ow := options(w)
+	ow.width = width
+	return ow
+}
+
+// chartWidth is the width GANTT charts written to f are drawn in for the -width given: itself if
+// positive, or if 0 the width of the terminal f is, from COLUMNS if set. Charts keep cells of one width
+// for 0 when f is not a terminal, and for negative widths.
+func chartWidth(width int, f *os.File) int {
+	if width != 0 {
+		return width
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			return n
+		}
+	}
+	return ttyWidth(f)
+}
+
+// outputProportional outputs a GANTT chart width columns wide, with a lane per CPU if it ran on several,
+// each slice's cell as wide as it ran for but at least one column, and idle time marked "-". Labels too
+// wide for their cell are shown by a letter, told apart by a key below the chart.
+func outputProportional(w io.Writer, gantt []TimeSlice, width int) {
+	var (
+		cpus        = cpuCount(gantt)
+		start, stop = (svgChart{gantt: gantt}).span()
+		keys        = make(map[string]rune)
+		keyed       []string
+	)
+	key := func(label string) string {
+		k, ok := keys[label]
+		if !ok {
+			const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+			k = '?'
+			if len(keyed) < len(letters) {
+				k = rune(letters[len(keyed)])
+			}
+			keys[label] = k
+			keyed = append(keyed, label)
+		}
+		return string(k)
+	}
+
+	for cpu := 0; cpu < cpus; cpu++ {
+		var (
+			lane []TimeSlice
+			at   = start
+		)
+		for _, s := range gantt {
+			if s.CPU != cpu {
+				continue
+			}
+			if at < s.Start {
+				lane = append(lane, TimeSlice{PID: idlePID, Start: at, Stop: s.Start, CPU: cpu})
+			}
+			lane = append(lane, s)
+			at = s.Stop
+		}
+		if cpus > 1 {
+			_, _ = fmt.Fprintf(w, "%s %d\n", msg(w, "CPU"), cpu)
+		}
+		if len(lane) == 0 {
+			_, _ = fmt.Fprintln(w)
+			continue
+		}
+
+		widths := proportionalWidths(lane, start, stop, width)
+		_, _ = fmt.Fprint(w, "|")
+		for i, s := range lane {
+			label := sliceLabel(s)
+			if len(label) > widths[i]-1 {
+				label = key(label)
+			}
+			_, _ = fmt.Fprint(w, proportionalCell(w, s.PID, label, widths[i]-1), "|")
+		}
+		_, _ = fmt.Fprintln(w)
+		if queued(lane) {
+			_, _ = fmt.Fprint(w, "|")
+			for i, s := range lane {
+				queue := s.Queue
+				if len(queue) > widths[i]-1 {
+					queue = queue[:widths[i]-1]
+				}
+				_, _ = fmt.Fprint(w, centre(queue, widths[i]-1), "|")
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprintln(w, proportionalTimes(w, lane, widths))
+	}
+
+	if len(keyed) > 0 {
+		pairs := make([]string, len(keyed))
+		for i, label := range keyed {
+			pairs[i] = fmt.Sprintf("%c=%s", keys[label], label)
+		}
+		_, _ = fmt.Fprintln(w, msg(w, "Key")+":", strings.Join(pairs, " "))
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// proportionalWidths returns the width of each slice's cell, counting the | closing it, in a chart of
+// width columns covering start to stop: its share of the chart by how long it ran, but at least 2, taken
+// back from the widest cells while the chart is too wide.
+func proportionalWidths(lane []TimeSlice, start, stop int64, width int) []int {
+	var (
+		widths = make([]int, len(lane))
+		scale  = float64(width-1) / float64(stop-start)
+		column = func(t int64) int { return int(float64(t-start)*scale + 0.5) }
+		total  int
+	)
+	for i, s := range lane {
+		if widths[i] = column(s.Stop) - column(s.Start); widths[i] < 2 {
+			widths[i] = 2
+		}
+		total += widths[i]
+	}
+	for total > width-1 {
+		widest := 0
+		for i := range widths {
+			if widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= 2 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// proportionalCell is the text of a cell n columns wide holding label, coloured for pid if w is to colour
+// charts.
+func proportionalCell(w io.Writer, pid int64, label string, n int) string {
+	cell := centre(label, n)
+	if pid == idlePID && label == "-" {
+		cell = strings.Repeat("-", n)
+	}
+	if options(w).color {
+		cell = ansiColor(pid) + cell + ansiReset
+	}
+	return cell
+}
+
+// centre centres s in n columns.
+func centre(s string, n int) string {
+	left := (n - len(s)) / 2
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", n-len(s)-left)
+}
+
+// proportionalTimes is the row of times under a lane of cells of the given widths: the time each cell
+// began under its opening |, and when the last ended under its closing one, leaving out times that would
+// run into the one before or the last.
+func proportionalTimes(w io.Writer, lane []TimeSlice, widths []int) string {
+	var (
+		unit  = unitOf(w)
+		row   []byte
+		col   int
+		times = make([]int64, 0, len(lane)+1)
+		cols  = make([]int, 0, len(lane)+1)
+	)
+	for i, s := range lane {
+		times, cols = append(times, s.Start), append(cols, col)
+		col += widths[i]
+	}
+	times, cols = append(times, lane[len(lane)-1].Stop), append(cols, col)
+
+	// The last time ends under the chart's closing |, and the others give way to it.
+	last := unit.format(times[len(times)-1])
+	end := cols[len(cols)-1] + 1 - len(last)
+	for i, t := range times[:len(times)-1] {
+		label := unit.format(t)
+		if at := cols[i]; (len(row) == 0 || at > len(row)) && at+len(label) < end {
+			row = append(row, strings.Repeat(" ", at-len(row))...)
+			row = append(row, label...)
+		}
+	}
+	if end <= len(row) {
+		end = len(row) + 1
+	}
+	row = append(row, strings.Repeat(" ", end-len(row))...)
+	row = append(row, last...)
+	return string(row)
+}
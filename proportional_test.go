@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_outputProportional(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		width int
+		gantt []TimeSlice
+		want  string
+	}{
+		{
+			name:  "idle gap",
+			width: 25,
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 6, Stop: 8}},
+			want: "Gantt schedule\n" +
+				"|     1     |-----|  2  |\n" +
+				"0           4     6     8\n\n",
+		},
+		{
+			name:  "key to labels too wide for their cells",
+			width: 20,
+			gantt: []TimeSlice{{PID: 100, Start: 0, Stop: 10}, {PID: 101, Start: 10, Stop: 11}, {PID: 102, Start: 11, Stop: 12}, {PID: 101, Start: 12, Stop: 13}},
+			want: "Gantt schedule\n" +
+				"|    100     |a|b|a|\n" +
+				"0            10   13\n" +
+				"Key: a=101 b=102\n\n",
+		},
+		{
+			name:  "lane per CPU",
+			width: 21,
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 0, Stop: 4, CPU: 1}, {PID: 3, Start: 2, Stop: 4, Queue: "Q1"}},
+			want: "Gantt schedule\n" +
+				"CPU 0\n" +
+				"|    1    |    3    |\n" +
+				"|         |   Q1    |\n" +
+				"0         2         4\n" +
+				"CPU 1\n" +
+				"|         2         |\n" +
+				"0                   4\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputGantt(WithWidth(&w, tt.width), tt.gantt)
+			if got := w.String(); got != tt.want {
+				t.Errorf("outputGantt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_chartWidth(t *testing.T) {
+	t.Parallel()
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	for _, tt := range []struct{ width, want int }{{width: 100, want: 100}, {width: -1, want: -1}, {width: 0, want: 0}} {
+		if got := chartWidth(tt.width, f); got != tt.want {
+			t.Errorf("chartWidth(%d) = %d, want %d", tt.width, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type (
+	// Quanta configures a quantum per priority level, for round-robin, or per queue, for feedback scheduling.
+	Quanta struct {
+		// Default is the quantum of levels without their own; zero is defaultQuantum.
+		Default int64
+		// Levels maps a priority or queue number to its quantum.
+		Levels map[int64]int64
+	}
+	// QuantumDecision is a dispatch of a process and the quantum it was given.
+	QuantumDecision struct {
+		TimeSlice
+		Quantum int64
+	}
+)
+
+// of is the quantum of a level.
+func (q Quanta) of(level int64) int64 {
+	if quantum := q.Levels[level]; quantum > 0 {
+		return quantum
+	}
+	if q.Default > 0 {
+		return q.Default
+	}
+	return defaultQuantum
+}
+
+// PriorityQuantaRRSchedule outputs a round-robin schedule of processes in a GANTT chart, a table of timing
+// and a trace of every dispatch with the quantum it was given, given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the quantum of each priority level
+//
+// Processes share one FIFO ready queue as under RRSchedule, but each runs for the quantum of its priority.
+func PriorityQuantaRRSchedule(w io.Writer, title string, processes []Process, quanta Quanta) {
+	r, decisions := roundRobinBy(processes, func(p Process) int64 { return quanta.of(p.Priority) })
+	priorities := make(map[int64]int64, len(processes))
+	for _, p := range processes {
+		priorities[p.ProcessID] = p.Priority
+	}
+	for k := range decisions {
+		decisions[k].Queue = strconv.FormatInt(priorities[decisions[k].PID], 10)
+	}
+	outputResult(w, title, r)
+	if len(processes) > 0 {
+		outputDecisions(w, decisions)
+	}
+}
+
+// outputDecisions outputs every dispatch with the level it was dispatched at and the quantum it was given.
+func outputDecisions(w io.Writer, decisions []QuantumDecision) {
+	_, _ = fmt.Fprintln(w, msg(w, "Dispatch trace"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "ID", "Start", "Stop", "Level", "Quantum"))
+	for _, d := range decisions {
+		table.Append([]string{
+			fmt.Sprint(d.PID),
+			fmt.Sprint(d.Start),
+			fmt.Sprint(d.Stop),
+			d.Queue,
+			fmt.Sprint(d.Quantum),
+		})
+	}
+	table.Render()
+}
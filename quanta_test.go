@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestQuantaSchedules(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		schedule func(w *bytes.Buffer)
+		wantOut  string
+	}{
+		{
+			name: "round-robin by priority",
+			schedule: func(w *bytes.Buffer) {
+				PriorityQuantaRRSchedule(w, "Round-robin by priority", []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 1},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+				}, Quanta{Default: 1, Levels: map[int64]int64{1: 3}})
+			},
+			wantOut: loadFixture(t, "rr_quanta_test.txt"),
+		},
+		{
+			name: "feedback by queue",
+			schedule: func(w *bytes.Buffer) {
+				FeedbackQuantaSchedule(w, "Feedback", []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+					{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+				}, 3, Quanta{Default: 2, Levels: map[int64]int64{0: 1, 2: 4}})
+			},
+			wantOut: loadFixture(t, "feedback_quanta_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			tt.schedule(&w)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestQuanta_of(t *testing.T) {
+	t.Parallel()
+	q := Quanta{Default: 5, Levels: map[int64]int64{1: 3, 2: 0}}
+	for level, want := range map[int64]int64{1: 3, 2: 5, 7: 5} {
+		if got := q.of(level); got != want {
+			t.Errorf("of(%d) = %d, want %d", level, got, want)
+		}
+	}
+	if got := (Quanta{}).of(1); got != defaultQuantum {
+		t.Errorf("of(1) = %d, want %d", got, defaultQuantum)
+	}
+}
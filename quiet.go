@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Quiet modes of output.
+const (
+	quietOff = iota
+	quietText
+	quietJSON
+)
+
+// WithQuiet returns a writer to w that has schedules written to it output only a line of their aggregate
+// metrics in place of their GANTT charts and tables, as a JSON object if asJSON, for scripts sweeping
+// many workloads.
+func WithQuiet(w io.Writer, asJSON bool) io.Writer {
+	ow := options(w)
+	ow.quiet = quietText
+	if asJSON {
+		ow.quiet = quietJSON
+	}
+	return ow
+}
+
+// quietMetrics is the line of metrics of a schedule in quiet JSON output, with the timing of each of its
+// processes. Times are in the writer's time unit, named by Unit unless bare ticks.
+type quietMetrics struct {
+	Schedule        string     `json:"schedule"`
+	Processes       int        `json:"processes"`
+	Wait            float64    `json:"wait"`
+	Turnaround      float64    `json:"turnaround"`
+	Throughput      float64    `json:"throughput"`
+	Makespan        float64    `json:"makespan"`
+	ContextSwitches int        `json:"context_switches"`
+	Utilization     float64    `json:"utilization"`
+	Idle            float64    `json:"idle"`
+	Unit            string     `json:"unit,omitempty"`
+	Jobs            []quietJob `json:"jobs,omitempty"`
+}
+
+// quietJob is the timing of a process in quiet JSON output.
+type quietJob struct {
+	ID         int64   `json:"id"`
+	Wait       float64 `json:"wait"`
+	Turnaround float64 `json:"turnaround"`
+	Completion float64 `json:"completion"`
+}
+
+// outputMetrics outputs the line of aggregate metrics of a schedule titled title.
+func outputMetrics(w io.Writer, title string, r Result) {
+	var (
+		unit     = unitOf(w)
+		s        = summarize(r)
+		switches = contextSwitches(r.Gantt)
+		_, cpu   = cpuTimes(r.Gantt)
+	)
+	if options(w).quiet == quietJSON {
+		jobs := make([]quietJob, len(r.Processes))
+		for i, p := range r.Processes {
+			jobs[i] = quietJob{
+				ID:         p.ProcessID,
+				Wait:       float64(p.Wait) * unit.scale(),
+				Turnaround: float64(p.Turnaround) * unit.scale(),
+				Completion: float64(p.Completion) * unit.scale(),
+			}
+		}
+		b, _ := json.Marshal(quietMetrics{
+			Schedule:        title,
+			Processes:       len(r.Processes),
+			Wait:            s.wait * unit.scale(),
+			Turnaround:      s.turnaround * unit.scale(),
+			Throughput:      s.throughput / unit.scale(),
+			Makespan:        float64(s.makespan) * unit.scale(),
+			ContextSwitches: switches,
+			Utilization:     cpu.utilization(),
+			Idle:            float64(cpu.idle) * unit.scale(),
+			Unit:            unit.Name,
+			Jobs:            jobs,
+		})
+		_, _ = fmt.Fprintln(w, string(b))
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s: %d processes, wait %s, turnaround %s, throughput %s, makespan %s, %d context switches, CPU utilization %.1f%%, idle %s\n",
+		title, len(r.Processes), unit.formatAverage(s.wait), unit.formatAverage(s.turnaround), unit.formatRate(s.throughput),
+		unit.format(s.makespan), switches, 100*cpu.utilization(), unit.format(cpu.idle))
+}
+
+// outputMetricsError outputs in place of a line of metrics the error that kept the schedule titled title
+// from running.
+func outputMetricsError(w io.Writer, title string, err error) {
+	if options(w).quiet == quietJSON {
+		b, _ := json.Marshal(struct {
+			Schedule string `json:"schedule"`
+			Error    string `json:"error"`
+		}{title, err.Error()})
+		_, _ = fmt.Fprintln(w, string(b))
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s: %v\n", title, err)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWithQuiet(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+	}
+	tests := []struct {
+		name    string
+		w       func(w io.Writer) io.Writer
+		wantOut string
+	}{
+		{
+			name:    "text",
+			w:       func(w io.Writer) io.Writer { return WithQuiet(w, false) },
+			wantOut: "Round-robin: 2 processes, wait 1.50, turnaround 4.00, throughput 0.40/t, makespan 5, 2 context switches, CPU utilization 100.0%, idle 0\n",
+		},
+		{
+			name:    "unit",
+			w:       func(w io.Writer) io.Writer { return WithQuiet(WithTimeUnit(w, TimeUnit{Name: "ms", Scale: 2}), false) },
+			wantOut: "Round-robin: 2 processes, wait 3.00ms, turnaround 8.00ms, throughput 0.20/ms, makespan 10ms, 2 context switches, CPU utilization 100.0%, idle 0ms\n",
+		},
+		{
+			name: "JSON",
+			w:    func(w io.Writer) io.Writer { return WithQuiet(w, true) },
+			wantOut: `{"schedule":"Round-robin","processes":2,"wait":1.5,"turnaround":4,"throughput":0.4,"makespan":5,"context_switches":2,"utilization":1,"idle":0,` +
+				`"jobs":[{"id":1,"wait":2,"turnaround":5,"completion":5},{"id":2,"wait":1,"turnaround":3,"completion":4}]}` + "\n",
+		},
+		{
+			name: "JSON unit",
+			w:    func(w io.Writer) io.Writer { return WithQuiet(WithTimeUnit(w, TimeUnit{Name: "ms", Scale: 2}), true) },
+			wantOut: `{"schedule":"Round-robin","processes":2,"wait":3,"turnaround":8,"throughput":0.2,"makespan":10,"context_switches":2,"utilization":1,"idle":0,"unit":"ms",` +
+				`"jobs":[{"id":1,"wait":4,"turnaround":10,"completion":10},{"id":2,"wait":2,"turnaround":6,"completion":8}]}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputResult(tt.w(&w), "Round-robin", roundRobin(processes, 2))
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("outputResult() = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_outputMetricsError(t *testing.T) {
+	t.Parallel()
+	err := errors.New(`bad "file"`)
+	var w bytes.Buffer
+	outputMetricsError(WithQuiet(&w, false), "b.csv", err)
+	outputMetricsError(WithQuiet(&w, true), "b.csv", err)
+	want := "b.csv: bad \"file\"\n" + `{"schedule":"b.csv","error":"bad \"file\""}` + "\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputMetricsError() = %q, want %q", got, want)
+	}
+}
+
+func TestWithQuiet_registered(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 2, Period: 6, Priority: 1, Class: "system", User: "ann", Deadline: 6},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 2, Class: "realtime", User: "bob"},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 3, Class: "batch", User: "bob", Memory: 512},
+	}
+	for _, name := range SchedulerNames() {
+		var w bytes.Buffer
+		reg := schedulers[name]
+		reg.output(WithQuiet(&w, true), reg.title, processes, runParams{quantum: 2, cpus: 2})
+		lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+		for _, line := range lines {
+			var m quietMetrics
+			if err := json.Unmarshal([]byte(line), &m); err != nil {
+				t.Errorf("%s: line %q of -quiet -json output: %v", name, line, err)
+			}
+		}
+		if len(lines) != 1 {
+			t.Errorf("%s: -quiet -json output %d lines, want 1", name, len(lines))
+		}
+	}
+}
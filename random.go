@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// RandomSchedule outputs a random dispatch schedule of processes in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the time quantum after which another process is drawn
+// • the seed for the random number generator
+//
+// Each quantum any ready process, including the one whose quantum just expired, is equally likely to run.
+// It makes a statistical baseline for other schedulers, and the same seed always gives the same schedule.
+func RandomSchedule(w io.Writer, title string, processes []Process, quantum, seed int64) {
+	outputResult(w, title, randomDispatch(processes, quantum, seed))
+	if len(processes) > 0 {
+		_, _ = fmt.Fprintf(w, "%s: %d\n", msg(w, "Seed"), seed)
+	}
+}
+
+func randomDispatch(processes []Process, quantum, seed int64) Result {
+	if quantum < 1 {
+		quantum = defaultQuantum
+	}
+	rng := rand.New(rand.NewSource(seed))
+	return simulate(processes, policy{
+		pick: func(ready []*job, _ int64) int {
+			return rng.Intn(len(ready))
+		},
+		quantum: quantum,
+	})
+}
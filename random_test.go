@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestRandomSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{
+			ProcessID:     1,
+			ArrivalTime:   0,
+			BurstDuration: 5,
+		},
+		{
+			ProcessID:     2,
+			ArrivalTime:   1,
+			BurstDuration: 4,
+		},
+		{
+			ProcessID:     3,
+			ArrivalTime:   2,
+			BurstDuration: 2,
+		},
+		{
+			ProcessID:     4,
+			ArrivalTime:   3,
+			BurstDuration: 1,
+		},
+	}
+
+	t.Run("seeded", func(t *testing.T) {
+		t.Parallel()
+		var w bytes.Buffer
+		RandomSchedule(&w, "Random", processes, 2, 42)
+		if got, want := w.String(), loadFixture(t, "random_test.txt"); got != want {
+			t.Errorf("RandomSchedule() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("reproducible", func(t *testing.T) {
+		t.Parallel()
+		a, b := randomDispatch(processes, 2, 7), randomDispatch(processes, 2, 7)
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("randomDispatch() = %v, then %v with the same seed", a, b)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// sparklineWidth is how many columns a ready queue sparkline takes at most when w has no chart width.
+const sparklineWidth = 60
+
+// sparkBars are the bars of a sparkline, from the shortest to the tallest.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// WithReadyQueueCSV returns a writer to w that has schedules written to it also write the length of their
+// ready queue to dst as CSV, a row each time it changed, under a single header row, so the backlog of
+// several policies can be plotted together.
+func WithReadyQueueCSV(w io.Writer, dst io.Writer) io.Writer {
+	ow := options(w)
+	ow.ready = &readyQueueCSV{w: csv.NewWriter(dst)}
+	return ow
+}
+
+// readyQueueCSV writes the length of the ready queue of schedules as CSV rows.
+type readyQueueCSV struct {
+	w      *csv.Writer
+	headed bool
+}
+
+// write writes a row for each time the ready queue of r, scheduled under title, changed length.
+func (rc *readyQueueCSV) write(title string, r Result, u TimeUnit) error {
+	if !rc.headed {
+		if err := rc.w.Write([]string{"schedule", "time", "ready"}); err != nil {
+			return err
+		}
+		rc.headed = true
+	}
+	for _, c := range readyQueue(r) {
+		if err := rc.w.Write([]string{title, u.value(c.at), strconv.FormatInt(c.ready, 10)}); err != nil {
+			return err
+		}
+	}
+	rc.w.Flush()
+	return rc.w.Error()
+}
+
+// WithSparkline returns a writer to w that has schedules written to it follow their schedule tables with a
+// sparkline of the length of their ready queue over time, so backlogs building up stand out.
+func WithSparkline(w io.Writer) io.Writer {
+	ow := options(w)
+	ow.sparkline = true
+	return ow
+}
+
+// outputSparkline outputs a sparkline of the ready queue of a schedule, if w asks for it: a column per
+// tick from the first arrival to the last completion, or per stretch of ticks showing the longest the
+// queue got in it when that would be wider than w's charts, blank while nothing waited.
+func outputSparkline(w io.Writer, r Result) {
+	o := options(w)
+	if !o.sparkline {
+		return
+	}
+	counts := readyQueue(r)
+	if len(counts) == 0 {
+		return
+	}
+	var (
+		start, stop = counts[0].at, counts[len(counts)-1].at
+		columns     = sparklineWidth
+		longest     int64
+	)
+	for _, p := range r.Processes {
+		if p.Completion > stop {
+			stop = p.Completion
+		}
+	}
+	for _, c := range counts {
+		if c.ready > longest {
+			longest = c.ready
+		}
+	}
+	if stop <= start {
+		return
+	}
+	if o.width > 2 {
+		columns = o.width - 2
+	}
+	if n := stop - start; n < int64(columns) {
+		columns = int(n)
+	}
+
+	var (
+		line strings.Builder
+		at   int
+	)
+	for col := 0; col < columns; col++ {
+		from := start + (stop-start)*int64(col)/int64(columns)
+		to := start + (stop-start)*int64(col+1)/int64(columns)
+		var ready int64
+		for at+1 < len(counts) && counts[at+1].at <= from {
+			at++
+		}
+		for i := at; i < len(counts) && counts[i].at < to; i++ {
+			if counts[i].ready > ready {
+				ready = counts[i].ready
+			}
+		}
+		if ready <= 0 {
+			line.WriteRune(' ')
+			continue
+		}
+		line.WriteRune(sparkBars[(ready*int64(len(sparkBars))+longest-1)/longest-1])
+	}
+
+	first, last := o.unit.format(start), o.unit.format(stop)
+	gap := columns + 2 - len(first) - len(last)
+	if gap < 1 {
+		gap = 1
+	}
+	_, _ = fmt.Fprintf(w, "%s, %s %d\n", msg(w, "Ready queue"), msg(w, "max"), longest)
+	_, _ = fmt.Fprintf(w, "|%s|\n", line.String())
+	_, _ = fmt.Fprintf(w, "%s%s%s\n\n", first, strings.Repeat(" ", gap), last)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// readyQueueResult is a schedule whose ready queue grows to 2, shrinks to 1 and empties before the last
+// process completes.
+var readyQueueResult = Result{
+	Processes: []ProcessResult{
+		{Process: Process{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3}, Completion: 3},
+		{Process: Process{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2}, Completion: 5},
+		{Process: Process{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1}, Completion: 6},
+	},
+	Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 3, Start: 5, Stop: 6}},
+}
+
+func Test_outputSparkline(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		w       func(w *bytes.Buffer) io.Writer
+		wantOut string
+	}{
+		{
+			name: "off",
+			w:    func(w *bytes.Buffer) io.Writer { return w },
+		},
+		{
+			name: "tick per column",
+			w: func(w *bytes.Buffer) io.Writer {
+				return WithSparkline(WithTimeUnit(w, TimeUnit{Name: "ms"}))
+			},
+			wantOut: "Ready queue, max 2\n| ██▄▄ |\n0ms  6ms\n\n",
+		},
+		{
+			name: "ticks per column",
+			w: func(w *bytes.Buffer) io.Writer {
+				return WithSparkline(WithWidth(w, 5))
+			},
+			wantOut: "Ready queue, max 2\n|██▄|\n0   6\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputSparkline(tt.w(&w), readyQueueResult)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("outputSparkline() = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestWithReadyQueueCSV(t *testing.T) {
+	t.Parallel()
+	var w, dst bytes.Buffer
+	out := WithReadyQueueCSV(WithTimeUnit(&w, TimeUnit{Name: "ms", Scale: 0.5}), &dst)
+	exportResult(out, "FCFS", readyQueueResult)
+	exportResult(out, "Again", Result{Processes: readyQueueResult.Processes[:1], Gantt: readyQueueResult.Gantt[:1]})
+
+	want := "schedule,time,ready\n" +
+		"FCFS,0,0\n" +
+		"FCFS,0.5,2\n" +
+		"FCFS,1.5,1\n" +
+		"FCFS,2.5,0\n" +
+		"Again,0,0\n"
+	if got := dst.String(); got != want {
+		t.Errorf("WithReadyQueueCSV() wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// RealTimePolicy orders the processes of the real-time class.
+type RealTimePolicy int
+
+const (
+	// RealTimeDeadline runs the real-time process with the earliest deadline.
+	RealTimeDeadline RealTimePolicy = iota
+	// RealTimePriority runs the real-time process with the highest fixed priority (lowest number).
+	RealTimePriority
+)
+
+// realTimeClass is the class of processes scheduled ahead of every best-effort process.
+const realTimeClass = "realtime"
+
+// RealTimeSchedule outputs a two-class schedule of processes in a GANTT chart, a table of timing and a table of
+// metrics for each class given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the policy ordering the real-time class
+// • the round-robin quantum of the best-effort class
+//
+// Processes of class realtime always preempt the others, which share the CPU round-robin whenever no
+// real-time process is ready.
+func RealTimeSchedule(w io.Writer, title string, processes []Process, rt RealTimePolicy, quantum int64) {
+	r := realTime(processes, rt, quantum)
+	outputResult(w, title, r)
+	outputClasses(w, r)
+}
+
+func realTime(processes []Process, rt RealTimePolicy, quantum int64) Result {
+	if quantum < 1 {
+		quantum = defaultQuantum
+	}
+	before := func(a, b *job) bool {
+		if rt == RealTimePriority {
+			return a.Priority < b.Priority
+		}
+		return lessNonZero(a.Deadline, b.Deadline)
+	}
+	return simulate(processes, policy{
+		pick: func(ready []*job, _ int64) int {
+			best := -1
+			for i, j := range ready {
+				if isRealTime(j.Process) && (best < 0 || before(j, ready[best])) {
+					best = i
+				}
+			}
+			if best < 0 {
+				// Best-effort processes keep the CPU until their quantum expires.
+				return 0
+			}
+			return best
+		},
+		preemptive: true,
+		quantum:    quantum,
+	})
+}
+
+func isRealTime(p Process) bool {
+	return strings.EqualFold(p.Class, realTimeClass)
+}
+
+// outputClasses outputs the average wait and turnaround, and the deadline misses, of the real-time and
+// best-effort classes separately.
+func outputClasses(w io.Writer, r Result) {
+	if len(r.Processes) == 0 {
+		return
+	}
+	var rt, be Result
+	for _, p := range r.Processes {
+		if isRealTime(p.Process) {
+			rt.Processes = append(rt.Processes, p)
+		} else {
+			be.Processes = append(be.Processes, p)
+		}
+	}
+
+	var rows [][]string
+	for _, c := range []struct {
+		name string
+		r    Result
+	}{{"Real-time", rt}, {"Best-effort", be}} {
+		if len(c.r.Processes) == 0 {
+			continue
+		}
+		var missed, deadlines int
+		for _, p := range c.r.Processes {
+			if p.Deadline != 0 {
+				deadlines++
+			}
+			if p.Missed() {
+				missed++
+			}
+		}
+		misses := "-"
+		if deadlines > 0 {
+			misses = fmt.Sprintf("%d of %d", missed, deadlines)
+		}
+		s := summarize(c.r)
+		rows = append(rows, []string{
+			c.name,
+			fmt.Sprint(len(c.r.Processes)),
+			fmt.Sprintf("%.2f", s.wait),
+			fmt.Sprintf("%.2f", s.turnaround),
+			misses,
+		})
+	}
+
+	_, _ = fmt.Fprintln(w, msg(w, "Class metrics"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "Class", "Processes", "Wait", "Turnaround", "Missed"))
+	table.AppendBulk(rows)
+	table.Render()
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRealTimeSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{
+			ProcessID:     1,
+			ArrivalTime:   0,
+			BurstDuration: 5,
+		},
+		{
+			ProcessID:     2,
+			ArrivalTime:   1,
+			BurstDuration: 3,
+		},
+		{
+			ProcessID:     3,
+			ArrivalTime:   2,
+			BurstDuration: 2,
+			Priority:      2,
+			Class:         "realtime",
+			Deadline:      6,
+		},
+		{
+			ProcessID:     4,
+			ArrivalTime:   3,
+			BurstDuration: 2,
+			Priority:      1,
+			Class:         "realtime",
+			Deadline:      9,
+		},
+	}
+	tests := []struct {
+		name    string
+		rt      RealTimePolicy
+		wantOut string
+	}{
+		{
+			name:    "earliest deadline first",
+			rt:      RealTimeDeadline,
+			wantOut: loadFixture(t, "rt_edf_test.txt"),
+		},
+		{
+			name:    "fixed priority",
+			rt:      RealTimePriority,
+			wantOut: loadFixture(t, "rt_prio_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			RealTimeSchedule(&w, "Real-time plus best-effort", processes, tt.rt, 2)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("RealTimeSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// RMSchedule outputs a rate monotonic schedule of periodic tasks in a GANTT chart, a table of timing per job,
+// the schedulability of the task set and any missed deadlines given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes, where those with a Period are periodic tasks whose burst is the worst-case execution time
+// • the number of hyperperiods to simulate for tasks without a repeat count
+//
+// Each job must complete before its task's next release. Tasks with shorter periods have higher priority;
+// processes without a period run in the background.
+func RMSchedule(w io.Writer, title string, processes []Process, hyperperiods int64) {
+	outputResult(w, title, rateMonotonic(processes, hyperperiods))
+	outputSchedulability(w, processes)
+}
+
+// rateMonotonic runs the jobs of periodic tasks over hyperperiods, those of the shortest period first.
+func rateMonotonic(processes []Process, hyperperiods int64) Result {
+	return simulate(expandPeriodic(processes, hyperperiods), policy{pick: shortestPeriod, preemptive: true})
+}
+
+// shortestPeriod picks the ready job of the task with the shortest period, keeping the earliest on ties.
+func shortestPeriod(ready []*job, _ int64) int {
+	best := 0
+	for i := range ready {
+		if lessNonZero(ready[i].Period, ready[best].Period) {
+			best = i
+		}
+	}
+	return best
+}
+
+// expandPeriodic replaces every periodic task with its numbered jobs, each released one period after the last
+// with a deadline at the next release. Tasks without a repeat count release jobs for the given number of
+// hyperperiods. Jobs already released are kept as they are.
+func expandPeriodic(processes []Process, hyperperiods int64) []Process {
+	if hyperperiods < 1 {
+		hyperperiods = 1
+	}
+
+	var (
+		start   = int64(math.MaxInt64)
+		expired = hyperperiod(processes) * hyperperiods
+		jobs    = make([]Process, 0, len(processes))
+	)
+	for _, p := range processes {
+		if p.Period > 0 && p.Job == 0 && p.ArrivalTime < start {
+			start = p.ArrivalTime
+		}
+	}
+
+	for _, p := range processes {
+		if p.Period <= 0 || p.Job > 0 {
+			jobs = append(jobs, p)
+			continue
+		}
+		for k := int64(0); p.Repeat <= 0 || k < p.Repeat; k++ {
+			release := p.ArrivalTime + k*p.Period
+			if p.Repeat <= 0 && release >= start+expired {
+				break
+			}
+			j := p
+			j.ArrivalTime = release
+			j.Deadline = release + p.Period
+			j.Job = k + 1
+			jobs = append(jobs, j)
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].ArrivalTime < jobs[j].ArrivalTime
+	})
+	return jobs
+}
+
+// hyperperiod is the least common multiple of the periods of all periodic tasks.
+func hyperperiod(processes []Process) int64 {
+	h := int64(1)
+	for _, p := range processes {
+		if p.Period > 0 && p.Job <= 1 {
+			h = h / gcd(h, p.Period) * p.Period
+		}
+	}
+	return h
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// responseTimes is the worst-case response time of each periodic task under rate monotonic priorities, found by
+// response-time analysis assuming all tasks are released together. A task whose response time would exceed its
+// period is reported with math.MaxInt64.
+func responseTimes(tasks []Process) []int64 {
+	order := make([]int, len(tasks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return tasks[order[i]].Period < tasks[order[j]].Period
+	})
+
+	response := make([]int64, len(tasks))
+	for k, i := range order {
+		r := tasks[i].BurstDuration
+		for {
+			next := tasks[i].BurstDuration
+			for _, j := range order[:k] {
+				next += (r + tasks[j].Period - 1) / tasks[j].Period * tasks[j].BurstDuration
+			}
+			if next > tasks[i].Period {
+				r = math.MaxInt64
+				break
+			}
+			if next == r {
+				break
+			}
+			r = next
+		}
+		response[i] = r
+	}
+	return response
+}
+
+// outputSchedulability outputs the utilization of the periodic tasks against the Liu & Layland bound and the
+// worst-case response time of each task.
+func outputSchedulability(w io.Writer, processes []Process) {
+	var (
+		tasks       []Process
+		utilization float64
+	)
+	for _, p := range processes {
+		if p.Period > 0 && p.Job <= 1 {
+			tasks = append(tasks, p)
+			utilization += float64(p.BurstDuration) / float64(p.Period)
+		}
+	}
+	if len(tasks) == 0 {
+		return
+	}
+
+	var (
+		n          = float64(len(tasks))
+		bound      = n * (math.Pow(2, 1/n) - 1)
+		response   = responseTimes(tasks)
+		rows       = make([][]string, len(tasks))
+		verdict    string
+		allMeetRTA = true
+	)
+	for i, p := range tasks {
+		r, ok := fmt.Sprint(response[i]), "yes"
+		if response[i] == math.MaxInt64 {
+			r, ok = "-", "no"
+			allMeetRTA = false
+		}
+		rows[i] = []string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.Period),
+			fmt.Sprint(p.BurstDuration),
+			r,
+			msg(w, ok),
+		}
+	}
+
+	switch {
+	case utilization > 1:
+		verdict = "not schedulable, utilization exceeds 1"
+	case utilization <= bound:
+		verdict = "schedulable, within the Liu & Layland bound"
+	case allMeetRTA:
+		verdict = "schedulable by response-time analysis"
+	default:
+		verdict = "not schedulable by response-time analysis"
+	}
+
+	_, _ = fmt.Fprintln(w, msg(w, "Schedulability"))
+	_, _ = fmt.Fprintf(w, "%s: %.2f; %s: %.2f; %s\n", msg(w, "Utilization"), utilization, msg(w, "Liu & Layland bound"), bound,
+		msg(w, verdict))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "ID", "Period", "WCET", "Response", "Schedulable"))
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// periodicJobs reports whether any process is a job released by a periodic task.
+func periodicJobs(processes []Process) bool {
+	for _, p := range processes {
+		if p.Job > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// outputTasks outputs the response times and missed deadlines of the jobs of each periodic task, in the
+// order the tasks first release a job.
+func outputTasks(w io.Writer, r Result) {
+	type task struct {
+		period, jobs, total, worst int64
+		missed                     int
+	}
+	var (
+		ids   []int64
+		tasks = make(map[int64]*task)
+	)
+	for _, p := range r.Processes {
+		if p.Job == 0 {
+			continue
+		}
+		t, ok := tasks[p.ProcessID]
+		if !ok {
+			t = &task{period: p.Period}
+			tasks[p.ProcessID] = t
+			ids = append(ids, p.ProcessID)
+		}
+		t.jobs++
+		t.total += p.Turnaround
+		if p.Turnaround > t.worst {
+			t.worst = p.Turnaround
+		}
+		if p.Missed() {
+			t.missed++
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	rows := make([][]string, len(ids))
+	for i, id := range ids {
+		t := tasks[id]
+		rows[i] = []string{
+			fmt.Sprint(id),
+			fmt.Sprint(t.period),
+			fmt.Sprint(t.jobs),
+			fmt.Sprintf("%.2f", float64(t.total)/float64(t.jobs)),
+			fmt.Sprint(t.worst),
+			fmt.Sprint(t.missed),
+		}
+	}
+	_, _ = fmt.Fprintln(w, msg(w, "Periodic tasks"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "ID", "Period", "Jobs", "Average response", "Worst response", "Missed"))
+	table.AppendBulk(rows)
+	table.Render()
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestRMSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes    []Process
+		title        string
+		hyperperiods int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "schedulable above bound",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						BurstDuration: 1,
+						Period:        4,
+					},
+					{
+						ProcessID:     2,
+						BurstDuration: 2,
+						Period:        6,
+					},
+					{
+						ProcessID:     3,
+						BurstDuration: 3,
+						Period:        12,
+					},
+				},
+				title:        "Rate monotonic",
+				hyperperiods: 1,
+			},
+			wantOut: loadFixture(t, "rm_test.txt"),
+		},
+		{
+			name: "overloaded",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						BurstDuration: 2,
+						Period:        3,
+					},
+					{
+						ProcessID:     2,
+						BurstDuration: 2,
+						Period:        4,
+						Repeat:        2,
+					},
+				},
+				title:        "Rate monotonic",
+				hyperperiods: 1,
+			},
+			wantOut: loadFixture(t, "rm_overload_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			RMSchedule(&w, tt.args.title, tt.args.processes, tt.args.hyperperiods)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("RMSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_hyperperiod(t *testing.T) {
+	t.Parallel()
+	got := hyperperiod([]Process{{Period: 4}, {Period: 6}, {}, {Period: 10}})
+	if got != 60 {
+		t.Errorf("hyperperiod() = %v, want %v", got, 60)
+	}
+}
+
+func Test_expandPeriodic(t *testing.T) {
+	t.Parallel()
+	tasks := []Process{
+		{ProcessID: 1, BurstDuration: 1, Period: 2},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1, Period: 3, Repeat: 1},
+		{ProcessID: 3, BurstDuration: 2},
+	}
+	want := []Process{
+		{ProcessID: 1, BurstDuration: 1, Period: 2, Deadline: 2, Job: 1},
+		{ProcessID: 3, BurstDuration: 2},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1, Period: 3, Repeat: 1, Deadline: 4, Job: 1},
+		{ProcessID: 1, BurstDuration: 1, ArrivalTime: 2, Period: 2, Deadline: 4, Job: 2},
+		{ProcessID: 1, BurstDuration: 1, ArrivalTime: 4, Period: 2, Deadline: 6, Job: 3},
+	}
+	got := expandPeriodic(tasks, 1)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expandPeriodic() = %v, want %v", got, want)
+	}
+	if again := expandPeriodic(got, 1); !reflect.DeepEqual(again, want) {
+		t.Errorf("expandPeriodic() of released jobs = %v, want %v", again, want)
+	}
+}
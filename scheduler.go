@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+type (
+	// Scheduler is a user-defined scheduling policy run by the shared simulator.
+	// PickNext returns the index in ready of the process to run at time t. ready is in
+	// ready-queue order and, for a preemptive policy, starts with the running process, so
+	// returning 0 keeps it on the CPU.
+	Scheduler interface {
+		PickNext(ready []Process, t int64) int
+	}
+	// SchedulerHooks may be implemented by a Scheduler to follow the simulation, for
+	// example to track how much of each process has run.
+	SchedulerHooks interface {
+		// OnArrival is called when p joins the ready queue at time t.
+		OnArrival(p Process, t int64)
+		// OnRun is called for each tick p runs, starting at time t.
+		OnRun(p Process, t int64)
+		// OnComplete is called when p finishes, or is killed for overrunning a limit, at time t.
+		OnComplete(p Process, t int64)
+	}
+	// Dispatch is how the simulator consults a Scheduler.
+	Dispatch struct {
+		// Preemptive has PickNext consulted every tick rather than only when the CPU is free.
+		Preemptive bool
+		// Quantum, when positive, sends the running process to the back of the ready queue after that many ticks.
+		Quantum int64
+		// Cost is what each dispatch and preemption costs the CPU before the process picked runs.
+		Cost DecisionCost
+	}
+	// registration is a scheduler run by name: its title, how it schedules processes and, for those reporting
+	// more than the chart and tables, how it outputs the schedule with the rest.
+	registration struct {
+		title    string
+		run      func(processes []Process, params runParams) Result
+		schedule func(w io.Writer, title string, processes []Process, params runParams)
+		// noBursts marks a scheduler with no model of I/O bursts, which refuses processes that have them.
+		noBursts bool
+	}
+	// runParams are the settings of a scenario that the schedulers run by name take.
+	runParams struct {
+		quantum int64
+		cpus    int
+	}
+)
+
+// ErrUnknownPolicy is returned when no Scheduler is registered under a name.
+var ErrUnknownPolicy = errors.New("unknown policy")
+
+var schedulers = map[string]registration{}
+
+// RegisterScheduler makes a Scheduler available by name, typically from an init function.
+// factory is called for every run so that a policy's state starts afresh. Registering a name
+// twice panics.
+func RegisterScheduler(name string, dispatch Dispatch, factory func() Scheduler) {
+	register(name, registration{title: name, run: func(p []Process, _ runParams) Result {
+		return simulate(p, schedulerPolicy(factory(), dispatch))
+	}})
+}
+
+// register makes a scheduler, built in or a Scheduler, available by name.
+func register(name string, reg registration) {
+	if _, ok := schedulers[name]; ok {
+		panic(fmt.Sprintf("scheduler %q registered twice", name))
+	}
+	schedulers[name] = reg
+}
+
+// SchedulerNames returns the names of the schedulers, built in and registered, in sorted order.
+func SchedulerNames() []string {
+	names := make([]string, 0, len(schedulers))
+	for name := range schedulers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PolicySchedule outputs the schedule of processes by the scheduler registered under name, with the default
+// quantum and CPUs, in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the registered name of the policy
+func PolicySchedule(w io.Writer, title string, processes []Process, name string) error {
+	reg, err := lookupScheduler(name)
+	if err != nil {
+		return err
+	}
+	reg.output(w, title, processes, runParams{})
+	return nil
+}
+
+// accepts returns an error if the scheduler cannot run processes as they are given.
+func (reg registration) accepts(processes []Process) error {
+	if reg.noBursts && blocking(processes) {
+		return fmt.Errorf("%w: %s has no model of I/O bursts; schedule processes with bursts on the shared simulator", ErrInvalidArgs, reg.title)
+	}
+	return nil
+}
+
+// lookupScheduler returns the scheduler registered under name.
+func lookupScheduler(name string) (registration, error) {
+	reg, ok := schedulers[name]
+	if !ok {
+		return registration{}, fmt.Errorf("%w: %q (registered: %v)", ErrUnknownPolicy, name, SchedulerNames())
+	}
+	return reg, nil
+}
+
+// schedulerPolicy adapts a Scheduler to the simulator.
+func schedulerPolicy(s Scheduler, dispatch Dispatch) policy {
+	hooks, _ := s.(SchedulerHooks)
+	return policy{
+		pick: func(ready []*job, t int64) int {
+			procs := make([]Process, len(ready))
+			for i, j := range ready {
+				procs[i] = j.Process
+			}
+			k := s.PickNext(procs, t)
+			if k < 0 || k >= len(ready) {
+				return 0
+			}
+			return k
+		},
+		preemptive: dispatch.Preemptive,
+		quantum:    dispatch.Quantum,
+		overhead:   dispatch.Cost,
+		hooks:      hooks,
+	}
+}
+
+func init() {
+	RegisterScheduler("hrrn", Dispatch{}, func() Scheduler { return responseRatio{} })
+}
+
+// responseRatio is highest-response-ratio-next, registered as an example policy: it picks the
+// ready process with the greatest (wait + burst) / burst, so short jobs go first but a long
+// job's ratio grows as it waits until it cannot starve.
+type responseRatio struct{}
+
+func (responseRatio) PickNext(ready []Process, t int64) int {
+	best, bestRatio := 0, -1.0
+	for i, p := range ready {
+		if p.BurstDuration == 0 {
+			return i
+		}
+		ratio := float64(t-p.ArrivalTime+p.BurstDuration) / float64(p.BurstDuration)
+		if ratio > bestRatio {
+			best, bestRatio = i, ratio
+		}
+	}
+	return best
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPolicySchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		name      string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+		wantErr error
+	}{
+		{
+			name: "registered policy",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   2,
+						BurstDuration: 6,
+						Priority:      2,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   5,
+						BurstDuration: 2,
+						Priority:      1,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   1,
+						BurstDuration: 8,
+						Priority:      3,
+					},
+					{
+						ProcessID:     4,
+						ArrivalTime:   0,
+						BurstDuration: 3,
+						Priority:      4,
+					},
+					{
+						ProcessID:     5,
+						ArrivalTime:   4,
+						BurstDuration: 4,
+						Priority:      5,
+					},
+				},
+				title: "Highest response ratio next",
+				name:  "hrrn",
+			},
+			wantOut: loadFixture(t, "hrrn_test.txt"),
+		},
+		{
+			name: "unknown policy",
+			args: args{
+				processes: []Process{{ProcessID: 1, BurstDuration: 1}},
+				title:     "Unknown",
+				name:      "unknown",
+			},
+			wantErr: ErrUnknownPolicy,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := PolicySchedule(&w, tt.args.title, tt.args.processes, tt.args.name)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PolicySchedule() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("PolicySchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+// remainingTime is a shortest-remaining-time-first Scheduler that tracks progress through its hooks.
+type remainingTime map[int64]int64
+
+func (r remainingTime) PickNext(ready []Process, _ int64) int {
+	best := 0
+	for i, p := range ready {
+		if r[p.ProcessID] < r[ready[best].ProcessID] {
+			best = i
+		}
+	}
+	return best
+}
+
+func (r remainingTime) OnArrival(p Process, _ int64)  { r[p.ProcessID] = p.BurstDuration }
+func (r remainingTime) OnRun(p Process, _ int64)      { r[p.ProcessID]-- }
+func (r remainingTime) OnComplete(p Process, _ int64) { delete(r, p.ProcessID) }
+
+func Test_schedulerPolicy(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 9},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 5},
+	}
+	got := simulate(processes, schedulerPolicy(remainingTime{}, Dispatch{Preemptive: true}))
+	want := simulate(processes, policy{pick: shortestRemaining, preemptive: true})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("simulate() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxServeProcesses is the most processes a request to /schedule may post.
+	maxServeProcesses = 10_000
+	// serveTimeout is how long the schedules of a request to /schedule may take to simulate.
+	serveTimeout = 30 * time.Second
+)
+
+// listenAndServe runs a long-lived service on addr, simulating the schedules posted to /schedule and serving the
+// metrics of those simulated on /metrics for Prometheus to scrape.
+func listenAndServe(addr string) error {
+	var m Metrics
+	mux := http.NewServeMux()
+	mux.Handle("/schedule", scheduleHandler(&m))
+	mux.Handle("/metrics", &m)
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	return srv.ListenAndServe()
+}
+
+// scheduleHandler simulates the schedules posted to it, counting each in m. The body is a scheduling file,
+// in the format named by the format parameter or CSV, and the algo parameter names the schedulers to run
+// as -algo does, round-robin by default, with the quantum parameter or else the scenario's, and the scenario's CPUs. It answers
+// with a line of each schedule's metrics, as -quiet -json writes them. Workloads of more than maxServeProcesses
+// are refused, and requests whose schedules take longer than serveTimeout to simulate fail.
+func scheduleHandler(m *Metrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "POST a scheduling file", http.StatusMethodNotAllowed)
+			return
+		}
+		query := req.URL.Query()
+		names := query.Get("algo")
+		if names == "" {
+			names = "rr"
+		}
+		algorithms, err := parseAlgorithms(names)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		load, err := scenarioLoader(query.Get("format"), "")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		scenario, err := load(http.MaxBytesReader(w, req.Body, maxFetchSize))
+		if err == nil {
+			err = checkUnique(scenario.Processes)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(scenario.Processes) > maxServeProcesses {
+			http.Error(w, fmt.Sprintf("%v: %d processes is more than the %d a request may schedule", ErrInvalidArgs,
+				len(scenario.Processes), maxServeProcesses), http.StatusRequestEntityTooLarge)
+			return
+		}
+		params := runParams{quantum: scenario.quantum(), cpus: scenario.cpus()}
+		if q := query.Get("quantum"); q != "" {
+			if params.quantum, err = strconv.ParseInt(q, 10, 64); err != nil || params.quantum < 1 {
+				http.Error(w, fmt.Sprintf("%v: quantum %q is not a positive number of ticks", ErrInvalidArgs, q), http.StatusBadRequest)
+				return
+			}
+		}
+
+		for _, reg := range algorithms {
+			if err := reg.accepts(scenario.Processes); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), serveTimeout)
+		defer cancel()
+		type run struct {
+			name string
+			r    Result
+			took time.Duration
+		}
+		done := make(chan []run, 1)
+		go func() {
+			runs := make([]run, 0, len(algorithms))
+			for _, reg := range algorithms {
+				if ctx.Err() != nil {
+					// Nobody is waiting for the rest.
+					done <- nil
+					return
+				}
+				a := reg.algorithm(params)
+				start := time.Now()
+				r := a.Run(scenario.Processes)
+				runs = append(runs, run{name: a.Name, r: r, took: time.Since(start)})
+			}
+			done <- runs
+		}()
+		var runs []run
+		select {
+		case runs = <-done:
+		case <-ctx.Done():
+		}
+		if runs == nil {
+			http.Error(w, fmt.Sprintf("simulating the schedules: %v", ctx.Err()), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		out := WithQuiet(w, true)
+		for _, run := range runs {
+			m.Observe(run.name, run.r, run.took)
+			outputMetrics(out, run.name, run.r)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_scheduleHandler(t *testing.T) {
+	t.Parallel()
+	const workload = "1,3,0,1\n2,2,1,1\n"
+	var many strings.Builder
+	for i := 1; i <= maxServeProcesses+1; i++ {
+		fmt.Fprintf(&many, "%d,1,0,1\n", i)
+	}
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "round-robin by default",
+			method:     http.MethodPost,
+			target:     "/schedule",
+			body:       workload,
+			wantStatus: http.StatusOK,
+			wantBody: `{"schedule":"Round-robin","processes":2,"wait":1.5,"turnaround":4,"throughput":0.4,"makespan":5,"context_switches":2,"utilization":1,"idle":0,` +
+				`"jobs":[{"id":1,"wait":2,"turnaround":5,"completion":5},{"id":2,"wait":1,"turnaround":3,"completion":4}]}` + "\n",
+		},
+		{
+			name:       "algorithms and quantum",
+			method:     http.MethodPost,
+			target:     "/schedule?algo=fcfs,rr&quantum=1",
+			body:       workload,
+			wantStatus: http.StatusOK,
+			wantBody: `{"schedule":"First-come, first-serve","processes":2,"wait":1,"turnaround":3.5,"throughput":0.4,"makespan":5,"context_switches":1,"utilization":1,"idle":0,` +
+				`"jobs":[{"id":1,"wait":0,"turnaround":3,"completion":3},{"id":2,"wait":2,"turnaround":4,"completion":5}]}` + "\n" +
+				`{"schedule":"Round-robin","processes":2,"wait":1.5,"turnaround":4,"throughput":0.4,"makespan":5,"context_switches":4,"utilization":1,"idle":0,` +
+				`"jobs":[{"id":1,"wait":2,"turnaround":5,"completion":5},{"id":2,"wait":1,"turnaround":3,"completion":4}]}` + "\n",
+		},
+		{name: "not posted", method: http.MethodGet, target: "/schedule", wantStatus: http.StatusMethodNotAllowed},
+		{name: "unknown algorithm", method: http.MethodPost, target: "/schedule?algo=nope", body: workload, wantStatus: http.StatusBadRequest},
+		{name: "bad quantum", method: http.MethodPost, target: "/schedule?quantum=0", body: workload, wantStatus: http.StatusBadRequest},
+		{name: "bad workload", method: http.MethodPost, target: "/schedule", body: "1,x,0,1\n", wantStatus: http.StatusBadRequest},
+		{name: "too many processes", method: http.MethodPost, target: "/schedule", body: many.String(), wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var m Metrics
+			rec := httptest.NewRecorder()
+			scheduleHandler(&m).ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus == http.StatusOK && rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %v, want %v", rec.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_scheduleHandler_canceled(t *testing.T) {
+	t.Parallel()
+	var m Metrics
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/schedule", strings.NewReader("1,3,0,1\n")).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	scheduleHandler(&m).ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d: %s", rec.Code, http.StatusServiceUnavailable, rec.Body)
+	}
+	if metrics := m.String(); strings.Contains(metrics, "scheduler_runs_total{") {
+		t.Errorf("metrics = %v, want no runs counted", metrics)
+	}
+}
+
+func Test_scheduleHandler_metrics(t *testing.T) {
+	t.Parallel()
+	var m Metrics
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/schedule?algo=rr", strings.NewReader("1,3,0,1\n2,2,1,1\n"))
+		scheduleHandler(&m).ServeHTTP(httptest.NewRecorder(), req)
+	}
+	metrics := m.String()
+	for _, want := range []string{
+		`scheduler_runs_total{algorithm="Round-robin"} 2`,
+		`scheduler_processes_total{algorithm="Round-robin"} 4`,
+		`scheduler_average_wait_ticks{algorithm="Round-robin"} 1.5`,
+		`scheduler_simulation_seconds_count{algorithm="Round-robin"} 2`,
+	} {
+		if !strings.Contains(metrics, want) {
+			t.Errorf("metrics = %v, want a line %s", metrics, want)
+		}
+	}
+}
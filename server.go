@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type (
+	// AperiodicServer is a periodic task that services aperiodic processes, using up to Budget ticks of CPU
+	// every Period at the rate monotonic priority of that period.
+	AperiodicServer struct {
+		Kind   ServerKind
+		Budget int64
+		Period int64
+	}
+	// ServerKind is how an aperiodic server keeps its budget between requests.
+	ServerKind int
+)
+
+const (
+	// PollingServer polls for aperiodic work at the start of each period, losing its budget if there
+	// is none waiting or once it has served what was waiting.
+	PollingServer ServerKind = iota
+	// DeferrableServer keeps its budget for the whole period, serving aperiodic work whenever it arrives.
+	DeferrableServer
+)
+
+func (k ServerKind) String() string {
+	if k == DeferrableServer {
+		return "Deferrable server"
+	}
+	return "Polling server"
+}
+
+// serverUsage is how an aperiodic server's requests were served.
+type serverUsage struct {
+	served     int64 // ticks of aperiodic work run on the server's budget
+	background int64 // ticks of aperiodic work run while the CPU was otherwise idle
+}
+
+// AperiodicServerSchedule outputs a rate monotonic schedule of periodic tasks alongside an aperiodic server
+// in a GANTT chart, a table of timing per job and the response times of the aperiodic processes given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes, where those with a Period are periodic tasks and the rest are aperiodic requests
+// • the server that runs the aperiodic requests
+// • the number of hyperperiods to simulate for tasks without a repeat count
+//
+// Aperiodic requests are served first-come, first-serve. The server runs them while it has budget at the
+// priority of its period, winning ties with tasks of the same period, and any still waiting run in the
+// background whenever no periodic job is ready. The chart marks the slices run by the server with "server"
+// and those run in the background with "bg".
+func AperiodicServerSchedule(w io.Writer, title string, processes []Process, server AperiodicServer, hyperperiods int64) {
+	r, u := serve(expandPeriodic(processes, hyperperiods), server)
+	outputResult(w, title, r)
+	outputAperiodic(w, r, server, u)
+}
+
+func serve(processes []Process, server AperiodicServer) (Result, serverUsage) {
+	var (
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]int64, n)
+		completion = make([]int64, n)
+		byServer   = make([]int64, n)
+		gantt      = make([]TimeSlice, 0)
+		ready      = make([]int, 0, n) // released periodic jobs, in release order
+		queue      []int               // aperiodic requests, in arrival order
+		usage      serverUsage
+		budget     int64
+		period     = int64(-1) // the server period budget was last replenished for
+		next       int
+		done       int
+		timer      int64
+	)
+	for i, p := range processes {
+		remaining[i] = p.BurstDuration
+	}
+
+	for done < n {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			if i := order[next]; processes[i].Period > 0 {
+				ready = append(ready, i)
+			} else {
+				queue = append(queue, i)
+			}
+			next++
+		}
+		if server.Budget > 0 && server.Period > 0 {
+			if k := timer / server.Period; k != period {
+				period, budget = k, server.Budget
+				// A polling server finds no work unless a request had arrived when it polled.
+				if server.Kind == PollingServer && (len(queue) == 0 || processes[queue[0]].ArrivalTime > k*server.Period) {
+					budget = 0
+				}
+			}
+		}
+
+		best := -1
+		for k, i := range ready {
+			if best < 0 || processes[i].Period < processes[ready[best]].Period {
+				best = k
+			}
+		}
+
+		var (
+			i     int
+			label string
+		)
+		switch {
+		case budget > 0 && len(queue) > 0 && (best < 0 || server.Period <= processes[ready[best]].Period):
+			i, label = queue[0], "server"
+			budget--
+			usage.served++
+			byServer[i]++
+		case best >= 0:
+			i = ready[best]
+		case len(queue) > 0:
+			i, label = queue[0], "bg"
+			usage.background++
+		default:
+			// Nothing is ready until the next arrival.
+			timer = processes[order[next]].ArrivalTime
+			continue
+		}
+
+		gantt = appendSlice(gantt, TimeSlice{PID: processes[i].ProcessID, Start: timer, Stop: timer + 1, Queue: label})
+		remaining[i]--
+		timer++
+
+		if remaining[i] > 0 {
+			continue
+		}
+		completion[i] = timer
+		done++
+		if label == "" {
+			ready = append(ready[:best], ready[best+1:]...)
+			continue
+		}
+		queue = queue[1:]
+		if server.Kind == PollingServer && len(queue) == 0 {
+			// The server has served all it found waiting and gives up the rest of its budget.
+			budget = 0
+		}
+	}
+
+	r := newResult(processes, completion, gantt)
+	served := make([]string, n)
+	for i, p := range processes {
+		served[i] = "-"
+		if p.Period <= 0 {
+			served[i] = fmt.Sprint(byServer[i])
+		}
+	}
+	r.Columns = append(r.Columns, Column{Header: "Server", Values: served})
+	return r, usage
+}
+
+// outputAperiodic outputs the response time of each aperiodic request and how much of their work the
+// server ran.
+func outputAperiodic(w io.Writer, r Result, server AperiodicServer, u serverUsage) {
+	var (
+		rows  [][]string
+		total int64
+	)
+	for _, p := range r.Processes {
+		if p.Period > 0 {
+			continue
+		}
+		total += p.Turnaround
+		rows = append(rows, []string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.ArrivalTime),
+			fmt.Sprint(p.BurstDuration),
+			fmt.Sprint(p.Completion),
+			fmt.Sprint(p.Turnaround),
+		})
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, msg(w, "Aperiodic requests"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "ID", "Arrival", "Burst", "Exit", "Response"))
+	table.AppendBulk(rows)
+	table.SetFooter([]string{"", "", "", "", fmt.Sprintf("%s\n%.2f", msg(w, "Average"), float64(total)/float64(len(rows)))})
+	table.Render()
+	_, _ = fmt.Fprintf(w, "%s: budget %d every %d, %d ticks served, %d in background\n",
+		server.Kind, server.Budget, server.Period, u.served, u.background)
+}
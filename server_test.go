@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAperiodicServerSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes    []Process
+		title        string
+		server       AperiodicServer
+		hyperperiods int64
+	}
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Period: 4},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 5, BurstDuration: 1},
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "polling server",
+			args: args{
+				processes:    processes,
+				title:        "Polling server",
+				server:       AperiodicServer{Kind: PollingServer, Budget: 1, Period: 2},
+				hyperperiods: 2,
+			},
+			wantOut: loadFixture(t, "server_polling_test.txt"),
+		},
+		{
+			name: "deferrable server",
+			args: args{
+				processes:    processes,
+				title:        "Deferrable server",
+				server:       AperiodicServer{Kind: DeferrableServer, Budget: 1, Period: 2},
+				hyperperiods: 2,
+			},
+			wantOut: loadFixture(t, "server_deferrable_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			AperiodicServerSchedule(&w, tt.args.title, tt.args.processes, tt.args.server, tt.args.hyperperiods)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("AperiodicServerSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
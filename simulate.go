@@ -0,0 +1,521 @@
+package main
+
+import "fmt"
+
+type (
+	// job is the simulator's view of a process while it is being scheduled.
+	job struct {
+		Process
+		index        int // position of the process in the input slice
+		remaining    int64
+		priority     int64  // effective priority, which aging may improve over Priority
+		waited       int64  // total ticks spent in the ready queue
+		sinceAged    int64  // ticks waited since the last aging step or dispatch
+		phase        int    // index in Bursts of the current CPU burst
+		readyAt      int64  // when the job's I/O burst completes while it is blocked
+		changes      int    // PriorityChanges already applied
+		executed     int64  // ticks run in total
+		spawned      int    // Spawns already forked
+		locks        int    // Locks already acquired
+		waitsFor     string // resource the job is blocked on, if any
+		blockedAt    int64  // when the job blocked on waitsFor
+		lockWait     int64  // total ticks spent blocked on locks
+		own          int64  // effective priority before inheriting a higher one
+		boosted      bool   // whether priority was inherited from a job blocked on the job's locks
+		eligible     bool   // whether the job's dependencies have been met
+		queuedAt     int64  // when the job's dependencies were met and it began waiting for memory
+		suspension   int    // index in Suspensions of the next or current suspension
+		suspendedAt  int64  // when the current suspension began
+		suspendedFor int64  // total ticks spent suspended
+		inIO         int64  // total ticks spent queued for or using the I/O device
+		killed       bool   // whether the job was killed for overrunning a limit
+	}
+	// picker returns the index in ready of the job to run at time t.
+	picker func(ready []*job, t int64) int
+	// policy describes how simulate dispatches jobs.
+	policy struct {
+		pick picker
+		// preemptive has pick consulted every tick rather than only when the CPU is free.
+		preemptive bool
+		// quantum, when positive, sends the running job to the back of the ready queue after that many ticks.
+		quantum int64
+		aging   Aging
+		// delay, when positive, lets the CPU idle up to that many ticks before each dispatch for a job
+		// expected to arrive that pick prefers over every ready one, making the policy non-work-conserving.
+		delay int64
+		// hooks, when set, is told of every arrival, tick run and completion.
+		hooks SchedulerHooks
+		// inherit has a job holding a lock run at the priority of the highest priority job it blocks.
+		inherit bool
+		// memory, when positive, is the memory capacity processes must fit in before they are admitted.
+		memory int64
+		// excludeSuspended leaves the time processes spend suspended out of their Wait.
+		excludeSuspended bool
+		// excludeKilled leaves processes killed for overrunning a limit out of the averages.
+		excludeKilled bool
+		// overhead is what each dispatch and preemption costs the CPU before the chosen job runs.
+		overhead DecisionCost
+	}
+)
+
+// simulate runs processes one tick at a time, using the policy's picker to choose among the ready jobs.
+// When preemptive, pick is consulted every tick with the running job first in ready, so a
+// picker that keeps the lowest index on ties never preempts needlessly; a preempted job
+// goes to the back of the ready queue. Otherwise the running job keeps the CPU until it completes
+// or its quantum expires, when it rejoins the back of the ready queue behind any new arrivals.
+//
+// A process with Bursts blocks after each CPU burst but the last, queueing first-come, first-serve for a
+// single I/O device, and rejoins the back of the ready queue once its I/O burst is served.
+//
+// A process that DependsOn others is held out of the ready queue, even after it arrives, until they
+// have all completed. loadProcesses rejects dependency cycles; any such processes are left unfinished.
+//
+// With a memory capacity, a process is also held in a job queue until its Memory fits in what completed
+// processes have left free, the first that fit being admitted in arrival order. One needing more than the
+// whole capacity is admitted once memory is empty. Time spent queued for memory is not counted in Wait.
+//
+// A ready or running process is taken off the CPU for each of its Suspensions, rejoining the back of the
+// ready queue when it is resumed.
+//
+// A process is killed once it has run for its MaxCPU ticks with burst left, or at the start of the tick
+// MaxTurnaround ticks after its arrival wherever it is waiting, releasing its locks and memory.
+//
+// PriorityChanges take effect at the start of their tick, replacing any priority earned by aging.
+//
+// A process with Locks takes each resource once it has run for the lock's At ticks, blocking out of the
+// ready queue while another job holds it. A released lock is handed to its highest priority waiter, which
+// rejoins the back of the ready queue.
+//
+// A process with Spawns forks each child once it has run for the child's After ticks; the children
+// follow the input processes in the Result.
+func simulate(processes []Process, pol policy) Result {
+	order := arrivalOrder(processes)
+	processes, children := withChildren(processes)
+	var (
+		n          = len(processes)
+		jobs       = make([]job, n)
+		completion = make([]int64, n)
+		gantt      = make([]TimeSlice, 0)
+		ready      = make([]*job, 0, n)
+		blocked    []*job // jobs queued for or using the I/O device, in the order they will finish
+		deviceFree int64  // when the I/O device finishes its queue
+		deviceWait = make([]int64, n)
+		held       []*job // arrived jobs waiting for their dependencies, in arrival order
+		released   = make([]int64, n)
+		finished   = make(map[int64]bool) // completed process IDs
+		running    *job
+		ran        int64 // ticks the running job has run since it was dispatched
+		next       int
+		done       int
+		timer      int64
+		slack      = pol.delay // ticks the CPU may still be held idle before the next dispatch
+		resources  = newLockTable(pol.inherit)
+		free       = pol.memory // memory not used by admitted processes
+		admission  = make([]int64, n)
+		suspended  []*job // jobs suspended by an operator, in the order they were suspended
+		limits     = limited(processes)
+	)
+	// kill ends j at the current time, returning the jobs waiting on its locks that may run again.
+	// A job still held for its dependencies or memory has no memory to free.
+	kill := func(j *job, admitted bool) []*job {
+		j.killed = true
+		completion[j.index] = timer
+		if admitted {
+			free += j.Memory
+		}
+		if pol.hooks != nil {
+			pol.hooks.OnComplete(j.Process, timer)
+		}
+		done++
+		return resources.release(j, timer, true)
+	}
+
+	for i, p := range processes {
+		jobs[i] = job{Process: p, index: i, remaining: p.BurstDuration, priority: p.Priority}
+		if len(p.Bursts) > 0 {
+			jobs[i].remaining = p.Bursts[0]
+		}
+	}
+
+	for done < n {
+		for i := range jobs {
+			j := &jobs[i]
+			for j.changes < len(j.PriorityChanges) && j.PriorityChanges[j.changes].At <= timer {
+				if j.boosted {
+					j.own = j.PriorityChanges[j.changes].Priority
+				} else {
+					j.priority = j.PriorityChanges[j.changes].Priority
+				}
+				j.changes++
+			}
+		}
+		for next < len(order) && processes[order[next]].ArrivalTime <= timer {
+			held = append(held, &jobs[order[next]])
+			next++
+		}
+		waiting := held[:0]
+		for _, j := range held {
+			if !dependenciesMet(j.Process, finished) {
+				waiting = append(waiting, j)
+				continue
+			}
+			if !j.eligible {
+				j.eligible, j.queuedAt = true, timer
+			}
+			if pol.memory > 0 {
+				if j.Memory > free && free < pol.memory {
+					waiting = append(waiting, j)
+					continue
+				}
+				free -= j.Memory
+				admission[j.index] = timer - j.queuedAt
+			}
+			ready = append(ready, j)
+			released[j.index] = timer
+			if pol.hooks != nil {
+				pol.hooks.OnArrival(j.Process, timer)
+			}
+		}
+		held = waiting
+		for len(blocked) > 0 && blocked[0].readyAt <= timer {
+			ready = append(ready, blocked[0])
+			blocked = blocked[1:]
+		}
+		stillSuspended := suspended[:0]
+		for _, j := range suspended {
+			if j.Suspensions[j.suspension].Until > timer {
+				stillSuspended = append(stillSuspended, j)
+				continue
+			}
+			j.suspendedFor += timer - j.suspendedAt
+			j.suspension++
+			ready = append(ready, j)
+		}
+		suspended = stillSuspended
+		if running != nil && running.suspend(timer) {
+			suspended = append(suspended, running)
+			running = nil
+		}
+		runnable := ready[:0]
+		for _, j := range ready {
+			if j.suspend(timer) {
+				suspended = append(suspended, j)
+			} else {
+				runnable = append(runnable, j)
+			}
+		}
+		ready = runnable
+		if limits {
+			var woken []*job
+			if running != nil && running.expired(timer) {
+				woken = append(woken, kill(running, true)...)
+				running = nil
+			}
+			for _, j := range resources.expire(timer) {
+				woken = append(woken, kill(j, true)...)
+			}
+			expire := func(queue []*job, admitted bool, before func(j *job)) []*job {
+				kept := queue[:0]
+				for _, j := range queue {
+					if !j.expired(timer) {
+						kept = append(kept, j)
+						continue
+					}
+					if before != nil {
+						before(j)
+					}
+					woken = append(woken, kill(j, admitted)...)
+				}
+				return kept
+			}
+			suspended = expire(suspended, true, func(j *job) { j.suspendedFor += timer - j.suspendedAt })
+			blocked = expire(blocked, true, func(j *job) {
+				if j.readyAt > timer {
+					j.inIO -= j.readyAt - timer
+				}
+			})
+			held = expire(held, false, nil)
+			ready = append(expire(ready, true, nil), woken...)
+		}
+
+		if running != nil && pol.quantum > 0 && ran >= pol.quantum {
+			ready = append(ready, running)
+			running = nil
+		}
+
+		if running != nil && pol.preemptive {
+			candidates := append([]*job{running}, ready...)
+			if k := pol.pick(candidates, timer); k != 0 {
+				ready = append(ready[:k-1], ready[k:]...)
+				ready = append(ready, running)
+				running = candidates[k]
+				running.sinceAged = 0
+				ran = 0
+				if cost := pol.overhead.of(len(candidates)); cost > 0 {
+					gantt, timer = chargeOverhead(gantt, ready, pol.aging, timer, cost)
+					continue
+				}
+			}
+		}
+
+		if running == nil {
+			if len(ready) == 0 {
+				// CPU is idle until the next arrival, I/O completion or resumption.
+				wake := int64(-1)
+				earliest := func(t int64) {
+					if wake < 0 || t < wake {
+						wake = t
+					}
+				}
+				if next < len(order) {
+					earliest(processes[order[next]].ArrivalTime)
+				}
+				if len(blocked) > 0 {
+					earliest(blocked[0].readyAt)
+				}
+				for _, j := range suspended {
+					earliest(j.Suspensions[j.suspension].Until)
+				}
+				if limits {
+					for i := range jobs {
+						j := &jobs[i]
+						if t := j.ArrivalTime + j.MaxTurnaround; j.MaxTurnaround > 0 && !j.killed && completion[i] == 0 && t > timer {
+							earliest(t)
+						}
+					}
+				}
+				if wake < 0 {
+					// Only processes in a dependency cycle or deadlocked on locks are left.
+					done = n
+				} else {
+					timer = wake
+				}
+				continue
+			}
+			if until := hold(pol.pick, ready, expected(jobs, processes, order[next:], timer+slack), timer); until > timer {
+				gantt = append(gantt, TimeSlice{PID: heldPID, Start: timer, Stop: until})
+				slack -= until - timer
+				timer = until
+				continue
+			}
+			k := pol.pick(ready, timer)
+			running = ready[k]
+			running.sinceAged = 0
+			ran = 0
+			slack = pol.delay
+			cost := pol.overhead.of(len(ready))
+			ready = append(ready[:k], ready[k+1:]...)
+			if cost > 0 {
+				gantt, timer = chargeOverhead(gantt, ready, pol.aging, timer, cost)
+				continue
+			}
+		}
+
+		if !resources.acquire(running, timer) {
+			// The job blocks on a lock, so another is dispatched in its place.
+			running = nil
+			continue
+		}
+
+		if running.remaining > 0 {
+			gantt = appendGantt(gantt, running.Process, timer)
+			if pol.hooks != nil {
+				pol.hooks.OnRun(running.Process, timer)
+			}
+			running.remaining--
+			running.executed++
+			ran++
+			timer++
+			gantt = setStop(gantt, timer)
+			for running.spawned < len(running.Spawns) && running.Spawns[running.spawned].After <= running.executed {
+				c := &jobs[children[running.index][running.spawned]]
+				c.ArrivalTime = timer
+				if c.Priority == 0 {
+					c.Priority = running.priority
+				}
+				c.priority = c.Priority
+				processes[c.index] = c.Process
+				held = append(held, c)
+				running.spawned++
+			}
+			ready = append(ready, resources.release(running, timer, false)...)
+			for _, j := range ready {
+				j.waited++
+				pol.aging.age(j)
+			}
+		}
+
+		if running.overran() {
+			ready = append(ready, kill(running, true)...)
+			running = nil
+		}
+
+		if running != nil && running.remaining <= 0 && running.phase+1 < len(running.Bursts) {
+			// The job blocks on I/O.
+			start := timer
+			if deviceFree > start {
+				start = deviceFree
+			}
+			deviceWait[running.index] += start - timer
+			deviceFree = start + running.Bursts[running.phase+1]
+			running.inIO += deviceFree - timer
+			running.readyAt = deviceFree
+			running.phase += 2
+			running.remaining = running.Bursts[running.phase]
+			blocked = append(blocked, running)
+			running = nil
+		}
+
+		if running != nil && running.remaining <= 0 {
+			completion[running.index] = timer
+			finished[running.ProcessID] = true
+			ready = append(ready, resources.release(running, timer, true)...)
+			free += running.Memory
+			if pol.hooks != nil {
+				pol.hooks.OnComplete(running.Process, timer)
+			}
+			done++
+			running = nil
+		}
+	}
+
+	r := newResult(processes, completion, gantt)
+	if pol.aging.Interval > 0 || reprioritized(processes) {
+		effective := make([]string, n)
+		for i := range jobs {
+			effective[i] = fmt.Sprint(jobs[i].priority)
+		}
+		r.Columns = append(r.Columns, Column{Header: "Effective", Values: effective})
+	}
+	if blocking(processes) {
+		io, queued := make([]string, n), make([]string, n)
+		for i, p := range processes {
+			// Wait is time spent in the ready queue, not doing I/O or queued for the device.
+			r.Processes[i].Wait -= p.IOTime() + deviceWait[i]
+			io[i], queued[i] = fmt.Sprint(p.IOTime()), fmt.Sprint(deviceWait[i])
+		}
+		r.Columns = append(r.Columns, Column{Header: "I/O", Values: io}, Column{Header: "Device wait", Values: queued})
+	}
+	if len(processes) > len(order) {
+		parents := make([]string, n)
+		for i, cs := range children {
+			for _, c := range cs {
+				parents[c] = fmt.Sprint(processes[i].ProcessID)
+			}
+		}
+		r.Columns = append(r.Columns, Column{Header: "Parent", Values: parents})
+	}
+	if locking(processes) {
+		waits := make([]string, n)
+		for i := range jobs {
+			r.Processes[i].Blocked = jobs[i].lockWait
+			waits[i] = fmt.Sprint(jobs[i].lockWait)
+		}
+		r.Columns = append(r.Columns, Column{Header: "Blocked", Values: waits})
+	}
+	if pol.memory > 0 {
+		memory, delays := make([]string, n), make([]string, n)
+		for i, p := range processes {
+			r.Processes[i].Wait -= admission[i]
+			r.Processes[i].Admission = admission[i]
+			memory[i], delays[i] = fmt.Sprint(p.Memory), fmt.Sprint(admission[i])
+		}
+		r.Columns = append(r.Columns, Column{Header: "Memory", Values: memory}, Column{Header: "Admission delay", Values: delays})
+	}
+	if suspending(processes) {
+		times := make([]string, n)
+		for i := range jobs {
+			if pol.excludeSuspended {
+				r.Processes[i].Wait -= jobs[i].suspendedFor
+			}
+			times[i] = fmt.Sprint(jobs[i].suspendedFor)
+		}
+		r.Columns = append(r.Columns, Column{Header: "Suspended", Values: times})
+	}
+	if dependent(processes) {
+		deps, at := make([]string, n), make([]string, n)
+		for i, p := range processes {
+			deps[i], at[i] = joinIDs(p.DependsOn, ", "), fmt.Sprint(released[i])
+		}
+		r.Columns = append(r.Columns, Column{Header: "Depends on", Values: deps}, Column{Header: "Released", Values: at})
+	}
+	if limits {
+		killed := make([]string, n)
+		for i := range jobs {
+			if j := &jobs[i]; j.killed {
+				killed[i] = j.limit()
+				p := &r.Processes[i]
+				// Only the time the process spent waiting before it was killed counts.
+				p.Killed = true
+				p.Wait = p.Turnaround - j.executed - j.inIO - admission[i]
+				if pol.excludeSuspended {
+					p.Wait -= j.suspendedFor
+				}
+			}
+		}
+		r.ExcludeKilled = pol.excludeKilled
+		r.Columns = append(r.Columns, Column{Header: "Killed", Values: killed})
+	}
+	return r
+}
+
+// reprioritized reports whether any process has scheduled priority changes.
+func reprioritized(processes []Process) bool {
+	for _, p := range processes {
+		if len(p.PriorityChanges) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// blocking reports whether any process has I/O bursts.
+func blocking(processes []Process) bool {
+	for _, p := range processes {
+		if len(p.Bursts) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// expected returns the jobs, taken in arrival order, that arrive by time by.
+func expected(jobs []job, processes []Process, order []int, by int64) []*job {
+	var future []*job
+	for _, i := range order {
+		if processes[i].ArrivalTime > by {
+			break
+		}
+		future = append(future, &jobs[i])
+	}
+	return future
+}
+
+// hold returns the arrival time of the future job that pick prefers over all of ready, or t when
+// a ready job should be dispatched now. Ready jobs come first, so ties never hold the CPU.
+func hold(pick picker, ready, future []*job, t int64) int64 {
+	if len(future) == 0 {
+		return t
+	}
+	candidates := append(append([]*job{}, ready...), future...)
+	if k := pick(candidates, t); k >= len(ready) {
+		return candidates[k].ArrivalTime
+	}
+	return t
+}
+
+// firstReady picks the job that has been ready the longest.
+func firstReady([]*job, int64) int {
+	return 0
+}
+
+// shortestRemaining picks the ready job with the least remaining burst, keeping the earliest on ties.
+func shortestRemaining(ready []*job, _ int64) int {
+	best := 0
+	for i := range ready {
+		if ready[i].remaining < ready[best].remaining {
+			best = i
+		}
+	}
+	return best
+}
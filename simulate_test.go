@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_simulateIO(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{
+			ProcessID:     1,
+			ArrivalTime:   0,
+			BurstDuration: 4,
+			Bursts:        []int64{2, 4, 2},
+		},
+		{
+			ProcessID:     2,
+			ArrivalTime:   0,
+			BurstDuration: 4,
+			Bursts:        []int64{3, 2, 1},
+		},
+		{
+			ProcessID:     3,
+			ArrivalTime:   1,
+			BurstDuration: 2,
+		},
+	}
+	tests := []struct {
+		name    string
+		title   string
+		pol     policy
+		wantOut string
+	}{
+		{
+			name:    "device queue",
+			title:   "First-come, first-serve with I/O",
+			pol:     policy{pick: firstReady},
+			wantOut: loadFixture(t, "io_fcfs_test.txt"),
+		},
+		{
+			name:    "return from I/O without preempting a shorter burst",
+			title:   "Shortest-remaining-time-first with I/O",
+			pol:     policy{pick: shortestRemaining, preemptive: true},
+			wantOut: loadFixture(t, "io_srtf_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputResult(&w, tt.title, simulate(processes, tt.pol))
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("simulate() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_ioOffSimulator(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Bursts: []int64{2, 4, 2}},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+	}
+	tests := []struct {
+		name      string
+		r         Result
+		wantWaits []int64
+	}{
+		{
+			name:      "shortest-job-first blocks for the I/O burst",
+			r:         eventDriven(processes, func(_ Process, remaining int64) int64 { return remaining }),
+			wantWaits: []int64{0, 1},
+		},
+		{
+			name:      "round-robin blocks for the I/O burst",
+			r:         roundRobin(processes, 2),
+			wantWaits: []int64{0, 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for i, p := range tt.r.Processes {
+				if p.Wait != tt.wantWaits[i] {
+					t.Errorf("P%d wait = %d, want %d", p.ProcessID, p.Wait, tt.wantWaits[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_simulateDependencies(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{
+			ProcessID:     1,
+			ArrivalTime:   0,
+			BurstDuration: 3,
+		},
+		{
+			ProcessID:     2,
+			ArrivalTime:   0,
+			BurstDuration: 2,
+			DependsOn:     []int64{3},
+		},
+		{
+			ProcessID:     3,
+			ArrivalTime:   1,
+			BurstDuration: 2,
+		},
+		{
+			ProcessID:     4,
+			ArrivalTime:   0,
+			BurstDuration: 1,
+			DependsOn:     []int64{1, 2},
+		},
+	}
+	var w bytes.Buffer
+	outputResult(&w, "First-come, first-serve with dependencies", simulate(processes, policy{pick: firstReady}))
+	if got, want := w.String(), loadFixture(t, "deps_test.txt"); got != want {
+		t.Errorf("simulate() = %v, want %v", got, want)
+	}
+}
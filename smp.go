@@ -0,0 +1,534 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+type (
+	// SMPParams configures symmetric multiprocessing.
+	SMPParams struct {
+		// CPUs is the number of processors.
+		CPUs int
+		// Quantum is the round-robin time quantum.
+		Quantum int64
+		// Speeds scales how much of a burst each CPU completes per tick, so a burst of 10 takes 20 ticks at
+		// 0.5; CPUs without a speed run at 1.
+		Speeds []float64
+		// Balance is how ready processes are shared between the CPUs.
+		Balance LoadBalance
+		// RebalanceInterval is how often PeriodicRebalance evens out the queues; zero is every 4 ticks.
+		RebalanceInterval int64
+		// Levels are the DVFS frequency levels of each CPU, replacing Speeds, with Governor choosing among
+		// them every tick.
+		Levels   [][]FrequencyLevel
+		Governor Governor
+		// IdlePower is the power drawn by a CPU with nothing to run.
+		IdlePower float64
+		// Thermal, when its Threshold is set, slows CPUs that stay busy for too long.
+		Thermal Thermal
+		// NodeSize groups the CPUs into NUMA nodes of this many consecutive CPUs; zero puts them all on
+		// one node.
+		NodeSize int
+		// MigrationCost is how many ticks a process makes no progress after resuming on a CPU of a
+		// different node, while it refills its caches from local memory.
+		MigrationCost int64
+		// SMTSlowdown, when positive, pairs consecutive CPUs as the two hardware threads of a core, each
+		// running this fraction slower while its sibling is busy too.
+		SMTSlowdown float64
+	}
+	// LoadBalance is a strategy for sharing ready processes between CPUs.
+	LoadBalance int
+	// smpUsage is how much CPU time a multi-CPU schedule used.
+	smpUsage struct {
+		busy       int64 // CPU ticks running a process
+		makespan   int64 // ticks from the first arrival to the last completion
+		perCPU     []int64
+		migrations int64   // times a process resumed on a different CPU
+		energy     float64 // drawn by CPUs while running processes
+		idleEnergy float64 // drawn by idle CPUs from the first arrival to the last completion
+		throttled  []int64 // busy ticks each CPU spent throttled
+		crossNode  int64   // migrations between NUMA nodes
+		overhead   int64   // ticks spent paying the cost of cross-node migrations
+		contended  int64   // busy ticks a hardware thread shared its core with a busy sibling
+		lost       float64 // work not done because of sibling contention
+	}
+)
+
+const (
+	// GlobalQueue has every CPU take processes from one shared ready queue.
+	GlobalQueue LoadBalance = iota
+	// WorkStealing gives each CPU its own queue; a CPU whose queue is empty takes a process from the longest.
+	WorkStealing
+	// PeriodicRebalance gives each CPU its own queue and evens out their lengths every RebalanceInterval ticks.
+	PeriodicRebalance
+)
+
+var loadBalanceNames = []string{"global queue", "work stealing", "periodic rebalance"}
+
+func (b LoadBalance) String() string {
+	if b < 0 || int(b) >= len(loadBalanceNames) {
+		return fmt.Sprintf("LoadBalance(%d)", int(b))
+	}
+	return loadBalanceNames[b]
+}
+
+// DefaultSMPParams are two CPUs of equal speed sharing the default quantum.
+var DefaultSMPParams = SMPParams{CPUs: 2, Quantum: defaultQuantum}
+
+// SMPSchedule outputs a symmetric multiprocessing round-robin schedule of processes on several CPUs in a
+// GANTT chart per CPU and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the CPUs and quantum
+//
+// Under GlobalQueue all CPUs share one ready queue. Whenever a CPU is free it takes the first process in
+// the queue whose Affinity allows it, free CPUs being filled in order, so a process may run on a different
+// CPU after each quantum. With per-CPU queues a new process joins the least loaded queue it may run from
+// and stays there unless balancing moves it. Each time a process resumes on a different CPU counts as a
+// migration. When any process has an affinity, CPU utilization is compared with the same processes run
+// without affinity.
+//
+// On CPUs of different speeds a process's wait is the time it spent not running, and the CPU time it took
+// to complete its burst is shown alongside it. With frequency Levels, the energy used by each process and
+// in total is reported too, and with a Thermal model, how long each CPU was throttled. On NUMA nodes the
+// time lost to cross-node migrations is reported and compared across the load balancing strategies. With
+// SMT, free hardware threads on idle cores are filled before those whose sibling is busy, and the work lost
+// to contention between siblings is reported.
+func SMPSchedule(w io.Writer, title string, processes []Process, params SMPParams) {
+	r, usage := smp(processes, params, true)
+	outputResult(w, title, r)
+	if len(processes) == 0 {
+		return
+	}
+	params = params.normalize()
+	if pinned(processes) {
+		free, freeUsage := smp(processes, params, false)
+		outputAffinityCost(w, usage, free, freeUsage)
+	}
+	outputMigrations(w, usage)
+	if params.scaled() {
+		outputEnergy(w, usage, len(processes))
+	} else if params.heterogeneous() {
+		outputSpeeds(w, usage, params)
+	}
+	if params.Thermal.throttles() {
+		outputThrottling(w, usage)
+	}
+	if params.numa() {
+		outputMigrationCost(w, processes, params, usage)
+	}
+	if params.smt() {
+		outputContention(w, usage)
+	}
+}
+
+// normalize fills in defaults for unset parameters.
+func (p SMPParams) normalize() SMPParams {
+	if p.CPUs < 1 {
+		p.CPUs = 1
+	}
+	if p.Quantum < 1 {
+		p.Quantum = defaultQuantum
+	}
+	if p.RebalanceInterval < 1 {
+		p.RebalanceInterval = 4
+	}
+	speeds := make([]float64, p.CPUs)
+	for c := range speeds {
+		speeds[c] = 1
+		if c < len(p.Speeds) && p.Speeds[c] > 0 {
+			speeds[c] = p.Speeds[c]
+		}
+	}
+	p.Speeds = speeds
+	levels := make([][]FrequencyLevel, len(p.Levels))
+	for c, ls := range p.Levels {
+		for _, l := range ls {
+			if l.Speed > 0 {
+				levels[c] = append(levels[c], l)
+			}
+		}
+	}
+	p.Levels = levels
+	p.Thermal = p.Thermal.normalize()
+	if p.SMTSlowdown >= 1 {
+		// A contended thread must still make progress.
+		p.SMTSlowdown = 0.9
+	}
+	return p
+}
+
+// varied reports whether the speed of a CPU depends on which CPU it is or when it runs.
+func (p SMPParams) varied() bool {
+	return p.heterogeneous() || p.scaled() || p.Thermal.throttles() || p.smt()
+}
+
+// heterogeneous reports whether the normalized CPUs run at different speeds.
+func (p SMPParams) heterogeneous() bool {
+	for _, s := range p.Speeds {
+		if s != p.Speeds[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// smpEpsilon absorbs rounding when fractional speeds complete a burst.
+const smpEpsilon = 1e-9
+
+func smp(processes []Process, params SMPParams, affinity bool) (Result, smpUsage) {
+	params = params.normalize()
+	var (
+		cpus       = params.CPUs
+		quantum    = params.Quantum
+		n          = len(processes)
+		order      = arrivalOrder(processes)
+		remaining  = make([]float64, n)
+		cpuTime    = make([]int64, n)
+		migrations = make([]int64, n)
+		energy     = make([]float64, n)
+		lastCPU    = make([]int, n)
+		completion = make([]int64, n)
+		lanes      = make([][]TimeSlice, cpus)
+		queues     = make([][]int, 1)  // the shared ready queue, or one per CPU
+		running    = make([]int, cpus) // process on each CPU, or -1
+		ran        = make([]int64, cpus)
+		usage      = smpUsage{perCPU: make([]int64, cpus), throttled: make([]int64, cpus)}
+		heat       = make([]int64, cpus)
+		penalty    = make([]int64, cpus) // ticks the process on each CPU has still to pay for migrating
+		overhead   = make([]int64, n)
+		next       int
+		done       int
+		timer      int64
+	)
+	if params.Balance != GlobalQueue {
+		queues = make([][]int, cpus)
+	}
+	for c := range running {
+		running[c] = -1
+	}
+	for i := range processes {
+		remaining[i] = float64(processes[i].BurstDuration)
+		lastCPU[i] = -1
+	}
+
+	may := func(i, c int) bool {
+		return !affinity || allowed(processes[i], c, cpus)
+	}
+	queueOf := func(c int) int {
+		if len(queues) == 1 {
+			return 0
+		}
+		return c
+	}
+	load := func(c int) int {
+		if running[c] >= 0 {
+			return len(queues[c]) + 1
+		}
+		return len(queues[c])
+	}
+	// take removes and returns the first process in queue q that may run on CPU c, or -1.
+	take := func(q, c int) int {
+		for k, i := range queues[q] {
+			if may(i, c) {
+				queues[q] = append(queues[q][:k], queues[q][k+1:]...)
+				return i
+			}
+		}
+		return -1
+	}
+
+	// dispatch gives free CPU c the next process it may run, if any.
+	dispatch := func(c int) {
+		running[c] = take(queueOf(c), c)
+		if running[c] < 0 && params.Balance == WorkStealing {
+			// Steal from the longest other queue holding a process that may run here.
+			for _, q := range longestFirst(queues) {
+				if q == c {
+					continue
+				}
+				if running[c] = take(q, c); running[c] >= 0 {
+					break
+				}
+			}
+		}
+		if i := running[c]; i >= 0 {
+			penalty[c] = 0
+			if lastCPU[i] >= 0 && lastCPU[i] != c {
+				migrations[i]++
+				if params.numa() && params.node(lastCPU[i]) != params.node(c) {
+					usage.crossNode++
+					penalty[c] = params.MigrationCost
+				}
+			}
+			lastCPU[i], ran[c] = c, 0
+		}
+	}
+
+	for done < n {
+		for next < n && processes[order[next]].ArrivalTime <= timer {
+			i := order[next]
+			next++
+			if remaining[i] <= smpEpsilon {
+				completion[i] = timer
+				done++
+				continue
+			}
+			// New processes join the least loaded queue they may run from.
+			q := 0
+			if len(queues) > 1 {
+				q = -1
+				for c := range queues {
+					if may(i, c) && (q < 0 || load(c) < load(q)) {
+						q = c
+					}
+				}
+			}
+			queues[q] = append(queues[q], i)
+		}
+
+		for c, i := range running {
+			if i >= 0 && ran[c] >= quantum {
+				queues[queueOf(c)] = append(queues[queueOf(c)], i)
+				running[c] = -1
+			}
+		}
+
+		if params.Balance == PeriodicRebalance && timer > 0 && timer%params.RebalanceInterval == 0 {
+			rebalance(queues, may)
+		}
+
+		if params.smt() {
+			for c := range running {
+				if running[c] < 0 && !params.siblingBusy(running, c) {
+					dispatch(c)
+				}
+			}
+		}
+		idle := true
+		for c := range running {
+			if running[c] < 0 {
+				dispatch(c)
+			}
+			if running[c] >= 0 {
+				idle = false
+			}
+		}
+
+		if idle {
+			if next == n {
+				break
+			}
+			// CPUs are idle until the next arrival.
+			gap := processes[order[next]].ArrivalTime - timer
+			if next > 0 {
+				usage.idleEnergy += float64(gap) * float64(cpus) * params.IdlePower
+			}
+			for c := range heat {
+				heat[c] = params.Thermal.heat(heat[c], false, gap)
+			}
+			timer += gap
+			continue
+		}
+
+		for c, i := range running {
+			if i < 0 {
+				usage.idleEnergy += params.IdlePower
+				continue
+			}
+			speed := params.Speeds[c]
+			if params.scaled() {
+				l := params.level(c, len(queues[queueOf(c)]) > 0)
+				speed = l.Speed
+				energy[i] += l.Power
+				usage.energy += l.Power
+			}
+			if params.Thermal.throttles() && heat[c] >= params.Thermal.Threshold {
+				speed *= params.Thermal.Throttle
+				usage.throttled[c]++
+			}
+			if params.smt() && params.siblingBusy(running, c) {
+				usage.contended++
+				usage.lost += speed * params.SMTSlowdown
+				speed *= 1 - params.SMTSlowdown
+			}
+			lanes[c] = appendSlice(lanes[c], TimeSlice{PID: processes[i].ProcessID, Start: timer, Stop: timer + 1, CPU: c})
+			if penalty[c] > 0 {
+				penalty[c]--
+				overhead[i]++
+				usage.overhead++
+			} else {
+				remaining[i] -= speed
+			}
+			cpuTime[i]++
+			ran[c]++
+			usage.busy++
+			usage.perCPU[c]++
+		}
+		for c, i := range running {
+			heat[c] = params.Thermal.heat(heat[c], i >= 0, 1)
+		}
+		timer++
+		for c, i := range running {
+			if i >= 0 && remaining[i] <= smpEpsilon {
+				completion[i] = timer
+				done++
+				running[c] = -1
+			}
+		}
+	}
+
+	var gantt []TimeSlice
+	for _, lane := range lanes {
+		gantt = append(gantt, lane...)
+	}
+	sort.SliceStable(gantt, func(i, j int) bool {
+		return gantt[i].CPU < gantt[j].CPU
+	})
+
+	r := newResult(processes, completion, gantt)
+	if params.varied() || params.numa() {
+		times := make([]string, n)
+		for i := range r.Processes {
+			r.Processes[i].Wait = r.Processes[i].Turnaround - cpuTime[i]
+			times[i] = fmt.Sprint(cpuTime[i])
+		}
+		r.Columns = append(r.Columns, Column{Header: "CPU time", Values: times})
+	}
+	if n > 0 {
+		first, last := processes[order[0]].ArrivalTime, int64(0)
+		for _, c := range completion {
+			if c > last {
+				last = c
+			}
+		}
+		usage.makespan = last - first
+	}
+	if params.scaled() {
+		used := make([]string, n)
+		for i := range used {
+			used[i] = fmt.Sprintf("%.2f", energy[i])
+		}
+		r.Columns = append(r.Columns, Column{Header: "Energy", Values: used})
+	}
+	moves := make([]string, n)
+	for i := range moves {
+		moves[i] = fmt.Sprint(migrations[i])
+		usage.migrations += migrations[i]
+	}
+	r.Columns = append(r.Columns, Column{Header: "Migrations", Values: moves})
+	if params.numa() {
+		costs := make([]string, n)
+		for i := range costs {
+			costs[i] = fmt.Sprint(overhead[i])
+		}
+		r.Columns = append(r.Columns, Column{Header: "Migration cost", Values: costs})
+	}
+	if affinity && pinned(processes) {
+		sets := make([]string, n)
+		for i, p := range processes {
+			sets[i] = affinitySet(p, cpus)
+		}
+		r.Columns = append(r.Columns, Column{Header: "Affinity", Values: sets})
+	}
+	return r, usage
+}
+
+// longestFirst returns the indexes of queues from the longest to the shortest, keeping the lowest on ties.
+func longestFirst(queues [][]int) []int {
+	order := make([]int, len(queues))
+	for q := range order {
+		order[q] = q
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(queues[order[i]]) > len(queues[order[j]])
+	})
+	return order
+}
+
+// rebalance moves processes from the longest queue to the shortest, from the back of the queue, until
+// their lengths differ by at most one or no process in the longest may run on the shortest's CPU.
+func rebalance(queues [][]int, may func(i, c int) bool) {
+	for {
+		order := longestFirst(queues)
+		long, short := order[0], order[len(order)-1]
+		if len(queues[long])-len(queues[short]) <= 1 {
+			return
+		}
+		moved := false
+		for k := len(queues[long]) - 1; k >= 0; k-- {
+			if i := queues[long][k]; may(i, short) {
+				queues[long] = append(queues[long][:k], queues[long][k+1:]...)
+				queues[short] = append(queues[short], i)
+				moved = true
+				break
+			}
+		}
+		if !moved {
+			return
+		}
+	}
+}
+
+// allowed reports whether p may run on CPU c. An affinity naming none of the cpus is ignored, so that
+// every process can run somewhere.
+func allowed(p Process, c, cpus int) bool {
+	any := false
+	for _, a := range p.Affinity {
+		if a == c {
+			return true
+		}
+		any = any || a < cpus
+	}
+	return !any
+}
+
+// pinned reports whether any process has an affinity.
+func pinned(processes []Process) bool {
+	for _, p := range processes {
+		if len(p.Affinity) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// affinitySet formats the CPUs p may run on.
+func affinitySet(p Process, cpus int) string {
+	var ids []int64
+	for c := 0; c < cpus; c++ {
+		if allowed(p, c, cpus) {
+			ids = append(ids, int64(c))
+		}
+	}
+	if len(ids) == cpus {
+		return "any"
+	}
+	return joinIDs(ids, ", ")
+}
+
+// outputMigrations outputs how many times processes moved between CPUs.
+func outputMigrations(w io.Writer, u smpUsage) {
+	_, _ = fmt.Fprintf(w, "Migrations: %d\n", u.migrations)
+}
+
+// outputSpeeds outputs the speed of each CPU and the time it spent running processes.
+func outputSpeeds(w io.Writer, u smpUsage, params SMPParams) {
+	unit := unitOf(w)
+	for c, s := range params.Speeds {
+		_, _ = fmt.Fprintf(w, "CPU %d at %gx: busy %s, %s work done\n", c, s, unit.format(u.perCPU[c]),
+			unit.formatFloat(s*float64(u.perCPU[c]), -1))
+	}
+}
+
+// outputAffinityCost outputs the CPU utilization and makespan of the schedule without affinity
+// constraints, to set against those with them.
+func outputAffinityCost(w io.Writer, pinned smpUsage, free Result, freeUsage smpUsage) {
+	_, cpu := cpuTimes(free.Gantt)
+	unit := unitOf(w)
+	_, _ = fmt.Fprintf(w, "Without affinity: CPU utilization %.1f%%, makespan %s (%s with)\n",
+		100*cpu.utilization(), unit.format(freeUsage.makespan), unit.format(pinned.makespan))
+}
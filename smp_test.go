@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSMPSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		params    SMPParams
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "shared ready queue",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+					{ProcessID: 4, ArrivalTime: 1, BurstDuration: 3},
+				},
+				title:  "Symmetric multiprocessing",
+				params: SMPParams{CPUs: 2, Quantum: 2},
+			},
+			wantOut: loadFixture(t, "smp_test.txt"),
+		},
+		{
+			name: "affinity",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Affinity: []int{0}},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Affinity: []int{0}},
+					{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+					{ProcessID: 4, ArrivalTime: 1, BurstDuration: 3},
+				},
+				title:  "Symmetric multiprocessing",
+				params: SMPParams{CPUs: 2, Quantum: 2},
+			},
+			wantOut: loadFixture(t, "smp_affinity_test.txt"),
+		},
+		{
+			name: "cores of different speeds",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+					{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2},
+				},
+				title:  "Symmetric multiprocessing",
+				params: SMPParams{CPUs: 2, Quantum: 2, Speeds: []float64{1, 0.5}},
+			},
+			wantOut: loadFixture(t, "smp_speeds_test.txt"),
+		},
+		{
+			name: "work stealing",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1},
+					{ProcessID: 3, ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: 4, ArrivalTime: 0, BurstDuration: 1},
+				},
+				title:  "Symmetric multiprocessing",
+				params: SMPParams{CPUs: 2, Quantum: 2, Balance: WorkStealing},
+			},
+			wantOut: loadFixture(t, "smp_stealing_test.txt"),
+		},
+		{
+			name: "periodic rebalance",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1},
+					{ProcessID: 3, ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: 4, ArrivalTime: 0, BurstDuration: 1},
+				},
+				title:  "Symmetric multiprocessing",
+				params: SMPParams{CPUs: 2, Quantum: 2, Balance: PeriodicRebalance},
+			},
+			wantOut: loadFixture(t, "smp_rebalance_test.txt"),
+		},
+		{
+			name: "on-demand frequency scaling",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+					{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+				},
+				title: "Symmetric multiprocessing",
+				params: SMPParams{
+					CPUs:      2,
+					Quantum:   2,
+					Levels:    [][]FrequencyLevel{{{Speed: 0.5, Power: 1}, {Speed: 1, Power: 4}}},
+					Governor:  OnDemandGovernor,
+					IdlePower: 0.5,
+				},
+			},
+			wantOut: loadFixture(t, "smp_energy_test.txt"),
+		},
+		{
+			name: "performance governor with idle time",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+					{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+					{ProcessID: 4, ArrivalTime: 5, BurstDuration: 1},
+				},
+				title: "Symmetric multiprocessing",
+				params: SMPParams{
+					CPUs:      2,
+					Quantum:   2,
+					Levels:    [][]FrequencyLevel{{{Speed: 0.5, Power: 1}, {Speed: 1, Power: 4}}},
+					Governor:  PerformanceGovernor,
+					IdlePower: 0.5,
+				},
+			},
+			wantOut: loadFixture(t, "smp_performance_test.txt"),
+		},
+		{
+			name: "thermal throttling",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+					{ProcessID: 3, ArrivalTime: 12, BurstDuration: 2},
+				},
+				title:  "Symmetric multiprocessing",
+				params: SMPParams{CPUs: 2, Quantum: 2, Thermal: Thermal{Threshold: 3}},
+			},
+			wantOut: loadFixture(t, "smp_thermal_test.txt"),
+		},
+		{
+			name: "cross-node migrations",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+					{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2},
+				},
+				title:  "NUMA multiprocessing",
+				params: SMPParams{CPUs: 2, Quantum: 2, NodeSize: 1, MigrationCost: 1},
+			},
+			wantOut: loadFixture(t, "smp_numa_test.txt"),
+		},
+		{
+			name: "sibling contention",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+					{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+				},
+				title:  "Hyperthreaded multiprocessing",
+				params: SMPParams{CPUs: 4, Quantum: 2, SMTSlowdown: 0.5},
+			},
+			wantOut: loadFixture(t, "smp_smt_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			SMPSchedule(&w, tt.args.title, tt.args.processes, tt.args.params)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("SMPSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
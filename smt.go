@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// smt reports whether the CPUs are paired into cores whose hardware threads slow each other down.
+func (p SMPParams) smt() bool {
+	return p.SMTSlowdown > 0 && p.CPUs > 1
+}
+
+// siblingBusy reports whether the other hardware thread of CPU c's core is running a process. The last
+// CPU of an odd number has no sibling.
+func (p SMPParams) siblingBusy(running []int, c int) bool {
+	s := c ^ 1
+	return s < len(running) && running[s] >= 0
+}
+
+// outputContention outputs how often hardware threads shared a core with a busy sibling and the work
+// that cost.
+func outputContention(w io.Writer, u smpUsage) {
+	unit := unitOf(w)
+	_, _ = fmt.Fprintf(w, "%s: %s/%s; %s: %s\n", msg(w, "SMT contention"), unit.format(u.contended), unit.format(u.busy),
+		msg(w, "Work lost"), unit.formatFloat(u.lost, 2))
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// SortOrder is the order of the rows of schedule tables. The zero SortOrder keeps the order the scheduler
+// left its processes in.
+type SortOrder string
+
+// Sort orders of schedule tables, each breaking ties by PID.
+const (
+	SortScheduled  SortOrder = "scheduled"
+	SortPID        SortOrder = "pid"
+	SortArrival    SortOrder = "arrival"
+	SortCompletion SortOrder = "completion"
+	SortWait       SortOrder = "wait"
+)
+
+// ErrUnknownSortOrder is returned when a sort order name is not recognised.
+var ErrUnknownSortOrder = errors.New("unknown sort order")
+
+// sortOrders are the orders schedule tables may be sorted in, and the key of a row for each.
+var sortOrders = []struct {
+	order SortOrder
+	key   func(p ProcessResult) int64
+}{
+	{SortScheduled, nil},
+	{SortPID, func(p ProcessResult) int64 { return p.ProcessID }},
+	{SortArrival, func(p ProcessResult) int64 { return p.ArrivalTime }},
+	{SortCompletion, func(p ProcessResult) int64 { return p.Completion }},
+	{SortWait, func(p ProcessResult) int64 { return p.Wait }},
+}
+
+// ParseSortOrder returns the sort order with the given name.
+func ParseSortOrder(name string) (SortOrder, error) {
+	names := make([]string, len(sortOrders))
+	for i, o := range sortOrders {
+		if strings.EqualFold(name, string(o.order)) {
+			return o.order, nil
+		}
+		names[i] = string(o.order)
+	}
+	return "", fmt.Errorf("%w: %q (known: %v)", ErrUnknownSortOrder, name, names)
+}
+
+// WithSortOrder returns a writer to w that has the rows of the schedule tables of schedules written to it
+// sorted in order, so tables of different algorithms can be compared row by row.
+func WithSortOrder(w io.Writer, order SortOrder) io.Writer {
+	ow := options(w)
+	ow.order = order
+	return ow
+}
+
+// tableOrder returns the indexes of processes in the order w sorts schedule table rows in.
+func tableOrder(w io.Writer, processes []ProcessResult) []int {
+	order := make([]int, len(processes))
+	for i := range order {
+		order[i] = i
+	}
+	var key func(p ProcessResult) int64
+	for _, o := range sortOrders {
+		if o.order == options(w).order {
+			key = o.key
+		}
+	}
+	if key == nil {
+		return order
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := processes[order[i]], processes[order[j]]
+		if key(a) != key(b) {
+			return key(a) < key(b)
+		}
+		return a.ProcessID < b.ProcessID
+	})
+	return order
+}
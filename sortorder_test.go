@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSortOrder(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		order   string
+		want    SortOrder
+		wantErr error
+	}{
+		{name: "scheduled", order: "scheduled", want: SortScheduled},
+		{name: "case", order: "Completion", want: SortCompletion},
+		{name: "unknown", order: "burst", wantErr: ErrUnknownSortOrder},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSortOrder(tt.order)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseSortOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSortOrder() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tableOrder(t *testing.T) {
+	t.Parallel()
+	processes := []ProcessResult{
+		{Process: Process{ProcessID: 3, ArrivalTime: 0}, Wait: 4, Completion: 9},
+		{Process: Process{ProcessID: 1, ArrivalTime: 2}, Wait: 0, Completion: 5},
+		{Process: Process{ProcessID: 2, ArrivalTime: 0}, Wait: 4, Completion: 7},
+	}
+	tests := []struct {
+		order SortOrder
+		want  []int
+	}{
+		{order: "", want: []int{0, 1, 2}},
+		{order: SortScheduled, want: []int{0, 1, 2}},
+		{order: SortPID, want: []int{1, 2, 0}},
+		{order: SortArrival, want: []int{2, 0, 1}},
+		{order: SortCompletion, want: []int{1, 2, 0}},
+		{order: SortWait, want: []int{1, 2, 0}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.order), func(t *testing.T) {
+			t.Parallel()
+			if got := tableOrder(WithSortOrder(&bytes.Buffer{}, tt.order), processes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tableOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithSortOrder(t *testing.T) {
+	t.Parallel()
+	r := Result{
+		Processes: []ProcessResult{
+			{Process: Process{ProcessID: 2, BurstDuration: 1}, Turnaround: 1, Completion: 1},
+			{Process: Process{ProcessID: 1, BurstDuration: 2}, Wait: 1, Turnaround: 3, Completion: 3},
+		},
+		Gantt:   []TimeSlice{{PID: 2, Start: 0, Stop: 1}, {PID: 1, Start: 1, Stop: 3}},
+		Columns: []Column{{Header: "Note", Values: []string{"second", "first"}}},
+	}
+	var w bytes.Buffer
+	outputResult(WithSortOrder(&w, SortPID), "Sorted", r)
+	got := w.String()
+	first, second := strings.Index(got, "|  1 |"), strings.Index(got, "|  2 |")
+	if first < 0 || second < 0 || first > second {
+		t.Fatalf("outputResult() = %v, want the row of PID 1 before that of PID 2", got)
+	}
+	if !strings.Contains(got[first:second], "first") || !strings.Contains(got[second:], "second") {
+		t.Errorf("outputResult() = %v, want extra columns sorted with their rows", got)
+	}
+}
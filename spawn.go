@@ -0,0 +1,56 @@
+package main
+
+import "fmt"
+
+// withChildren returns a copy of processes followed by the children they spawn, and the indexes of
+// each process's children in the copy. Children inherit their parent's class, user, niceness and
+// tickets; their arrival time is only known once they are forked.
+func withChildren(processes []Process) ([]Process, [][]int) {
+	all := append([]Process(nil), processes...)
+	children := make([][]int, len(processes))
+	for i, p := range processes {
+		for _, s := range p.Spawns {
+			children[i] = append(children[i], len(all))
+			all = append(all, Process{
+				ProcessID:     s.ProcessID,
+				BurstDuration: s.BurstDuration,
+				Priority:      s.Priority,
+				Class:         p.Class,
+				Nice:          p.Nice,
+				User:          p.User,
+				Tickets:       p.Tickets,
+			})
+		}
+	}
+	for len(children) < len(all) {
+		children = append(children, nil)
+	}
+	return all, children
+}
+
+// checkSpawns reports children out of fork order, a child that would never be forked because its parent
+// finishes first, or one whose process ID is already taken.
+func checkSpawns(processes []Process) error {
+	ids := make(map[int64]bool, len(processes))
+	for _, p := range processes {
+		ids[p.ProcessID] = true
+	}
+	for _, p := range processes {
+		for i, s := range p.Spawns {
+			switch {
+			case i > 0 && s.After < p.Spawns[i-1].After:
+				return fmt.Errorf("%w: process %d spawns are not in order", ErrInvalidColumn, p.ProcessID)
+			case s.After < 1 || s.After > p.BurstDuration:
+				return fmt.Errorf("%w: process %d cannot spawn after %d of %d ticks",
+					ErrInvalidColumn, p.ProcessID, s.After, p.BurstDuration)
+			case s.BurstDuration < 1:
+				return fmt.Errorf("%w: process %d spawns process %d with no burst", ErrInvalidColumn, p.ProcessID, s.ProcessID)
+			case ids[s.ProcessID]:
+				return fmt.Errorf("%w: process %d spawns process %d, which already exists",
+					ErrInvalidColumn, p.ProcessID, s.ProcessID)
+			}
+			ids[s.ProcessID] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// StarvationSchedule outputs the schedule an algorithm produces in a GANTT chart and a table of timing,
+// followed by the processes it starved, given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • the algorithm to run
+// • the wait beyond which a process counts as starved
+//
+// A process is starved if it waited longer than the threshold, or if it never ran at all before the
+// simulation ended, as happens to processes left in a dependency cycle or deadlocked on locks.
+func StarvationSchedule(w io.Writer, title string, processes []Process, a Algorithm, threshold int64) {
+	r := a.Run(processes)
+	if outputCustom(w, title, r) {
+		return
+	}
+	outputResult(w, title, r)
+	outputStarved(w, r, threshold)
+}
+
+// starvation is a process found starved in a schedule.
+type starvation struct {
+	ProcessResult
+	// wait is the process's wait, or for a process that never ran the time from its arrival until the
+	// simulation ended.
+	wait  int64
+	never bool
+}
+
+// starved returns the processes in r that waited longer than threshold or never ran, in table order.
+func starved(r Result, threshold int64) []starvation {
+	var (
+		ran = make(map[int64]bool)
+		end int64
+	)
+	for _, s := range r.Gantt {
+		if s.PID >= 0 {
+			ran[s.PID] = true
+		}
+		if end < s.Stop {
+			end = s.Stop
+		}
+	}
+
+	var found []starvation
+	for _, p := range r.Processes {
+		switch {
+		case !ran[p.ProcessID] && p.BurstDuration > 0:
+			wait := end - p.ArrivalTime
+			if wait < 0 {
+				wait = 0
+			}
+			found = append(found, starvation{ProcessResult: p, wait: wait, never: true})
+		case p.Wait > threshold:
+			found = append(found, starvation{ProcessResult: p, wait: p.Wait})
+		}
+	}
+	return found
+}
+
+// outputStarved outputs the processes in r that waited longer than threshold or never ran.
+func outputStarved(w io.Writer, r Result, threshold int64) {
+	if len(r.Processes) == 0 {
+		return
+	}
+	found := starved(r, threshold)
+	if len(found) == 0 {
+		_, _ = fmt.Fprintf(w, "%s: %s; %s: %s\n", msg(w, "Starved processes"), msg(w, "none"), msg(w, "Threshold"),
+			unitOf(w).format(threshold))
+		return
+	}
+
+	rows := make([][]string, len(found))
+	for i, s := range found {
+		reason := fmt.Sprintf("waited %d over %d", s.wait-threshold, threshold)
+		if s.never {
+			reason = "never ran"
+		}
+		rows[i] = []string{
+			fmt.Sprint(s.ProcessID),
+			fmt.Sprint(s.Priority),
+			fmt.Sprint(s.ArrivalTime),
+			fmt.Sprint(s.wait),
+			reason,
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, msg(w, "Starved processes"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "ID", "Priority", "Arrival", "Wait", "Reason"))
+	table.AppendBulk(rows)
+	table.SetFooter([]string{"", "", "", "", fmt.Sprintf("%d/%d", len(found), len(r.Processes))})
+	table.Render()
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStarvationSchedule(t *testing.T) {
+	t.Parallel()
+	preemptivePriority := Algorithm{Name: "Preemptive priority", Run: func(p []Process) Result {
+		return simulate(p, policy{pick: highestPriority, preemptive: true})
+	}}
+	type args struct {
+		processes []Process
+		title     string
+		threshold int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "long wait and dependency cycle",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Priority: 1},
+					{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1, Priority: 5},
+					{ProcessID: 3, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+					{ProcessID: 4, ArrivalTime: 2, BurstDuration: 2, Priority: 2, DependsOn: []int64{5}},
+					{ProcessID: 5, ArrivalTime: 2, BurstDuration: 2, Priority: 2, DependsOn: []int64{4}},
+				},
+				title:     "Preemptive priority",
+				threshold: 5,
+			},
+			wantOut: loadFixture(t, "starvation_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			StarvationSchedule(&w, tt.args.title, tt.args.processes, preemptivePriority, tt.args.threshold)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("StarvationSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_starved(t *testing.T) {
+	t.Parallel()
+	r := roundRobin([]Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+	}, defaultQuantum)
+	if got := starved(r, 2); len(got) != 0 {
+		t.Errorf("starved(2) = %v, want none", got)
+	}
+	if got := starved(r, 1); len(got) != 1 || got[0].ProcessID != 2 || got[0].never {
+		t.Errorf("starved(1) = %v, want process 2 over its wait", got)
+	}
+}
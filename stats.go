@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// WithStatistics returns a writer to w that has schedules written to it follow their schedule tables with
+// the spread of their waiting and turnaround times and slowdowns, which averages alone hide, their
+// averages weighted by burst, their makespans, how fairly their waits were shared out, how busy their CPUs were, and their averages at each
+// priority if their processes were given several.
+func WithStatistics(w io.Writer) io.Writer {
+	ow := options(w)
+	ow.stats = true
+	return ow
+}
+
+// valueStats describes the spread of a set of values.
+type valueStats struct {
+	mean, stddev, median float64
+	min, p95, p99, max   float64
+}
+
+// describe returns the statistics of values, which must not be empty: the mean and its population standard
+// deviation, the median, and the 95th and 99th percentiles by nearest rank.
+func describe(values []float64) valueStats {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	rank := func(p float64) float64 {
+		return sorted[int(math.Ceil(p/100*float64(n)))-1]
+	}
+
+	s := valueStats{min: sorted[0], max: sorted[n-1], p95: rank(95), p99: rank(99)}
+	for _, v := range sorted {
+		s.mean += v
+	}
+	s.mean /= float64(n)
+	for _, v := range sorted {
+		s.stddev += (v - s.mean) * (v - s.mean)
+	}
+	s.stddev = math.Sqrt(s.stddev / float64(n))
+	s.median = sorted[n/2]
+	if n%2 == 0 {
+		s.median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return s
+}
+
+// slowdown is the normalized turnaround of p: its turnaround as a multiple of its burst, 1 for a process
+// that never waited. Processes without a burst have none.
+func slowdown(p ProcessResult) (float64, bool) {
+	if p.BurstDuration <= 0 {
+		return 0, false
+	}
+	return float64(p.Turnaround) / float64(p.BurstDuration), true
+}
+
+// outputStatistics outputs the spread of the waiting and turnaround times and slowdowns of a schedule,
+// the means of the times weighted by burst, its makespan, the fairness of its waits, its CPU time and its averages by priority, if w asks for them, leaving out killed processes when the averages do.
+func outputStatistics(w io.Writer, r Result) {
+	if !options(w).stats {
+		return
+	}
+	var waits, turnarounds, slowdowns []float64
+	for _, p := range r.Processes {
+		if p.Killed && r.ExcludeKilled {
+			continue
+		}
+		waits = append(waits, float64(p.Wait))
+		turnarounds = append(turnarounds, float64(p.Turnaround))
+		if s, ok := slowdown(p); ok {
+			slowdowns = append(slowdowns, s)
+		}
+	}
+	if len(waits) == 0 {
+		return
+	}
+
+	unit := unitOf(w)
+	times := func(name string, s valueStats) []string {
+		tick := func(v float64) string { return unit.format(int64(v)) }
+		return []string{name, unit.formatAverage(s.mean), unit.formatAverage(s.stddev), tick(s.min),
+			unit.formatAverage(s.median), tick(s.p95), tick(s.p99), tick(s.max)}
+	}
+	rows := [][]string{times(msg(w, "Wait"), describe(waits)), times(msg(w, "Turnaround"), describe(turnarounds))}
+	if len(slowdowns) > 0 {
+		s := describe(slowdowns)
+		row := []string{msg(w, "Slowdown")}
+		for _, v := range []float64{s.mean, s.stddev, s.min, s.median, s.p95, s.p99, s.max} {
+			row = append(row, fmt.Sprintf("%.2f", v))
+		}
+		rows = append(rows, row)
+	}
+	_, _ = fmt.Fprintln(w, msg(w, "Statistics"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(append([]string{""}, msgs(w, "Mean", "Std dev", "Min", "Median", "P95", "P99", "Max")...))
+	table.SetColumnAlignment(sideBySideAlignment(8))
+	table.AppendBulk(rows)
+	table.Render()
+	s := summarize(r)
+	_, _ = fmt.Fprintf(w, "%s: %s %s, %s %s\n", msg(w, "Mean weighted by burst"), msg(w, "Wait"), unit.formatAverage(s.weightedWait),
+		msg(w, "Turnaround"), unit.formatAverage(s.weightedTurnaround))
+	_, _ = fmt.Fprintf(w, "%s %s\n", msg(w, "Makespan"), unit.format(s.makespan))
+	_, _ = fmt.Fprintf(w, "%s %.2f, %s %.2f\n", msg(w, "Fairness"), s.fairness, msg(w, "Gini"), s.gini)
+	outputPriorityClasses(w, r)
+}
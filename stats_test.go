@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_describe(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		times []float64
+		want  valueStats
+	}{
+		{
+			name:  "one",
+			times: []float64{5},
+			want:  valueStats{mean: 5, median: 5, min: 5, p95: 5, p99: 5, max: 5},
+		},
+		{
+			name:  "odd",
+			times: []float64{4, 1, 4},
+			want:  valueStats{mean: 3, stddev: 1.4142135623730951, median: 4, min: 1, p95: 4, p99: 4, max: 4},
+		},
+		{
+			name:  "even",
+			times: []float64{6, 0, 2, 4},
+			want:  valueStats{mean: 3, stddev: 2.23606797749979, median: 3, min: 0, p95: 6, p99: 6, max: 6},
+		},
+		{
+			name:  "percentiles",
+			times: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 100},
+			want:  valueStats{mean: 14.5, stddev: 20.328551350256124, median: 10.5, min: 1, p95: 19, p99: 100, max: 100},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := describe(tt.times); got != tt.want {
+				t.Errorf("describe() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithStatistics(t *testing.T) {
+	t.Parallel()
+	r := Result{Processes: []ProcessResult{
+		{Process: Process{ProcessID: 1, BurstDuration: 2}, Wait: 1, Turnaround: 3, Completion: 3},
+		{Process: Process{ProcessID: 2, BurstDuration: 3}, Wait: 4, Turnaround: 7, Completion: 7},
+		{Process: Process{ProcessID: 3, BurstDuration: 4}, Wait: 4, Turnaround: 8, Completion: 8},
+		{Process: Process{ProcessID: 4}, Wait: 9, Turnaround: 9, Completion: 9, Killed: true},
+	}, ExcludeKilled: true}
+
+	var w bytes.Buffer
+	outputStatistics(&w, r)
+	if w.Len() != 0 {
+		t.Errorf("outputStatistics() without WithStatistics = %q, want nothing", w.String())
+	}
+
+	outputStatistics(WithStatistics(WithTimeUnit(&w, TimeUnit{Name: "ms"})), r)
+	want := strings.Join([]string{
+		"Statistics",
+		"+------------+--------+---------+------+--------+------+------+------+",
+		"|            |  MEAN  | STD DEV | MIN  | MEDIAN | P95  | P99  | MAX  |",
+		"+------------+--------+---------+------+--------+------+------+------+",
+		"| Wait       | 3.00ms |  1.41ms |  1ms | 4.00ms |  4ms |  4ms |  4ms |",
+		"| Turnaround | 6.00ms |  2.16ms |  3ms | 7.00ms |  8ms |  8ms |  8ms |",
+		"| Slowdown   |   1.94 |    0.34 | 1.50 |   2.00 | 2.33 | 2.33 | 2.33 |",
+		"+------------+--------+---------+------+--------+------+------+------+",
+		"Mean weighted by burst: Wait 3.33ms, Turnaround 6.56ms",
+		"Makespan 8ms",
+		"Fairness 0.82, Gini 0.22",
+		"",
+	}, "\n")
+	if got := w.String(); got != want {
+		t.Errorf("outputStatistics() = %v, want %v", got, want)
+	}
+}
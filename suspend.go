@@ -0,0 +1,42 @@
+package main
+
+import "io"
+
+// SuspendSchedule outputs a round-robin schedule of processes that an operator suspends and resumes in a
+// GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes, where those with Suspensions are stopped for each of them
+// • the time quantum each process may run before being preempted
+// • whether the time a process spends suspended is left out of its wait
+//
+// The time each process spent suspended is shown in the table.
+func SuspendSchedule(w io.Writer, title string, processes []Process, quantum int64, excludeSuspended bool) {
+	if quantum < 1 {
+		quantum = defaultQuantum
+	}
+	outputResult(w, title, simulate(processes, policy{pick: firstReady, quantum: quantum, excludeSuspended: excludeSuspended}))
+}
+
+// suspend reports whether one of j's suspensions covers time t, recording when it began. Suspensions that
+// ended while j could not run are skipped.
+func (j *job) suspend(t int64) bool {
+	for j.suspension < len(j.Suspensions) && j.Suspensions[j.suspension].Until <= t {
+		j.suspension++
+	}
+	if j.suspension == len(j.Suspensions) || j.Suspensions[j.suspension].From > t {
+		return false
+	}
+	j.suspendedAt = t
+	return true
+}
+
+// suspending reports whether any process is ever suspended.
+func suspending(processes []Process) bool {
+	for _, p := range processes {
+		if len(p.Suspensions) > 0 {
+			return true
+		}
+	}
+	return false
+}
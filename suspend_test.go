@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSuspendSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Suspensions: []Suspension{{From: 1, Until: 5}}},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+	}
+	type args struct {
+		processes        []Process
+		title            string
+		quantum          int64
+		excludeSuspended bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "suspension counted as waiting",
+			args: args{
+				processes: processes,
+				title:     "Suspend and resume",
+				quantum:   2,
+			},
+			wantOut: loadFixture(t, "suspend_test.txt"),
+		},
+		{
+			name: "suspension excluded from waiting",
+			args: args{
+				processes:        processes,
+				title:            "Suspend and resume",
+				quantum:          2,
+				excludeSuspended: true,
+			},
+			wantOut: loadFixture(t, "suspend_excluded_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			SuspendSchedule(&w, tt.args.title, tt.args.processes, tt.args.quantum, tt.args.excludeSuspended)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("SuspendSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
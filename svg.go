@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"sort"
+)
+
+// Layout of an SVG chart, in pixels.
+const (
+	svgMargin     = 16
+	svgLabelWidth = 64  // the lane names left of the bars
+	svgPlotWidth  = 720 // the bars of the whole schedule
+	svgTitle      = 28
+	svgLaneHeight = 22
+	svgLaneGap    = 4
+	svgAxis       = 36
+	svgCharWidth  = 7 // roughly, of a digit in the 11px font bars are labelled in
+)
+
+// GanttSVG collects the GANTT charts of schedules to draw them, one under another, in one SVG image.
+type GanttSVG struct {
+	charts []svgChart
+}
+
+// svgChart is the GANTT chart of one schedule.
+type svgChart struct {
+	title string
+	gantt []TimeSlice
+	unit  TimeUnit
+}
+
+// add adds the chart of a schedule titled title, with times shown in unit u.
+func (g *GanttSVG) add(title string, gantt []TimeSlice, u TimeUnit) {
+	if len(gantt) > 0 {
+		g.charts = append(g.charts, svgChart{title: title, gantt: gantt, unit: u})
+	}
+}
+
+// WriteTo writes the charts as an SVG image. Each chart draws a lane per process, or per CPU for a
+// schedule run on several, with bars as long as the slices ran, coloured by PID, over an axis of time.
+func (g *GanttSVG) WriteTo(w io.Writer) (int64, error) {
+	var (
+		b      bytes.Buffer
+		height = svgMargin
+		width  = svgMargin + svgLabelWidth + svgPlotWidth + svgMargin
+	)
+	for _, c := range g.charts {
+		height += c.height() + svgMargin
+	}
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+	y := svgMargin
+	for _, c := range g.charts {
+		c.draw(&b, y)
+		y += c.height() + svgMargin
+	}
+	b.WriteString("</svg>\n")
+	return b.WriteTo(w)
+}
+
+// svgLane is one lane of a chart: its name and the slices drawn in it.
+type svgLane struct {
+	name   string
+	slices []TimeSlice
+}
+
+// lanes returns a lane per CPU if the chart ran on several, or else a lane per process in PID order,
+// leaving out the time CPUs were idle.
+func (c svgChart) lanes() []svgLane {
+	if cpus := cpuCount(c.gantt); cpus > 1 {
+		lanes := make([]svgLane, cpus)
+		for cpu := range lanes {
+			lanes[cpu].name = fmt.Sprintf("CPU %d", cpu)
+		}
+		for _, s := range c.gantt {
+			if s.PID != idlePID {
+				lanes[s.CPU].slices = append(lanes[s.CPU].slices, s)
+			}
+		}
+		return lanes
+	}
+
+	var (
+		lanes []svgLane
+		index = make(map[int64]int)
+	)
+	for _, s := range c.gantt {
+		if s.PID < 0 {
+			continue
+		}
+		i, ok := index[s.PID]
+		if !ok {
+			i = len(lanes)
+			index[s.PID] = i
+			lanes = append(lanes, svgLane{name: fmt.Sprintf("P%d", s.PID)})
+		}
+		lanes[i].slices = append(lanes[i].slices, s)
+	}
+	sort.SliceStable(lanes, func(i, j int) bool { return lanes[i].slices[0].PID < lanes[j].slices[0].PID })
+	return lanes
+}
+
+// height is how tall the chart is drawn.
+func (c svgChart) height() int {
+	return svgTitle + len(c.lanes())*(svgLaneHeight+svgLaneGap) + svgAxis
+}
+
+// span is the time the chart covers: from 0, or its first slice if that started earlier, to its last.
+func (c svgChart) span() (start, stop int64) {
+	for _, s := range c.gantt {
+		if s.Start < start {
+			start = s.Start
+		}
+		if s.Stop > stop {
+			stop = s.Stop
+		}
+	}
+	if stop <= start {
+		stop = start + 1
+	}
+	return start, stop
+}
+
+// draw draws the chart with its top at y.
+func (c svgChart) draw(b *bytes.Buffer, y int) {
+	var (
+		start, stop = c.span()
+		scale       = float64(svgPlotWidth) / float64(stop-start)
+		left        = svgMargin + svgLabelWidth
+		x           = func(t int64) float64 { return float64(left) + float64(t-start)*scale }
+	)
+	b.WriteString(`<g class="gantt">` + "\n")
+	fmt.Fprintf(b, `<text x="%d" y="%d" font-size="14" font-weight="bold">%s</text>`+"\n", svgMargin, y+18, html.EscapeString(c.title))
+	y += svgTitle
+
+	for _, lane := range c.lanes() {
+		fmt.Fprintf(b, `<text x="%d" y="%d">%s</text>`+"\n", svgMargin, y+15, html.EscapeString(lane.name))
+		for _, s := range lane.slices {
+			x0, x1 := x(s.Start), x(s.Stop)
+			label, tip := sliceLabel(s), sliceLabel(s)
+			if s.PID >= 0 {
+				tip = "P" + label
+			}
+			fmt.Fprintf(b, `<rect x="%.2f" y="%d" width="%.2f" height="%d" fill="%s" stroke="white" stroke-width="0.5"><title>%s: %s to %s</title></rect>`+"\n",
+				x0, y, x1-x0, svgLaneHeight, svgColor(s.PID), tip, c.unit.format(s.Start), c.unit.format(s.Stop))
+			if x1-x0 >= float64(svgCharWidth*len(label)+4) {
+				fmt.Fprintf(b, `<text x="%.2f" y="%d" text-anchor="middle">%s</text>`+"\n", (x0+x1)/2, y+15, label)
+			}
+		}
+		y += svgLaneHeight + svgLaneGap
+	}
+
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", left, y, left+svgPlotWidth, y)
+	step := svgTickStep(stop - start)
+	for t := start - start%step; t <= stop; t += step {
+		fmt.Fprintf(b, `<line x1="%.2f" y1="%d" x2="%.2f" y2="%d" stroke="black"/>`+"\n", x(t), y, x(t), y+4)
+		fmt.Fprintf(b, `<text x="%.2f" y="%d" text-anchor="middle">%s</text>`+"\n", x(t), y+16, c.unit.value(t))
+	}
+	name := c.unit.Name
+	if name == "" {
+		name = "ticks"
+	}
+	fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="end">time (%s)</text>`+"\n", left+svgPlotWidth, y+32, html.EscapeString(name))
+	b.WriteString("</g>\n")
+}
+
+// svgTickStep is the number of ticks between labels on an axis covering span ticks: 1, 2 or 5 times a
+// power of ten, so there are at most ten or so.
+func svgTickStep(span int64) int64 {
+	step := int64(1)
+	for {
+		for _, m := range []int64{1, 2, 5} {
+			if span/(step*m) <= 10 {
+				return step * m
+			}
+		}
+		step *= 10
+	}
+}
+
+// svgColor is the fill of a PID's bars, or grey for a CPU held idle or deciding what to run.
+func svgColor(pid int64) string {
+	if pid == heldPID || pid == overheadPID {
+		return "#bbbbbb"
+	}
+	return fmt.Sprintf("hsl(%.0f,65%%,60%%)", pidHue(pid))
+}
+
+// pidHue is the hue, in degrees, charts colour a PID's bars: hues a golden angle apart, so neighbouring
+// PIDs differ.
+func pidHue(pid int64) float64 {
+	hue := math.Mod(float64(pid)*137.508, 360)
+	if hue < 0 {
+		hue += 360
+	}
+	return hue
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestGanttSVG(t *testing.T) {
+	t.Parallel()
+	var (
+		g   = &GanttSVG{}
+		out = WithGanttSVG(io.Discard, g)
+	)
+	RRSchedule(out, "Round-robin", []Process{
+		{ProcessID: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+	}, 2)
+	SMPSchedule(WithTimeUnit(out, TimeUnit{Name: "ms", Scale: 0.5}), "SMP <2 CPUs>", []Process{
+		{ProcessID: 1, BurstDuration: 4},
+		{ProcessID: 2, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+	}, SMPParams{CPUs: 2, Quantum: 2})
+
+	var w bytes.Buffer
+	if _, err := g.WriteTo(&w); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.String(), loadFixture(t, "svg_test.txt"); got != want {
+		t.Errorf("GanttSVG.WriteTo() = %v, want %v", got, want)
+	}
+}
+
+func Test_svgTickStep(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		span int64
+		want int64
+	}{
+		{span: 1, want: 1},
+		{span: 10, want: 1},
+		{span: 11, want: 2},
+		{span: 45, want: 5},
+		{span: 100, want: 10},
+		{span: 1500, want: 200},
+	}
+	for _, tt := range tests {
+		if got := svgTickStep(tt.span); got != tt.want {
+			t.Errorf("svgTickStep(%d) = %d, want %d", tt.span, got, tt.want)
+		}
+	}
+}
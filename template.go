@@ -0,0 +1,24 @@
+package main
+
+// instances returns the processes a process template stands for: Count copies numbered on from its ID,
+// each arriving Stagger ticks after the one before with any deadline moved along with it. A process
+// that is not a template stands for itself.
+func (p Process) instances() []Process {
+	if p.Count <= 1 {
+		p.Count, p.Stagger = 0, 0
+		return []Process{p}
+	}
+
+	copies := make([]Process, p.Count)
+	for k := range copies {
+		c := p
+		c.Count, c.Stagger = 0, 0
+		c.ProcessID += int64(k)
+		c.ArrivalTime += int64(k) * p.Stagger
+		if c.Deadline > 0 {
+			c.Deadline += int64(k) * p.Stagger
+		}
+		copies[k] = c
+	}
+	return copies
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package main
+
+import "os"
+
+// ttyWidth is the width, in columns, of the terminal f is, or 0 if it is not one or its width is unknown.
+func ttyWidth(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// Thermal is a simple thermal model of each CPU: heat builds by one for every tick the CPU runs a process
+// and drains by Cooling for every tick it idles. Once a CPU has built up Threshold heat it is throttled to
+// Throttle of its speed until it cools below the threshold again.
+type Thermal struct {
+	// Threshold is the heat at which a CPU throttles; zero disables the model.
+	Threshold int64
+	// Throttle is the fraction of its speed a throttled CPU runs at; zero is half speed.
+	Throttle float64
+	// Cooling is how much heat a CPU loses per idle tick; zero is one.
+	Cooling int64
+}
+
+// throttles reports whether the thermal model is enabled.
+func (t Thermal) throttles() bool {
+	return t.Threshold > 0
+}
+
+// normalize fills in defaults for unset parameters.
+func (t Thermal) normalize() Thermal {
+	if t.Throttle <= 0 || t.Throttle > 1 {
+		t.Throttle = 0.5
+	}
+	if t.Cooling < 1 {
+		t.Cooling = 1
+	}
+	return t
+}
+
+// heat updates the heat of a CPU after ticks spent busy or idle; heat never exceeds the threshold, so a
+// CPU stops throttling as soon as it idles.
+func (t Thermal) heat(h int64, busy bool, ticks int64) int64 {
+	if busy {
+		h += ticks
+	} else {
+		h -= t.Cooling * ticks
+	}
+	return clamp(h, 0, t.Threshold)
+}
+
+// outputThrottling outputs how long each CPU spent throttled.
+func outputThrottling(w io.Writer, u smpUsage) {
+	unit := unitOf(w)
+	for c, t := range u.throttled {
+		_, _ = fmt.Fprintf(w, "CPU %d throttled for %s of busy %s\n", c, unit.format(t), unit.format(u.perCPU[c]))
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Layout of a TikZ chart, in centimetres.
+const (
+	tikzWidth     = 12.0 // of the bars of the whole schedule
+	tikzCharWidth = 0.2  // roughly, of a digit of a bar's label
+)
+
+// tikzSpecial escapes the characters LaTeX treats specially in text.
+var tikzSpecial = strings.NewReplacer(
+	`\`, `\textbackslash{}`, `{`, `\{`, `}`, `\}`, `$`, `\$`, `&`, `\&`, `#`, `\#`, `%`, `\%`,
+	`_`, `\_`, `^`, `\textasciicircum{}`, `~`, `\textasciitilde{}`, `µ`, `$\mu$`,
+)
+
+// WithTikZ returns a writer to w that has schedules written to it also write their GANTT charts to dst as
+// TikZ pictures, for LaTeX documents that load the tikz package. Each picture draws a lane per process,
+// or per CPU for a schedule run on several, its bars coloured by PID as in SVG charts.
+func WithTikZ(w io.Writer, dst io.Writer) io.Writer {
+	ow := options(w)
+	ow.tikz = &tikzWriter{w: dst}
+	return ow
+}
+
+// tikzWriter writes the GANTT charts of schedules as TikZ pictures.
+type tikzWriter struct {
+	w io.Writer
+}
+
+// write writes the chart of a schedule titled title, with times shown in unit u.
+func (tw *tikzWriter) write(title string, gantt []TimeSlice, u TimeUnit) error {
+	if len(gantt) == 0 {
+		return nil
+	}
+	var (
+		b           strings.Builder
+		c           = svgChart{title: title, gantt: gantt, unit: u}
+		start, stop = c.span()
+		lanes       = c.lanes()
+		colors      = make(map[int64]bool)
+		scale       = tikzWidth / float64(stop-start)
+	)
+	fmt.Fprintf(&b, "%% %s\n", strings.ReplaceAll(title, "\n", " "))
+	fmt.Fprintf(&b, "\\begin{tikzpicture}[x=%.4fcm, y=0.6cm, font=\\small]\n", scale)
+	for _, s := range gantt {
+		if s.PID >= 0 && !colors[s.PID] {
+			colors[s.PID] = true
+			rgb := pngColor(s.PID)
+			fmt.Fprintf(&b, "  \\definecolor{pid%d}{RGB}{%d,%d,%d}\n", s.PID, rgb.R, rgb.G, rgb.B)
+		}
+	}
+	fmt.Fprintf(&b, "  \\node[anchor=south west, font=\\bfseries] at (%d, 0.2) {%s};\n", start, tikzSpecial.Replace(title))
+
+	for i, lane := range lanes {
+		top, bottom := -i, -i-1
+		fmt.Fprintf(&b, "  \\node[anchor=east] at (%d, %.1f) {%s};\n", start, float64(bottom)+0.5, lane.name)
+		for _, s := range lane.slices {
+			fill := "gray!40"
+			if s.PID >= 0 {
+				fill = fmt.Sprintf("pid%d", s.PID)
+			}
+			fmt.Fprintf(&b, "  \\filldraw[fill=%s, draw=white] (%d, %d) rectangle (%d, %d);\n", fill, s.Start, bottom, s.Stop, top)
+			if label := sliceLabel(s); float64(s.Stop-s.Start)*scale >= tikzCharWidth*float64(len(label)+1) {
+				fmt.Fprintf(&b, "  \\node at (%g, %.1f) {%s};\n", float64(s.Start+s.Stop)/2, float64(bottom)+0.5, label)
+			}
+		}
+	}
+
+	axis := -len(lanes)
+	fmt.Fprintf(&b, "  \\draw (%d, %d) -- (%d, %d);\n", start, axis, stop, axis)
+	step := svgTickStep(stop - start)
+	for t := start - start%step; t <= stop; t += step {
+		fmt.Fprintf(&b, "  \\draw (%d, %d) -- ++(0, -0.2) node[below] {%s};\n", t, axis, u.value(t))
+	}
+	name := u.Name
+	if name == "" {
+		name = "ticks"
+	}
+	fmt.Fprintf(&b, "  \\node[anchor=north east] at (%d, %.1f) {time (%s)};\n", stop, float64(axis)-0.9, tikzSpecial.Replace(name))
+	b.WriteString("\\end{tikzpicture}\n\n")
+
+	_, err := io.WriteString(tw.w, b.String())
+	return err
+}
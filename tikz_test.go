@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestWithTikZ(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	out := WithTikZ(io.Discard, &w)
+	RRSchedule(out, "Round-robin", []Process{
+		{ProcessID: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+	}, 2)
+	SMPSchedule(WithTimeUnit(out, TimeUnit{Name: "µs", Scale: 0.5}), "SMP_2 & 50%", []Process{
+		{ProcessID: 1, BurstDuration: 4},
+		{ProcessID: 2, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+	}, SMPParams{CPUs: 2, Quantum: 2})
+	if got, want := w.String(), loadFixture(t, "tikz_test.txt"); got != want {
+		t.Errorf("TikZ = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// traceEvent is an event of the Trace Event Format as written. Times are in microseconds.
+type traceEvent struct {
+	Name string                 `json:"name"`
+	Ph   string                 `json:"ph"`
+	Ts   float64                `json:"ts"`
+	Dur  float64                `json:"dur,omitempty"`
+	Pid  int                    `json:"pid"`
+	Tid  int                    `json:"tid"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// traceMicroseconds is how many microseconds a tick of each time unit name stands for, before its scale.
+// Bare ticks are written as milliseconds, as Chrome traces are read.
+var traceMicroseconds = map[string]float64{"": 1000, "s": 1e6, "ms": 1000, "µs": 1, "ns": 0.001}
+
+// TraceExport collects the schedules written to a writer to write them as one Chrome trace: a process
+// per schedule, named by its title, with a thread per CPU holding a complete event per slice of its
+// GANTT chart, so they can be explored in chrome://tracing or the Perfetto UI.
+type TraceExport struct {
+	schedules []traceSchedule
+}
+
+// traceSchedule is what a trace holds of one schedule.
+type traceSchedule struct {
+	title string
+	r     Result
+	us    float64 // microseconds per tick
+}
+
+// WithTraceExport returns a writer to w that has schedules written to it also add their GANTT charts to t.
+func WithTraceExport(w io.Writer, t *TraceExport) io.Writer {
+	ow := options(w)
+	ow.trace = t
+	return ow
+}
+
+// add adds a schedule titled title, with ticks standing for unit u.
+func (t *TraceExport) add(title string, r Result, u TimeUnit) {
+	if len(r.Gantt) > 0 {
+		t.schedules = append(t.schedules, traceSchedule{title: title, r: r, us: traceMicroseconds[u.Name] * u.scale()})
+	}
+}
+
+// events returns the trace events of the schedules.
+func (t *TraceExport) events() []traceEvent {
+	events := []traceEvent{}
+	for i, sc := range t.schedules {
+		pid := i + 1
+		events = append(events,
+			traceEvent{Name: "process_name", Ph: "M", Pid: pid, Args: map[string]interface{}{"name": sc.title}},
+			traceEvent{Name: "process_sort_index", Ph: "M", Pid: pid, Args: map[string]interface{}{"sort_index": pid}},
+		)
+		for cpu := 0; cpu < cpuCount(sc.r.Gantt); cpu++ {
+			events = append(events, traceEvent{Name: "thread_name", Ph: "M", Pid: pid, Tid: cpu, Args: map[string]interface{}{"name": fmt.Sprintf("CPU %d", cpu)}})
+		}
+		for _, s := range sc.r.Gantt {
+			if s.PID == idlePID {
+				continue
+			}
+			e := traceEvent{
+				Name: "P" + sliceLabel(s),
+				Ph:   "X",
+				Ts:   float64(s.Start) * sc.us,
+				Dur:  float64(s.Stop-s.Start) * sc.us,
+				Pid:  pid,
+				Tid:  s.CPU,
+				Args: map[string]interface{}{"pid": s.PID},
+			}
+			if s.PID < 0 {
+				e.Name, e.Args = sliceLabel(s), nil
+			}
+			if s.Queue != "" {
+				if e.Args == nil {
+					e.Args = make(map[string]interface{})
+				}
+				e.Args["queue"] = s.Queue
+			}
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// WriteTo writes the trace as JSON.
+func (t *TraceExport) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.MarshalIndent(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{t.events(), "ms"}, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(append(b, '\n'))
+	return int64(n), err
+}
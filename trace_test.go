@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTraceExport(t *testing.T) {
+	t.Parallel()
+	var (
+		tr  = &TraceExport{}
+		out = WithTraceExport(io.Discard, tr)
+	)
+	RRSchedule(out, "Round-robin", []Process{
+		{ProcessID: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+	}, 2)
+	SMPSchedule(WithTimeUnit(out, TimeUnit{Name: "µs", Scale: 0.5}), "SMP", []Process{
+		{ProcessID: 1, BurstDuration: 4},
+		{ProcessID: 2, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+	}, SMPParams{CPUs: 2, Quantum: 2})
+
+	var w bytes.Buffer
+	if _, err := tr.WriteTo(&w); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.String(), loadFixture(t, "trace_test.txt"); got != want {
+		t.Errorf("TraceExport.WriteTo() = %v, want %v", got, want)
+	}
+
+}
+
+func TestTraceExport_roundTrip(t *testing.T) {
+	t.Parallel()
+	tr := &TraceExport{}
+	RRSchedule(WithTraceExport(io.Discard, tr), "Round-robin", []Process{
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 2, Priority: 2},
+	}, 2)
+	var w bytes.Buffer
+	if _, err := tr.WriteTo(&w); err != nil {
+		t.Fatal(err)
+	}
+
+	// Read back, the CPU is a thread busy from when the schedule first kept it busy, for as long as it did.
+	got, err := loadChromeTrace(&w, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Process{{ProcessID: 0, BurstDuration: 5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("loadChromeTrace() = %+v, want %+v", got, want)
+	}
+}
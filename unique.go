@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateProcess is returned when a scheduling file gives the same process ID to more than one process.
+var ErrDuplicateProcess = errors.New("duplicate process ID")
+
+// renumbering is a process given a fresh ID because an earlier one already had its own.
+type renumbering struct {
+	from, to int64
+}
+
+// checkUnique reports a process ID given to more than one process, as when a template's copies run into
+// the IDs of the processes after it.
+func checkUnique(processes []Process) error {
+	ids := make(map[int64]bool, len(processes))
+	for _, p := range processes {
+		if ids[p.ProcessID] {
+			return fmt.Errorf("%w: process %d given more than once", ErrDuplicateProcess, p.ProcessID)
+		}
+		ids[p.ProcessID] = true
+	}
+	return nil
+}
+
+// renumberDuplicates gives every process whose ID an earlier process already has a fresh ID above all
+// the IDs in use, including those of spawned children, and returns the changes in order. Dependencies
+// on a duplicated ID are left on its first process.
+func renumberDuplicates(processes []Process) []renumbering {
+	var (
+		ids     = make(map[int64]bool, len(processes))
+		highest int64
+		changes []renumbering
+	)
+	for _, p := range processes {
+		if p.ProcessID > highest {
+			highest = p.ProcessID
+		}
+		for _, s := range p.Spawns {
+			if s.ProcessID > highest {
+				highest = s.ProcessID
+			}
+		}
+	}
+	for i, p := range processes {
+		if !ids[p.ProcessID] {
+			ids[p.ProcessID] = true
+			continue
+		}
+		highest++
+		changes = append(changes, renumbering{from: p.ProcessID, to: highest})
+		processes[i].ProcessID = highest
+	}
+	return changes
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_loadScenario_duplicates(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		f := filepath.Join(dir, name)
+		if err := os.WriteFile(f, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		file     string
+		renumber bool
+		want     []Process
+		wantErr  error
+	}{
+		{
+			name:    "template overlaps",
+			file:    write("template.csv", "1,4,0,2,count=3\n3,2,1,1"),
+			wantErr: ErrDuplicateProcess,
+		},
+		{
+			name:    "thread ID in two processes",
+			format:  "chrome",
+			file:    write("trace.json", `[{"ph": "X", "ts": 0, "dur": 5000, "pid": 1, "tid": 3}, {"ph": "X", "ts": 0, "dur": 5000, "pid": 2, "tid": 3}]`),
+			wantErr: ErrDuplicateProcess,
+		},
+		{
+			name:     "renumbered",
+			file:     write("duplicates.csv", "1,4,0,2\n2,2,1,1,spawn=1:5:1\n1,3,2,1\n2,1,3,1\n"),
+			renumber: true,
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 4, ArrivalTime: 0, Priority: 2},
+				{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1, Priority: 1, Spawns: []Spawn{{After: 1, ProcessID: 5, BurstDuration: 1}}},
+				{ProcessID: 6, BurstDuration: 3, ArrivalTime: 2, Priority: 1},
+				{ProcessID: 7, BurstDuration: 1, ArrivalTime: 3, Priority: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadScenario(tt.format, "", []string{tt.file}, tt.renumber)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadScenario() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got.Processes, tt.want) {
+				t.Errorf("loadScenario() = %+v, want %+v", got.Processes, tt.want)
+			}
+		})
+	}
+}
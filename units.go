@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TimeUnit is what one simulated tick stands for: Scale of the unit Name, so a Scale of 0.5 with
+// Name "ms" shows a tick as half a millisecond. The zero TimeUnit shows bare tick counts.
+type TimeUnit struct {
+	Name  string
+	Scale float64
+}
+
+// ErrUnknownTimeUnit is returned when a time unit name is not recognised or its scale is not positive.
+var ErrUnknownTimeUnit = errors.New("unknown time unit")
+
+// timeUnitNames are the units a tick may be declared in; abstract ticks have no suffix.
+var timeUnitNames = []string{"abstract", "s", "ms", "µs", "us", "ns"}
+
+// ParseTimeUnit returns the time unit with the given name, each tick standing for scale of it.
+func ParseTimeUnit(name string, scale float64) (TimeUnit, error) {
+	if scale <= 0 {
+		return TimeUnit{}, fmt.Errorf("%w: scale must be positive, got %g", ErrUnknownTimeUnit, scale)
+	}
+	for _, n := range timeUnitNames {
+		if !strings.EqualFold(name, n) {
+			continue
+		}
+		switch n {
+		case "abstract":
+			n = ""
+		case "us":
+			n = "µs"
+		}
+		return TimeUnit{Name: n, Scale: scale}, nil
+	}
+	return TimeUnit{}, fmt.Errorf("%w: %q (known: %v)", ErrUnknownTimeUnit, name, timeUnitNames)
+}
+
+// scale is how much of the unit a tick stands for.
+func (u TimeUnit) scale() float64 {
+	if u.Scale <= 0 {
+		return 1
+	}
+	return u.Scale
+}
+
+// format shows t ticks in the unit.
+func (u TimeUnit) format(t int64) string {
+	if u.scale() == 1 {
+		return fmt.Sprint(t) + u.Name
+	}
+	return strconv.FormatFloat(float64(t)*u.scale(), 'f', -1, 64) + u.Name
+}
+
+// formatFloat shows a fractional number of ticks t in the unit, to prec decimal places, or as few as
+// needed when prec is negative.
+func (u TimeUnit) formatFloat(t float64, prec int) string {
+	return strconv.FormatFloat(t*u.scale(), 'f', prec, 64) + u.Name
+}
+
+// formatAverage shows an average of v ticks in the unit to two decimal places, for a table footer.
+func (u TimeUnit) formatAverage(v float64) string {
+	return fmt.Sprintf("%.2f%s", v*u.scale(), u.footer())
+}
+
+// formatFooter shows t ticks in the unit, for a table footer.
+func (u TimeUnit) formatFooter(t int64) string {
+	return u.value(t) + u.footer()
+}
+
+// formatRate shows a rate of v per tick as a rate per unit, for a table footer.
+func (u TimeUnit) formatRate(v float64) string {
+	name := u.footer()
+	if name == "" {
+		name = "t"
+	}
+	return fmt.Sprintf("%.2f/%s", v/u.scale(), name)
+}
+
+// footer is the unit's name as written in table footers, which are upper-cased: a capital µ would read
+// as an M, so microseconds are written us.
+func (u TimeUnit) footer() string {
+	return strings.ReplaceAll(u.Name, "µ", "u")
+}
+
+// value shows t ticks as a bare number of the unit, for machine-readable output.
+func (u TimeUnit) value(t int64) string {
+	if u.scale() == 1 {
+		return strconv.FormatInt(t, 10)
+	}
+	return strconv.FormatFloat(float64(t)*u.scale(), 'f', -1, 64)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseTimeUnit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		unit    string
+		scale   float64
+		want    TimeUnit
+		wantErr error
+	}{
+		{name: "abstract", unit: "abstract", scale: 1, want: TimeUnit{Scale: 1}},
+		{name: "milliseconds", unit: "MS", scale: 0.5, want: TimeUnit{Name: "ms", Scale: 0.5}},
+		{name: "ascii microseconds", unit: "us", scale: 10, want: TimeUnit{Name: "µs", Scale: 10}},
+		{name: "unknown", unit: "fortnight", scale: 1, wantErr: ErrUnknownTimeUnit},
+		{name: "non-positive scale", unit: "ms", scale: 0, wantErr: ErrUnknownTimeUnit},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseTimeUnit(tt.unit, tt.scale)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseTimeUnit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTimeUnit() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTimeUnit(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	FCFSSchedule(WithTimeUnit(&w, TimeUnit{Name: "µs", Scale: 2.5}), "First-come, first-serve", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 2, Deadline: 4},
+	})
+	if got, want := w.String(), loadFixture(t, "units_test.txt"); got != want {
+		t.Errorf("FCFSSchedule() = %v, want %v", got, want)
+	}
+}
+
+func TestWithTimeUnit_reports(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 1, Threads: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 2, Threads: 2},
+	}
+	unit := TimeUnit{Name: "ms", Scale: 2}
+	tests := []struct {
+		name     string
+		schedule func(w io.Writer)
+		want     string
+	}{
+		{
+			name:     "held idle",
+			schedule: func(w io.Writer) { DelayedSJFSchedule(w, "Delay", processes, 2) },
+			want:     "Held idle: 2ms\n",
+		},
+		{
+			name:     "scheduler overhead",
+			schedule: func(w io.Writer) { OverheadSchedule(w, "Overhead", processes, DecisionCost{Decision: 1}) },
+			want:     "Scheduler overhead: 4ms in 2 decisions\n",
+		},
+		{
+			name:     "gang fragmentation",
+			schedule: func(w io.Writer) { GangSchedule(w, "Gang", processes, 3, 2) },
+			want:     "Gang fragmentation: 4ms; Idle time: 10ms\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			tt.schedule(WithTimeUnit(&w, unit))
+			if got := w.String(); !strings.Contains(got, tt.want) {
+				t.Errorf("schedule = %v, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
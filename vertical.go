@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// WithVertical returns a writer to w that has the GANTT charts of schedules written to it laid out as
+// timelines, a line per slice, rather than across the page.
+func WithVertical(w io.Writer) io.Writer {
+	ow := options(w)
+	ow.vertical = true
+	return ow
+}
+
+// outputVertical outputs a GANTT chart as a timeline of a line per slice in order of starting: when it
+// ran, the PID, and the CPU it ran on if the chart ran on several and its queue if it has one, so that
+// long schedules stay readable and can be searched with grep.
+func outputVertical(w io.Writer, gantt []TimeSlice) {
+	var (
+		unit   = unitOf(w)
+		cpus   = cpuCount(gantt)
+		slices = append([]TimeSlice(nil), gantt...)
+		tw     = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	)
+	sort.SliceStable(slices, func(i, j int) bool {
+		if slices[i].Start != slices[j].Start {
+			return slices[i].Start < slices[j].Start
+		}
+		return slices[i].CPU < slices[j].CPU
+	})
+	for _, s := range slices {
+		fields := []string{unit.format(s.Start) + "-" + unit.format(s.Stop), "P" + sliceLabel(s)}
+		if s.PID < 0 {
+			fields[1] = sliceLabel(s)
+		}
+		if cpus > 1 {
+			fields = append(fields, fmt.Sprintf("%s %d", msg(w, "CPU"), s.CPU))
+		}
+		if s.Queue != "" {
+			fields = append(fields, s.Queue)
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(fields, "\t")+"\t")
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputVertical(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  string
+	}{
+		{
+			name:  "one CPU",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2, Queue: "Q0"}, {PID: heldPID, Start: 2, Stop: 3}, {PID: 12, Start: 3, Stop: 10, Queue: "Q1"}},
+			want: "Gantt schedule\n" +
+				"   0-2    P1  Q0\n" +
+				"   2-3  idle\n" +
+				"  3-10   P12  Q1\n\n",
+		},
+		{
+			name:  "lane per CPU in order of starting",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 4}, {PID: 2, Start: 0, Stop: 4, CPU: 1}},
+			want: "Gantt schedule\n" +
+				"  0-2  P1  CPU 0\n" +
+				"  0-4  P2  CPU 1\n" +
+				"  2-4  P3  CPU 0\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputGantt(WithVertical(&w), tt.gantt)
+			if got := w.String(); got != tt.want {
+				t.Errorf("outputGantt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
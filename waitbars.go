@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// waitBarWidth is how many columns the bar of the longest wait takes.
+const waitBarWidth = 40
+
+// WithWaitBars returns a writer to w that has schedules written to it follow their schedule tables with a
+// bar chart of how long each process waited, so that outliers stand out.
+func WithWaitBars(w io.Writer) io.Writer {
+	ow := options(w)
+	ow.waitBars = true
+	return ow
+}
+
+// outputWaitBars outputs a bar of #s per process of a schedule as long as it waited, the longest wait's
+// waitBarWidth columns and any other wait at least one, if w asks for them. Bars are coloured by PID if w
+// is to colour charts.
+func outputWaitBars(w io.Writer, r Result) {
+	o := options(w)
+	if !o.waitBars || len(r.Processes) == 0 {
+		return
+	}
+	var (
+		labels  = make([]string, len(r.Processes))
+		longest int64
+		width   int
+	)
+	for i, p := range r.Processes {
+		if labels[i] = fmt.Sprintf("P%d", p.ProcessID); len(labels[i]) > width {
+			width = len(labels[i])
+		}
+		if p.Wait > longest {
+			longest = p.Wait
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, msg(w, "Waiting time"))
+	for i, p := range r.Processes {
+		n := 0
+		if longest > 0 {
+			n = int((p.Wait*waitBarWidth + longest/2) / longest)
+		}
+		if n == 0 && p.Wait > 0 {
+			n = 1
+		}
+		bar := strings.Repeat("#", n)
+		if o.color && n > 0 {
+			bar = ansiColor(p.ProcessID) + bar + ansiReset
+		}
+		line := fmt.Sprintf("%-*s |%s %s", width, labels[i], bar, o.unit.format(p.Wait))
+		if p.Killed {
+			line += " (killed)"
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+	_, _ = fmt.Fprintln(w)
+}
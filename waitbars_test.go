@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func Test_outputWaitBars(t *testing.T) {
+	t.Parallel()
+	r := Result{Processes: []ProcessResult{
+		{Process: Process{ProcessID: 1}, Wait: 8},
+		{Process: Process{ProcessID: 2}, Wait: 0},
+		{Process: Process{ProcessID: 10}, Wait: 1, Killed: true},
+		{Process: Process{ProcessID: 3}, Wait: 3},
+	}}
+	tests := []struct {
+		name    string
+		w       func(w *bytes.Buffer) io.Writer
+		wantOut string
+	}{
+		{
+			name:    "off",
+			w:       func(w *bytes.Buffer) io.Writer { return w },
+			wantOut: "",
+		},
+		{
+			name: "bars",
+			w: func(w *bytes.Buffer) io.Writer {
+				return WithWaitBars(WithTimeUnit(w, TimeUnit{Name: "ms"}))
+			},
+			wantOut: "Waiting time\n" +
+				"P1  |######################################## 8ms\n" +
+				"P2  | 0ms\n" +
+				"P10 |##### 1ms (killed)\n" +
+				"P3  |############### 3ms\n\n",
+		},
+		{
+			name: "colour",
+			w: func(w *bytes.Buffer) io.Writer {
+				return WithColor(WithWaitBars(w))
+			},
+			wantOut: "Waiting time\n" +
+				"P1  |" + ansiColor(1) + "########################################" + ansiReset + " 8\n" +
+				"P2  | 0\n" +
+				"P10 |" + ansiColor(10) + "#####" + ansiReset + " 1 (killed)\n" +
+				"P3  |" + ansiColor(3) + "###############" + ansiReset + " 3\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputWaitBars(tt.w(&w), r)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("outputWaitBars() = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// WithThroughputWindows returns a writer to w that has schedules written to it follow their schedule tables
+// with how many processes completed in each window of window ticks, so that warm-up and convoys show as
+// throughput changing over time.
+func WithThroughputWindows(w io.Writer, window int64) io.Writer {
+	ow := options(w)
+	ow.window = window
+	return ow
+}
+
+// throughputWindow is how many processes of a schedule completed after start and by stop.
+type throughputWindow struct {
+	start, stop int64
+	completed   int
+}
+
+// throughputWindows returns the processes of r completed in each window of window ticks, from the one the
+// first arrived in to the one the last completed in, leaving out killed processes when the averages do.
+// Windows start at multiples of window, and a completion at the boundary of two counts in the earlier one.
+func throughputWindows(r Result, window int64) []throughputWindow {
+	var (
+		counted                      int
+		firstArrival, lastCompletion int64
+	)
+	index := func(t int64) int64 {
+		if t <= 0 {
+			return 0
+		}
+		return (t - 1) / window
+	}
+	for _, p := range r.Processes {
+		if p.Killed && r.ExcludeKilled {
+			continue
+		}
+		if counted == 0 || p.ArrivalTime < firstArrival {
+			firstArrival = p.ArrivalTime
+		}
+		if p.Completion > lastCompletion {
+			lastCompletion = p.Completion
+		}
+		counted++
+	}
+	if counted == 0 {
+		return nil
+	}
+
+	first := firstArrival / window
+	windows := make([]throughputWindow, index(lastCompletion)-first+1)
+	for i := range windows {
+		windows[i].start = (first + int64(i)) * window
+		windows[i].stop = windows[i].start + window
+	}
+	for _, p := range r.Processes {
+		if p.Killed && r.ExcludeKilled {
+			continue
+		}
+		if i := index(p.Completion) - first; i >= 0 {
+			windows[i].completed++
+		}
+	}
+	return windows
+}
+
+// outputThroughputWindows outputs a table of the processes of a schedule completed in each window, and
+// the throughput that makes, if w asks for it.
+func outputThroughputWindows(w io.Writer, r Result) {
+	o := options(w)
+	if o.window <= 0 {
+		return
+	}
+	windows := throughputWindows(r, o.window)
+	if len(windows) == 0 {
+		return
+	}
+	rows := make([][]string, len(windows))
+	for i, win := range windows {
+		rows[i] = []string{fmt.Sprintf("%s-%s", o.unit.format(win.start), o.unit.format(win.stop)),
+			fmt.Sprint(win.completed), o.unit.formatRate(float64(win.completed) / float64(o.window))}
+	}
+	_, _ = fmt.Fprintln(w, msg(w, "Throughput over time"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(msgs(w, "Window", "Completed", "Throughput"))
+	table.SetColumnAlignment(sideBySideAlignment(3))
+	table.AppendBulk(rows)
+	table.Render()
+}
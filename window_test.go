@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_outputThroughputWindows(t *testing.T) {
+	t.Parallel()
+	r := Result{Processes: []ProcessResult{
+		{Process: Process{ProcessID: 1, ArrivalTime: 5}, Completion: 8},
+		{Process: Process{ProcessID: 2, ArrivalTime: 6}, Completion: 9, Killed: true},
+		{Process: Process{ProcessID: 3, ArrivalTime: 6}, Completion: 14},
+	}}
+	tests := []struct {
+		name    string
+		w       func(w *bytes.Buffer) io.Writer
+		r       Result
+		wantOut string
+	}{
+		{
+			name: "off",
+			w:    func(w *bytes.Buffer) io.Writer { return w },
+			r:    r,
+		},
+		{
+			name: "windows",
+			w: func(w *bytes.Buffer) io.Writer {
+				return WithThroughputWindows(WithTimeUnit(w, TimeUnit{Name: "ms"}), 4)
+			},
+			r: r,
+			wantOut: strings.Join([]string{
+				"Throughput over time",
+				"+-----------+-----------+------------+",
+				"|  WINDOW   | COMPLETED | THROUGHPUT |",
+				"+-----------+-----------+------------+",
+				"| 4ms-8ms   |         1 |    0.25/ms |",
+				"| 8ms-12ms  |         1 |    0.25/ms |",
+				"| 12ms-16ms |         1 |    0.25/ms |",
+				"+-----------+-----------+------------+",
+				"",
+			}, "\n"),
+		},
+		{
+			name: "killed excluded",
+			w: func(w *bytes.Buffer) io.Writer {
+				return WithThroughputWindows(w, 4)
+			},
+			r: Result{Processes: r.Processes, ExcludeKilled: true},
+			wantOut: strings.Join([]string{
+				"Throughput over time",
+				"+--------+-----------+------------+",
+				"| WINDOW | COMPLETED | THROUGHPUT |",
+				"+--------+-----------+------------+",
+				"| 4-8    |         1 |     0.25/t |",
+				"| 8-12   |         0 |     0.00/t |",
+				"| 12-16  |         1 |     0.25/t |",
+				"+--------+-----------+------------+",
+				"",
+			}, "\n"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputThroughputWindows(tt.w(&w), tt.r)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("outputThroughputWindows() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
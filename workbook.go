@@ -0,0 +1,303 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Parts of a workbook as written, besides its sheets.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`%s</Types>`
+	xlsxSheetType   = `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`
+	xlsxPackageRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font/><font><b/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border/></borders>` +
+		`<cellStyleXfs count="1"><xf/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf/><xf fontId="1" applyFont="1"/></cellXfs>` +
+		`</styleSheet>`
+
+	// xlsxSheetName is the longest name Excel gives a sheet.
+	xlsxSheetName = 31
+)
+
+// ResultsWorkbook collects the schedules written to a writer to write them as one Excel workbook: a
+// summary sheet of the averages of each, a sheet per schedule of its processes' timing, and a sheet of
+// the slices of all their GANTT charts, so results can be graded and annotated in a spreadsheet. Times
+// are numbers of the writer's time unit.
+type ResultsWorkbook struct {
+	schedules []workbookSchedule
+}
+
+// workbookSchedule is what a workbook holds of one schedule.
+type workbookSchedule struct {
+	title string
+	r     Result
+	unit  TimeUnit
+}
+
+// WithResultsWorkbook returns a writer to w that has schedules written to it also add their results to b.
+func WithResultsWorkbook(w io.Writer, b *ResultsWorkbook) io.Writer {
+	ow := options(w)
+	ow.workbook = b
+	return ow
+}
+
+// add adds a schedule titled title, with times shown in unit u.
+func (b *ResultsWorkbook) add(title string, r Result, u TimeUnit) {
+	b.schedules = append(b.schedules, workbookSchedule{title: title, r: r, unit: u})
+}
+
+// xlsxCell is a cell of a sheet being written: a number, or else text.
+type xlsxCell struct {
+	v      string
+	number bool
+}
+
+func xlsxNumber(v string) xlsxCell { return xlsxCell{v: v, number: true} }
+
+func xlsxFloat(f float64) xlsxCell { return xlsxNumber(strconv.FormatFloat(f, 'g', -1, 64)) }
+
+func xlsxString(s string) xlsxCell { return xlsxCell{v: s} }
+
+// workbookSheet is a sheet being written, its first row a header.
+type workbookSheet struct {
+	name string
+	rows [][]xlsxCell
+}
+
+// sheets returns the sheets of the workbook.
+func (b *ResultsWorkbook) sheets() []workbookSheet {
+	var unit TimeUnit
+	if len(b.schedules) > 0 {
+		unit = b.schedules[0].unit
+	}
+	var (
+		names   = make(map[string]bool)
+		summary = workbookSheet{name: xlsxUniqueName("Summary", names), rows: [][]xlsxCell{xlsxHeader("Schedule", "Processes",
+			xlsxTimeHeader("Average wait", unit), xlsxTimeHeader("Average turnaround", unit),
+			"Average slowdown", xlsxRateHeader("Throughput", unit), xlsxTimeHeader("Makespan", unit), "Context switches")}}
+		gantt = workbookSheet{name: xlsxUniqueName("Gantt", names), rows: [][]xlsxCell{xlsxHeader("Schedule", "CPU", "PID",
+			xlsxTimeHeader("Start", unit), xlsxTimeHeader("Stop", unit), "Queue")}}
+		sheets []workbookSheet
+	)
+
+	for _, sc := range b.schedules {
+		u := sc.unit
+		s := summarize(sc.r)
+		summary.rows = append(summary.rows, []xlsxCell{
+			xlsxString(sc.title),
+			xlsxNumber(strconv.Itoa(len(sc.r.Processes))),
+			xlsxFloat(s.wait * u.scale()),
+			xlsxFloat(s.turnaround * u.scale()),
+			xlsxFloat(s.slowdown),
+			xlsxFloat(s.throughput / u.scale()),
+			xlsxNumber(u.value(s.makespan)),
+			xlsxNumber(strconv.Itoa(contextSwitches(sc.r.Gantt))),
+		})
+
+		header := []string{"ID", "Priority", xlsxTimeHeader("Burst", u), xlsxTimeHeader("Arrival", u),
+			xlsxTimeHeader("Wait", u), xlsxTimeHeader("Turnaround", u), xlsxTimeHeader("Completion", u),
+			xlsxTimeHeader("Response", u), "Slowdown"}
+		for _, c := range sc.r.Columns {
+			header = append(header, c.Header)
+		}
+		sheet := workbookSheet{name: xlsxUniqueName(sc.title, names), rows: [][]xlsxCell{xlsxHeader(header...)}}
+		for i, p := range sc.r.Processes {
+			response, slowed := xlsxString(""), xlsxString("")
+			if t, ok := responseTime(p, sc.r.Gantt); ok {
+				response = xlsxNumber(u.value(t))
+			}
+			if sd, ok := slowdown(p); ok {
+				slowed = xlsxFloat(sd)
+			}
+			row := []xlsxCell{
+				xlsxNumber(strconv.FormatInt(p.ProcessID, 10)),
+				xlsxNumber(strconv.FormatInt(p.Priority, 10)),
+				xlsxNumber(u.value(p.BurstDuration)),
+				xlsxNumber(u.value(p.ArrivalTime)),
+				xlsxNumber(u.value(p.Wait)),
+				xlsxNumber(u.value(p.Turnaround)),
+				xlsxNumber(u.value(p.Completion)),
+				response,
+				slowed,
+			}
+			for _, c := range sc.r.Columns {
+				row = append(row, xlsxString(c.Values[i]))
+			}
+			sheet.rows = append(sheet.rows, row)
+		}
+		sheets = append(sheets, sheet)
+
+		for _, sl := range sc.r.Gantt {
+			pid := xlsxString(sliceLabel(sl))
+			if sl.PID >= 0 {
+				pid = xlsxNumber(strconv.FormatInt(sl.PID, 10))
+			}
+			gantt.rows = append(gantt.rows, []xlsxCell{
+				xlsxString(sc.title),
+				xlsxNumber(strconv.Itoa(sl.CPU)),
+				pid,
+				xlsxNumber(u.value(sl.Start)),
+				xlsxNumber(u.value(sl.Stop)),
+				xlsxString(sl.Queue),
+			})
+		}
+	}
+	return append(append([]workbookSheet{summary}, sheets...), gantt)
+}
+
+// WriteTo writes the workbook as an Excel .xlsx file.
+func (b *ResultsWorkbook) WriteTo(w io.Writer) (int64, error) {
+	var (
+		sheets             = b.sheets()
+		types, names, rels strings.Builder
+		buf                bytes.Buffer
+		zw                 = zip.NewWriter(&buf)
+	)
+	for i, sheet := range sheets {
+		n := i + 1
+		fmt.Fprintf(&types, xlsxSheetType, n)
+		fmt.Fprintf(&names, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscape(sheet.name), n, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+	fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1)
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", fmt.Sprintf(xlsxContentTypes, types.String())},
+		{"_rels/.rels", xlsxPackageRels},
+		{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` +
+			names.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` + rels.String() + `</Relationships>`},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, struct{ name, body string }{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return 0, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+	return buf.WriteTo(w)
+}
+
+// xml is the worksheet part of the sheet, its header row in bold.
+func (s workbookSheet) xml() string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range s.rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		style := ""
+		if i == 0 {
+			style = ` s="1"`
+		}
+		for col, c := range row {
+			ref := xlsxColumnName(col) + strconv.Itoa(i+1)
+			switch {
+			case c.number:
+				fmt.Fprintf(&b, `<c r="%s"%s><v>%s</v></c>`, ref, style, c.v)
+			case c.v != "":
+				fmt.Fprintf(&b, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xlsxEscape(c.v))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// xlsxHeader is a header row of text cells.
+func xlsxHeader(names ...string) []xlsxCell {
+	row := make([]xlsxCell, len(names))
+	for i, name := range names {
+		row[i] = xlsxString(name)
+	}
+	return row
+}
+
+// xlsxTimeHeader is the header of a column of times in unit u, naming the unit if it has one.
+func xlsxTimeHeader(name string, u TimeUnit) string {
+	if u.Name == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, u.Name)
+}
+
+// xlsxRateHeader is the header of a column of rates per unit u.
+func xlsxRateHeader(name string, u TimeUnit) string {
+	per := u.Name
+	if per == "" {
+		per = "tick"
+	}
+	return fmt.Sprintf("%s (per %s)", name, per)
+}
+
+// xlsxUniqueName returns a sheet name Excel accepts for title that is not yet in names, and adds it: the
+// characters it forbids replaced, cut to its longest name, and numbered if need be.
+func xlsxUniqueName(title string, names map[string]bool) string {
+	name := strings.Trim(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '_'
+		}
+		return r
+	}, title), "'")
+	if name == "" {
+		name = "Schedule"
+	}
+	cut := func(s string, n int) string {
+		if r := []rune(s); len(r) > n {
+			return string(r[:n])
+		}
+		return s
+	}
+	unique := cut(name, xlsxSheetName)
+	for i := 2; names[strings.ToLower(unique)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		unique = strings.TrimRight(cut(name, xlsxSheetName-len(suffix)), " ") + suffix
+	}
+	names[strings.ToLower(unique)] = true
+	return unique
+}
+
+// xlsxColumnName is the letters naming the column numbered col from 0, the inverse of xlsxColumn.
+func xlsxColumnName(col int) string {
+	var name []byte
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = append([]byte{byte('A' + (col-1)%26)}, name...)
+	}
+	return string(name)
+}
+
+// xlsxEscape escapes s for XML text or attributes.
+func xlsxEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResultsWorkbook(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+	}
+	book := &ResultsWorkbook{}
+	w := WithResultsWorkbook(WithTimeUnit(discardText(&bytes.Buffer{}), TimeUnit{Name: "ms"}), book)
+	outputResult(w, "Round-robin", roundRobin(processes, 2))
+	outputResult(w, "Gantt", simulate(processes, policy{pick: firstReady}))
+
+	var b bytes.Buffer
+	if _, err := book.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first sheet is found as when loading processes, and the rest by their part names.
+	if rows, err := xlsxFirstSheet(zr); err != nil || rows[0].cells[0] != "Schedule" {
+		t.Fatalf("xlsxFirstSheet() = %v, %v, want the summary", rows, err)
+	}
+	var wb struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := xlsxPart(zr, "xl/workbook.xml", &wb, true); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range wb.Sheets {
+		names = append(names, s.Name)
+	}
+	if want := []string{"Summary", "Round-robin", "Gantt (2)", "Gantt"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("sheet names = %v, want %v", names, want)
+	}
+
+	want := [][]string{
+		{
+			"Schedule,Processes,Average wait (ms),Average turnaround (ms),Average slowdown,Throughput (per ms),Makespan (ms),Context switches",
+			"Round-robin,2,1.5,4,1.5833333333333335,0.4,5,2",
+			"Gantt,2,1,3.5,1.5,0.4,5,1",
+		},
+		{
+			"ID,Priority,Burst (ms),Arrival (ms),Wait (ms),Turnaround (ms),Completion (ms),Response (ms),Slowdown",
+			"1,2,3,0,2,5,5,0,1.6666666666666667",
+			"2,1,2,1,1,3,4,1,1.5",
+		},
+		{
+			"ID,Priority,Burst (ms),Arrival (ms),Wait (ms),Turnaround (ms),Completion (ms),Response (ms),Slowdown",
+			"1,2,3,0,0,3,3,0,1",
+			"2,1,2,1,2,4,5,2,2",
+		},
+		{
+			"Schedule,CPU,PID,Start (ms),Stop (ms),Queue",
+			"Round-robin,0,1,0,2",
+			"Round-robin,0,2,2,4",
+			"Round-robin,0,1,4,5",
+			"Gantt,0,1,0,3",
+			"Gantt,0,2,3,5",
+		},
+	}
+	for i, sheetWant := range want {
+		var sheet xlsxSheet
+		if err := xlsxPart(zr, fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), &sheet, true); err != nil {
+			t.Fatal(err)
+		}
+		rows, err := xlsxRows(sheet, xlsxSharedStrings{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, row := range rows {
+			got = append(got, strings.Join(row.cells, ","))
+		}
+		if !reflect.DeepEqual(got, sheetWant) {
+			t.Errorf("sheet %d = %q, want %q", i+1, got, sheetWant)
+		}
+	}
+}
+
+func Test_xlsxUniqueName(t *testing.T) {
+	t.Parallel()
+	names := map[string]bool{}
+	for _, tt := range []struct{ title, want string }{
+		{"Round-robin", "Round-robin"},
+		{"round-robin", "round-robin (2)"},
+		{"Round-robin", "Round-robin (3)"},
+		{"SJF [preemptive]: a/b?", "SJF _preemptive__ a_b_"},
+		{"'quoted'", "quoted"},
+		{"", "Schedule"},
+		{"Multi-level feedback queue scheduling", "Multi-level feedback queue sche"},
+		{"Multi-level feedback queue scheduling", "Multi-level feedback queue (2)"},
+	} {
+		if got := xlsxUniqueName(tt.title, names); got != tt.want {
+			t.Errorf("xlsxUniqueName(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func Test_xlsxColumnName(t *testing.T) {
+	t.Parallel()
+	for col, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumnName(col); got != want {
+			t.Errorf("xlsxColumnName(%d) = %q, want %q", col, got, want)
+		}
+		if got := xlsxColumn(want + "1"); got != col {
+			t.Errorf("xlsxColumn(%q) = %d, want %d", want+"1", got, col)
+		}
+	}
+}
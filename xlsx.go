@@ -0,0 +1,251 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// xlsxMaxColumns and xlsxMaxRows are how many columns, A to XFD, and rows a worksheet may have.
+const (
+	xlsxMaxColumns = 16384
+	xlsxMaxRows    = 1048576
+)
+
+type (
+	// xlsxWorkbook lists a workbook's sheets in order.
+	xlsxWorkbook struct {
+		Sheets []struct {
+			RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	// xlsxRelationships locate the parts a workbook refers to.
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	// xlsxSharedStrings are the strings cells of type s index into.
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+	// xlsxText is a string, either plain or in runs of differing formatting.
+	xlsxText struct {
+		T    string `xml:"t"`
+		Runs []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	}
+	// xlsxSheet holds a worksheet's rows of cells.
+	xlsxSheet struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				R      string   `xml:"r,attr"`
+				T      string   `xml:"t,attr"`
+				V      string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+// loadProcessesXLSX reads processes from the first sheet of an Excel workbook, whose first row is a header
+// naming the columns as for CSV. Empty cells are left unset, and problems are located by the sheet's row
+// and column numbers.
+func loadProcessesXLSX(r io.Reader) ([]Process, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading workbook", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading workbook", err)
+	}
+	rows, err := xlsxFirstSheet(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		processes = make([]Process, 0, len(rows))
+		header    []string
+		errs      ParseErrors
+	)
+	for _, row := range rows {
+		if header == nil {
+			var he *ParseError
+			if header, he = headerColumns(row.cells); he != nil {
+				he.Line = row.line
+				return nil, ParseErrors{he}
+			}
+			continue
+		}
+		p, rowErrs := headedProcess(header, row.cells, row.line, len(processes)+1)
+		if len(rowErrs) > 0 {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+		processes = append(processes, p.instances()...)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if err := checkProcesses(processes); err != nil {
+		return nil, err
+	}
+	return processes, nil
+}
+
+// xlsxRow is the text of a row of cells, numbered from 1 as in the sheet.
+type xlsxRow struct {
+	line  int
+	cells []string
+}
+
+// xlsxFirstSheet returns the non-empty rows of the first sheet of a workbook.
+func xlsxFirstSheet(zr *zip.Reader) ([]xlsxRow, error) {
+	var (
+		wb   xlsxWorkbook
+		rels xlsxRelationships
+		sst  xlsxSharedStrings
+	)
+	if err := xlsxPart(zr, "xl/workbook.xml", &wb, true); err != nil {
+		return nil, err
+	}
+	if len(wb.Sheets) == 0 {
+		return nil, fmt.Errorf("%w: workbook has no sheets", ErrInvalidArgs)
+	}
+	if err := xlsxPart(zr, "xl/_rels/workbook.xml.rels", &rels, true); err != nil {
+		return nil, err
+	}
+	if err := xlsxPart(zr, "xl/sharedStrings.xml", &sst, false); err != nil {
+		return nil, err
+	}
+	sheetPart := ""
+	for _, rel := range rels.Relationships {
+		if rel.ID == wb.Sheets[0].RID {
+			sheetPart = path.Join("xl", rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				sheetPart = strings.TrimPrefix(rel.Target, "/")
+			}
+		}
+	}
+	var sheet xlsxSheet
+	if err := xlsxPart(zr, sheetPart, &sheet, true); err != nil {
+		return nil, err
+	}
+	return xlsxRows(sheet, sst)
+}
+
+// xlsxRows returns the text of the non-empty rows of a sheet, whose cells of type s index into sst.
+func xlsxRows(sheet xlsxSheet, sst xlsxSharedStrings) ([]xlsxRow, error) {
+	rows := make([]xlsxRow, 0, len(sheet.Rows))
+	for i, row := range sheet.Rows {
+		out := xlsxRow{line: row.R}
+		if out.line == 0 {
+			out.line = i + 1
+		}
+		if out.line < 0 || out.line > xlsxMaxRows {
+			err := fmt.Errorf("%w: row %d is outside rows 1 to %d", ErrInvalidColumn, out.line, xlsxMaxRows)
+			return nil, ParseErrors{{Line: out.line, Err: err}}
+		}
+		for k, c := range row.Cells {
+			col := xlsxColumn(c.R)
+			if col < 0 {
+				col = k
+			}
+			if col >= xlsxMaxColumns {
+				err := fmt.Errorf("%w: cell is beyond column XFD", ErrInvalidColumn)
+				return nil, ParseErrors{{Line: out.line, Column: col + 1, Value: c.R, Err: err}}
+			}
+			var v string
+			switch c.T {
+			case "s":
+				n, err := strconv.Atoi(c.V)
+				if err != nil || n < 0 || n >= len(sst.Items) {
+					err := fmt.Errorf("%w: no such shared string", ErrInvalidColumn)
+					return nil, ParseErrors{{Line: out.line, Column: col + 1, Value: c.V, Err: err}}
+				}
+				v = sst.Items[n].String()
+			case "inlineStr":
+				v = c.Inline.String()
+			case "", "n":
+				// Whole numbers may be stored as floats, such as 5.0 or 1.2E1.
+				v = c.V
+				if f, err := strconv.ParseFloat(c.V, 64); err == nil && f == float64(int64(f)) {
+					v = strconv.FormatInt(int64(f), 10)
+				}
+			default:
+				v = c.V
+			}
+			for len(out.cells) <= col {
+				out.cells = append(out.cells, "")
+			}
+			out.cells[col] = v
+		}
+		if strings.TrimSpace(strings.Join(out.cells, "")) != "" {
+			rows = append(rows, out)
+		}
+	}
+	return rows, nil
+}
+
+// xlsxPart decodes the XML part of a workbook with the given name into v, leaving v alone if the part is
+// missing and not required.
+func xlsxPart(zr *zip.Reader, name string, v interface{}, required bool) error {
+	f, err := zr.Open(name)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: workbook has no %s", ErrInvalidArgs, name)
+	}
+	defer func() { _ = f.Close() }()
+	if err := xml.NewDecoder(f).Decode(v); err != nil {
+		return fmt.Errorf("%w: reading workbook %s", err, name)
+	}
+	return nil
+}
+
+// xlsxColumn returns the column, numbered from 0, of a cell reference such as B7, or -1 if it has none.
+// A column beyond the last a worksheet may have is returned as xlsxMaxColumns.
+func xlsxColumn(ref string) int {
+	col := 0
+	for i, r := range ref {
+		if r < 'A' || r > 'Z' {
+			if i == 0 {
+				return -1
+			}
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		if col > xlsxMaxColumns {
+			return xlsxMaxColumns
+		}
+	}
+	if col == 0 {
+		return -1
+	}
+	return col - 1
+}
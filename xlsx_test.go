@@ -0,0 +1,130 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// workbook returns an Excel workbook whose first sheet is sheetData, with the given shared strings.
+func workbook(t *testing.T, sharedStrings, sheetData string) *bytes.Reader {
+	t.Helper()
+	parts := map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Processes" sheetId="1" r:id="rId2"/><sheet name="Notes" sheetId="2" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + sheetData + `</sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`,
+	}
+	if sharedStrings != "" {
+		parts["xl/sharedStrings.xml"] = `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` + sharedStrings + `</sst>`
+	}
+
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	for name, body := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(b.Bytes())
+}
+
+func Test_loadProcessesXLSX(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		sharedStrings string
+		sheetData     string
+		want          []Process
+		wantErr       error
+	}{
+		{
+			name:          "header row",
+			sharedStrings: `<si><t>PID</t></si><si><t>Burst</t></si><si><r><t>Arr</t></r><r><t>ival</t></r></si><si><t>class</t></si><si><t>batch</t></si>`,
+			sheetData: `<row r="2"><c r="A2" t="s"><v>0</v></c><c r="B2" t="s"><v>1</v></c><c r="C2" t="s"><v>2</v></c><c r="D2" t="s"><v>3</v></c></row>
+<row r="3"><c r="A3"><v>1</v></c><c r="B3"><v>5.0</v></c><c r="C3"><v>0</v></c><c r="D3" t="s"><v>4</v></c></row>
+<row r="4"><c r="A4"><v>2</v></c><c r="B4"><v>4</v></c><c r="C4"><v>1.2E1</v></c></row>
+<row r="5"><c r="D5" t="inlineStr"><is><t> </t></is></c></row>
+<row r="6"><c r="A6"><v>3</v></c><c r="C6"><v>2</v></c><c r="B6"><v>2</v></c></row>`,
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Class: "batch"},
+				{ProcessID: 2, BurstDuration: 4, ArrivalTime: 12},
+				{ProcessID: 3, BurstDuration: 2, ArrivalTime: 2},
+			},
+		},
+		{
+			name:      "inline strings",
+			sheetData: `<row><c t="inlineStr"><is><t>id</t></is></c><c t="inlineStr"><is><t>burst</t></is></c></row><row><c><v>7</v></c><c><v>3</v></c></row>`,
+			want:      []Process{{ProcessID: 7, BurstDuration: 3}},
+		},
+		{
+			name:      "bad value",
+			sheetData: `<row><c t="inlineStr"><is><t>id</t></is></c><c t="inlineStr"><is><t>burst</t></is></c></row><row><c><v>7</v></c><c t="str"><v>x</v></c></row>`,
+			wantErr:   strconv.ErrSyntax,
+		},
+		{
+			name:      "missing shared string",
+			sheetData: `<row><c t="s"><v>0</v></c></row>`,
+			wantErr:   ErrInvalidColumn,
+		},
+		{
+			name: "last column and row",
+			sheetData: `<row r="1"><c r="A1" t="inlineStr"><is><t>id</t></is></c><c r="B1" t="inlineStr"><is><t>burst</t></is></c></row>
+<row r="2"><c r="A2"><v>1</v></c><c r="B2"><v>2</v></c></row>
+<row r="1048576"><c r="XFD1048576" t="inlineStr"><is><t> </t></is></c></row>`,
+			want: []Process{{ProcessID: 1, BurstDuration: 2}},
+		},
+		{
+			name:      "beyond column XFD",
+			sheetData: `<row r="1"><c r="XFE1"><v>1</v></c></row>`,
+			wantErr:   ErrInvalidColumn,
+		},
+		{
+			name:      "column reference too long",
+			sheetData: `<row r="1"><c r="ZZZZZZZZZZZZZZZZ1"><v>1</v></c></row>`,
+			wantErr:   ErrInvalidColumn,
+		},
+		{
+			name:      "beyond the last row",
+			sheetData: `<row r="1048577"><c r="A1048577"><v>1</v></c></row>`,
+			wantErr:   ErrInvalidColumn,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadProcessesXLSX(workbook(t, tt.sharedStrings, tt.sheetData))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadProcessesXLSX() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadProcessesXLSX() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadProcessesXLSX_notWorkbook(t *testing.T) {
+	t.Parallel()
+	if _, err := loadProcessesXLSX(bytes.NewReader([]byte("1,5,0,3\n"))); !errors.Is(err, zip.ErrFormat) {
+		t.Errorf("loadProcessesXLSX() error = %v, want %v", err, zip.ErrFormat)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario is a workload together with the name and default parameters of the experiment run on it.
+type Scenario struct {
+	Name string
+	// Quantum and CPUs replace the default time quantum and number of CPUs when positive.
+	Quantum   int64
+	CPUs      int
+	Processes []Process
+}
+
+// quantum is the scenario's time quantum, or the default when it gives none.
+func (s Scenario) quantum() int64 {
+	if s.Quantum > 0 {
+		return s.Quantum
+	}
+	return defaultQuantum
+}
+
+// cpus is the scenario's number of CPUs, or the default when it gives none.
+func (s Scenario) cpus() int {
+	if s.CPUs > 0 {
+		return s.CPUs
+	}
+	return DefaultSMPParams.CPUs
+}
+
+// loadScenarioYAML reads a scenario as a YAML document with an optional name, quantum and cpus, and its
+// processes as a list of the same named fields as JSON input:
+//
+//	name: Interactive burst
+//	quantum: 3
+//	cpus: 2
+//	processes:
+//	  - {id: 1, burst: 5, arrival: 0, priority: 3}
+//	  - {id: 2, burst: 4, arrival: 1, bursts: [2, 1, 2]}
+func loadScenarioYAML(r io.Reader) (Scenario, error) {
+	var doc struct {
+		Name      string                   `yaml:"name"`
+		Quantum   int64                    `yaml:"quantum"`
+		CPUs      int                      `yaml:"cpus"`
+		Processes []map[string]interface{} `yaml:"processes"`
+	}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil {
+		return Scenario{}, fmt.Errorf("%w: reading YAML", err)
+	}
+	if doc.Quantum < 0 || doc.CPUs < 0 {
+		return Scenario{}, fmt.Errorf("%w: quantum and cpus must not be negative", ErrInvalidColumn)
+	}
+	processes, err := processesFromFields(doc.Processes)
+	if err != nil {
+		return Scenario{}, err
+	}
+	return Scenario{Name: doc.Name, Quantum: doc.Quantum, CPUs: doc.CPUs, Processes: processes}, nil
+}
+
+// outputScenario outputs the name and parameters of a scenario that has a name.
+func outputScenario(w io.Writer, s Scenario) {
+	if s.Name == "" {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s: %s; %s: %d; %s: %s; %s: %d\n", msg(w, "Scenario"), s.Name, msg(w, "Processes"), len(s.Processes),
+		msg(w, "Quantum"), unitOf(w).format(s.quantum()), msg(w, "CPUs"), s.cpus())
+}
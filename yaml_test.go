@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadScenarioYAML(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		yaml    string
+		want    Scenario
+		wantErr error
+	}{
+		{
+			name: "scenario",
+			yaml: `
+name: Interactive burst
+quantum: 3
+cpus: 2
+processes:
+  - {id: 1, burst: 5, priority: 3, locks: [[disk, 1, 3]]}
+  - id: 2
+    burst: 1
+    arrival: 1
+    bursts: [2, 1, 2]
+    class: batch
+`,
+			want: Scenario{Name: "Interactive burst", Quantum: 3, CPUs: 2, Processes: []Process{
+				{ProcessID: 1, BurstDuration: 5, Priority: 3, Locks: []Lock{{Resource: "disk", At: 1, Hold: 3}}},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4, Bursts: []int64{2, 1, 2}, Class: "batch"},
+			}},
+		},
+		{
+			name:    "negative quantum",
+			yaml:    "quantum: -1\nprocesses: []\n",
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name:    "unknown process field",
+			yaml:    "processes:\n  - {id: 1, burst: 2, colour: red}\n",
+			wantErr: ErrInvalidColumn,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadScenarioYAML(strings.NewReader(tt.yaml))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadScenarioYAML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadScenarioYAML() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadScenarioYAML_unknownParameter(t *testing.T) {
+	t.Parallel()
+	if _, err := loadScenarioYAML(strings.NewReader("name: x\nquanta: 3\nprocesses: []\n")); err == nil {
+		t.Error("loadScenarioYAML() error = nil, want an error for the unknown quanta")
+	}
+}
+
+func TestExampleScenario(t *testing.T) {
+	t.Parallel()
+	f, err := os.Open("example_scenario.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	load, err := scenarioLoader("", f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := load(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.quantum() != 3 || s.cpus() != 2 || len(s.Processes) != 4 {
+		t.Errorf("example scenario = %+v, want quantum 3, 2 CPUs and 4 processes", s)
+	}
+}